@@ -0,0 +1,56 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// AbortIf returns an incremental that passes through the value of the
+// input incremental, unless the condition incremental is true, in which case
+// it stops the current stabilization by returning [ErrStabilizationAborted].
+//
+// Aborting a stabilization does not call the node's error handlers, and the
+// nodes that have not been recomputed yet (including the [AbortIf] node itself)
+// are left in the recompute heap, so that once the condition is false
+// the graph can be stabilized again to pick up where it left off.
+func AbortIf[A any](scope Scope, cond Incr[bool], input Incr[A]) Incr[A] {
+	return WithinScope(scope, &abortIfIncr[A]{
+		n:     NewNode("abort_if"),
+		cond:  cond,
+		input: input,
+	})
+}
+
+var (
+	_ Incr[string] = (*abortIfIncr[string])(nil)
+	_ IParents     = (*abortIfIncr[string])(nil)
+	_ IStabilize   = (*abortIfIncr[string])(nil)
+	_ fmt.Stringer = (*abortIfIncr[string])(nil)
+)
+
+type abortIfIncr[A any] struct {
+	n     *Node
+	cond  Incr[bool]
+	input Incr[A]
+	value A
+}
+
+func (a *abortIfIncr[A]) Parents() []INode {
+	return []INode{a.cond, a.input}
+}
+
+func (a *abortIfIncr[A]) Node() *Node { return a.n }
+
+func (a *abortIfIncr[A]) Value() A { return a.value }
+
+func (a *abortIfIncr[A]) Stabilize(_ context.Context) error {
+	if a.cond.Value() {
+		return ErrStabilizationAborted
+	}
+	a.value = a.input.Value()
+	return nil
+}
+
+func (a *abortIfIncr[A]) String() string {
+	return a.n.String()
+}
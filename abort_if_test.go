@@ -0,0 +1,118 @@
+package incr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_AbortIf(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	kill := Var(g, false)
+	v := Var(g, "a")
+	a := AbortIf(g, kill, v)
+	var errorHandlerCalls int
+	a.Node().OnError(func(_ context.Context, _ error) {
+		errorHandlerCalls++
+	})
+	m := Map(g, a, ident)
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+
+	kill.Set(true)
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.Equal(t, ErrStabilizationAborted, err)
+	var nodeErr *NodeError
+	testutil.Equal(t, false, errors.As(err, &nodeErr))
+	testutil.Equal(t, 0, errorHandlerCalls)
+	testutil.Equal(t, "a", o.Value())
+	testutil.Equal(t, true, g.recomputeHeap.has(a))
+
+	err = g.Stabilize(ctx)
+	testutil.Equal(t, ErrStabilizationAborted, err)
+	testutil.Equal(t, "a", o.Value())
+
+	kill.Set(false)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b", o.Value())
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+	testutil.Equal(t, 0, errorHandlerCalls)
+}
+
+func Test_AbortIf_clearRecomputeHeapOnError(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphClearRecomputeHeapOnError(true))
+	kill := Var(g, false)
+	v := Var(g, "a")
+	a := AbortIf(g, kill, v)
+	m0 := Map(g, a, ident)
+	m1 := Map(g, Map(g, v, ident), ident)
+	var aborted int
+	m1.Node().OnAborted(func(_ context.Context, _ error) {
+		aborted++
+	})
+	o0 := MustObserve(g, m0)
+	o1 := MustObserve(g, m1)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	kill.Set(true)
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.Equal(t, ErrStabilizationAborted, err)
+	testutil.Equal(t, 0, aborted)
+	testutil.NotEqual(t, 0, g.recomputeHeap.len())
+
+	kill.Set(false)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b", o0.Value())
+	testutil.Equal(t, "b", o1.Value())
+}
+
+func Test_AbortIf_ParallelStabilize(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	kill := Var(g, true)
+	v := Var(g, "a")
+	a := AbortIf(g, kill, v)
+	o := MustObserve(g, a)
+
+	err := g.ParallelStabilize(ctx)
+	testutil.Equal(t, ErrStabilizationAborted, err)
+	testutil.Equal(t, "", o.Value())
+	testutil.Equal(t, true, g.recomputeHeap.has(a))
+
+	kill.Set(false)
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+}
+
+func Test_AbortIf_Step(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	kill := Var(g, true)
+	v := Var(g, "a")
+	a := AbortIf(g, kill, v)
+	_ = MustObserve(g, a)
+
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+	var done bool
+	for !done {
+		done, err = s.Step()
+	}
+	testutil.Equal(t, ErrStabilizationAborted, err)
+	testutil.Equal(t, ErrStabilizationAborted, s.Finish())
+	testutil.Equal(t, true, g.recomputeHeap.has(a))
+}
@@ -1,6 +1,10 @@
 package incr
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 // Always returns an incremental that is always stale and whose
 // children will always be marked for recomputation.
@@ -59,3 +63,89 @@ func (a *alwaysIncr[A]) Node() *Node { return a.n }
 func (a *alwaysIncr[A]) String() string {
 	return a.n.String()
 }
+
+// AlwaysWhen returns an incremental that behaves like [Always] but
+// that is only recomputed each stabilization pass if the given predicate
+// returns true (or if its input has changed).
+//
+// The predicate is evaluated each stabilization pass when the graph
+// decides if the node should be recomputed; if the predicate returns an
+// error, the error is passed to the node's error handlers and
+// stabilization is halted.
+//
+// This is useful for patterns where you want to force a recomputation
+// only once some cheap check passes, e.g. a polling interval elapsing.
+func AlwaysWhen[A any](scope Scope, input Incr[A], shouldRecompute func(context.Context) (bool, error)) Incr[A] {
+	return WithinScope(scope, &alwaysWhenIncr[A]{
+		alwaysIncr: alwaysIncr[A]{
+			n:       NewNode("always_when"),
+			input:   input,
+			parents: []INode{input},
+		},
+		shouldRecompute: shouldRecompute,
+	})
+}
+
+var (
+	_ Incr[any]    = (*alwaysWhenIncr[any])(nil)
+	_ IAlwaysWhen  = (*alwaysWhenIncr[any])(nil)
+	_ IStale       = (*alwaysWhenIncr[any])(nil)
+	_ fmt.Stringer = (*alwaysWhenIncr[any])(nil)
+)
+
+type alwaysWhenIncr[A any] struct {
+	alwaysIncr[A]
+	shouldRecompute func(context.Context) (bool, error)
+}
+
+func (a *alwaysWhenIncr[A]) ShouldRecompute(ctx context.Context) (bool, error) {
+	return a.shouldRecompute(ctx)
+}
+
+// AlwaysThrottled returns an incremental that behaves like [Always] but
+// that is recomputed at most once per a given minimum interval (or if its
+// input has changed), as measured by a given clock.
+//
+// Between intervals the node stays scheduled for recomputation each
+// stabilization pass, but is skipped, such that its children are not recomputed.
+// This is useful to rate limit polling, e.g. checking a file's modification time,
+// if [Graph.Stabilize] is called in a tight loop.
+//
+// If the clock is nil, the current UTC time is used.
+func AlwaysThrottled[A any](scope Scope, input Incr[A], minInterval time.Duration, clock func(context.Context) time.Time) Incr[A] {
+	if clock == nil {
+		clock = func(_ context.Context) time.Time { return time.Now().UTC() }
+	}
+	return WithinScope(scope, &alwaysThrottledIncr[A]{
+		alwaysIncr: alwaysIncr[A]{
+			n:       NewNode("always_throttled"),
+			input:   input,
+			parents: []INode{input},
+		},
+		minInterval: minInterval,
+		clockSource: clock,
+	})
+}
+
+var (
+	_ Incr[any]    = (*alwaysThrottledIncr[any])(nil)
+	_ IAlwaysWhen  = (*alwaysThrottledIncr[any])(nil)
+	_ IStabilize   = (*alwaysThrottledIncr[any])(nil)
+	_ fmt.Stringer = (*alwaysThrottledIncr[any])(nil)
+)
+
+type alwaysThrottledIncr[A any] struct {
+	alwaysIncr[A]
+	minInterval     time.Duration
+	clockSource     func(context.Context) time.Time
+	lastRecomputeAt time.Time
+}
+
+func (a *alwaysThrottledIncr[A]) ShouldRecompute(ctx context.Context) (bool, error) {
+	return a.clockSource(ctx).Sub(a.lastRecomputeAt) >= a.minInterval, nil
+}
+
+func (a *alwaysThrottledIncr[A]) Stabilize(ctx context.Context) error {
+	a.lastRecomputeAt = a.clockSource(ctx)
+	return nil
+}
@@ -2,7 +2,10 @@ package incr
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/wcharczuk/go-incr/testutil"
 )
@@ -50,3 +53,191 @@ func Test_Always(t *testing.T) {
 	testutil.Equal(t, "bar", o.Value())
 	testutil.Equal(t, 3, updates)
 }
+
+func Test_AlwaysWhen(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "foo")
+	var shouldRecompute bool
+	a := AlwaysWhen(g, v, func(_ context.Context) (bool, error) {
+		return shouldRecompute, nil
+	})
+	var recomputes int
+	m := Map(g, a, func(vv string) string {
+		recomputes++
+		return vv
+	})
+	o := MustObserve(g, m)
+
+	testutil.Equal(t, "always_when", a.Node().Kind())
+	_, isAlways := a.(IAlways)
+	testutil.Equal(t, true, isAlways)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", o.Value())
+	testutil.Equal(t, 1, recomputes)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, recomputes)
+
+	shouldRecompute = true
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, recomputes)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, recomputes)
+
+	shouldRecompute = false
+	v.Set("bar")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", o.Value())
+	testutil.Equal(t, 4, recomputes, "input changes should propagate regardless of the predicate")
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 4, recomputes)
+}
+
+func Test_AlwaysWhen_skippedNotCounted(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "foo")
+	var shouldRecompute bool
+	a := AlwaysWhen(g, v, func(_ context.Context) (bool, error) {
+		return shouldRecompute, nil
+	})
+	_ = MustObserve(g, a)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, uint64(1), a.Node().numRecomputes)
+	testutil.Equal(t, true, g.LastStabilizeChanged())
+
+	// the always node is queued but skipped, so it
+	// shouldn't count as having been recomputed.
+	numRecomputed := ExpertGraph(g).NumNodesRecomputed()
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, uint64(1), a.Node().numRecomputes)
+	testutil.Equal(t, numRecomputed, ExpertGraph(g).NumNodesRecomputed())
+	testutil.Equal(t, false, g.LastStabilizeChanged())
+
+	shouldRecompute = true
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, uint64(2), a.Node().numRecomputes)
+	testutil.Equal(t, true, g.LastStabilizeChanged())
+}
+
+func Test_AlwaysWhen_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "foo")
+	var shouldError bool
+	a := AlwaysWhen(g, v, func(_ context.Context) (bool, error) {
+		if shouldError {
+			return false, fmt.Errorf("this is only a test")
+		}
+		return false, nil
+	})
+	var gotError error
+	a.Node().OnError(func(_ context.Context, err error) {
+		gotError = err
+	})
+	var recomputes int
+	m := Map(g, a, func(vv string) string {
+		recomputes++
+		return vv
+	})
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, recomputes)
+
+	shouldError = true
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Error(t, gotError)
+	testutil.Equal(t, "this is only a test", gotError.Error())
+
+	var nodeErr *NodeError
+	testutil.Equal(t, true, errors.As(err, &nodeErr))
+	testutil.Equal(t, a.Node().ID(), nodeErr.ID)
+	testutil.Equal(t, 1, recomputes)
+}
+
+func Test_AlwaysThrottled(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "foo")
+	now := time.Date(2024, 01, 02, 03, 04, 05, 0, time.UTC)
+	a := AlwaysThrottled(g, v, time.Second, func(_ context.Context) time.Time {
+		return now
+	})
+	var recomputes int
+	m := Map(g, a, func(vv string) string {
+		recomputes++
+		return vv
+	})
+	o := MustObserve(g, m)
+
+	testutil.Equal(t, "always_throttled", a.Node().Kind())
+	_, isAlways := a.(IAlways)
+	testutil.Equal(t, true, isAlways)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", o.Value())
+	testutil.Equal(t, 1, recomputes)
+
+	for x := 0; x < 5; x++ {
+		now = now.Add(100 * time.Millisecond)
+		err = g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, 1, recomputes)
+		testutil.Equal(t, true, g.recomputeHeap.has(a), "the node should stay scheduled")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, recomputes)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, recomputes)
+
+	v.Set("bar")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", o.Value())
+	testutil.Equal(t, 3, recomputes, "input changes should propagate regardless of the interval")
+
+	now = now.Add(500 * time.Millisecond)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, recomputes, "the interval should reset when the input changes")
+}
+
+func Test_AlwaysThrottled_defaultClock(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	a := AlwaysThrottled(g, Var(g, "foo"), time.Hour, nil)
+	var recomputes int
+	_ = MustObserve(g, Map(g, a, func(vv string) string {
+		recomputes++
+		return vv
+	}))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, recomputes)
+}
@@ -0,0 +1,22 @@
+package incr
+
+import "context"
+
+// Apply returns an incremental that applies the current value of a function
+// incremental to the current value of an argument incremental, recomputing
+// when either the function or the argument change.
+//
+// If the function value is nil the output is the zero value of the output type.
+//
+// It is largely a "macro" for a [Map2] over the function and the argument.
+func Apply[A, B any](scope Scope, fn Incr[func(A) B], arg Incr[A]) Incr[B] {
+	m := Map2Context(scope, fn, arg, func(_ context.Context, fnv func(A) B, argv A) (output B, _ error) {
+		if fnv == nil {
+			return
+		}
+		output = fnv(argv)
+		return
+	})
+	m.Node().SetKind("apply")
+	return m
+}
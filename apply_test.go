@@ -0,0 +1,41 @@
+package incr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Apply(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	fn := Var(g, strings.ToUpper)
+	arg := Var(g, "foo")
+	m := Map(g, arg, ident)
+	a := Apply(g, fn, m)
+	o := MustObserve(g, a)
+
+	testutil.Matches(t, `apply\[(.*)\]`, fmt.Sprint(a))
+	testutil.Equal(t, 2, a.Node().height)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "FOO", o.Value())
+
+	arg.Set("bar")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "BAR", o.Value())
+
+	fn.Set(func(v string) string { return v + "!" })
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar!", o.Value())
+
+	fn.Set(nil)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "", o.Value())
+}
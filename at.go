@@ -0,0 +1,124 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// At returns an incremental whose value is the element of a given input slice
+// at a given index, e.g. to give each element of a slice its own node.
+//
+// The node is cut off unless the element at the index changes as determined by a
+// given eq function, or the index itself changes, such that replacing the input
+// slice with a copy in which only some elements differ only propagates changes
+// through the nodes for those elements. If eq is nil, [reflect.DeepEqual] is used.
+//
+// If the index is out of range of the input slice the node returns an error,
+// which halts stabilization; use [AtOption] to handle out of range indexes.
+func At[A any](scope Scope, input Incr[[]A], index Incr[int], eq func(A, A) bool) Incr[A] {
+	return WithinScope(scope, &atIncr[A]{
+		n:     NewNode("at"),
+		input: input,
+		index: index,
+		eq:    eq,
+	})
+}
+
+// AtOption returns an incremental whose value is the element of a given input
+// slice at a given index, or [None] if the index is out of range of the slice.
+//
+// It otherwise behaves like [At].
+func AtOption[A any](scope Scope, input Incr[[]A], index Incr[int], eq func(A, A) bool) Incr[Option[A]] {
+	return WithinScope(scope, &atOptionIncr[A]{
+		atIncr: &atIncr[A]{
+			n:          NewNode("at_option"),
+			input:      input,
+			index:      index,
+			eq:         eq,
+			outOfRange: true,
+		},
+	})
+}
+
+var (
+	_ Incr[int]    = (*atIncr[int])(nil)
+	_ IParents     = (*atIncr[int])(nil)
+	_ ICutoff      = (*atIncr[int])(nil)
+	_ IStabilize   = (*atIncr[int])(nil)
+	_ fmt.Stringer = (*atIncr[int])(nil)
+
+	_ Incr[Option[int]] = (*atOptionIncr[int])(nil)
+	_ IParents          = (*atOptionIncr[int])(nil)
+	_ ICutoff           = (*atOptionIncr[int])(nil)
+	_ IStabilize        = (*atOptionIncr[int])(nil)
+	_ fmt.Stringer      = (*atOptionIncr[int])(nil)
+)
+
+type atIncr[A any] struct {
+	n     *Node
+	input Incr[[]A]
+	index Incr[int]
+	eq    func(A, A) bool
+	// outOfRange is set if out of range indexes
+	// yield [None] rather than an error.
+	outOfRange bool
+	// at is the index the value was read from.
+	at    int
+	value Option[A]
+}
+
+func (a *atIncr[A]) Parents() []INode {
+	return []INode{a.input, a.index}
+}
+
+func (a *atIncr[A]) Node() *Node { return a.n }
+
+func (a *atIncr[A]) Value() A { return a.value.Value }
+
+func (a *atIncr[A]) Cutoff(_ context.Context) (bool, error) {
+	if a.n.changedAt == 0 {
+		return false, nil
+	}
+	at := a.index.Value()
+	if at != a.at {
+		return false, nil
+	}
+	next := a.element(at)
+	if next.Valid != a.value.Valid {
+		return false, nil
+	}
+	if !next.Valid {
+		return true, nil
+	}
+	if a.eq != nil {
+		return a.eq(a.value.Value, next.Value), nil
+	}
+	return reflect.DeepEqual(a.value.Value, next.Value), nil
+}
+
+func (a *atIncr[A]) Stabilize(_ context.Context) error {
+	at := a.index.Value()
+	next := a.element(at)
+	if !next.Valid && !a.outOfRange {
+		return fmt.Errorf("at; index %d out of range for slice of length %d", at, len(a.input.Value()))
+	}
+	a.at, a.value = at, next
+	return nil
+}
+
+func (a *atIncr[A]) String() string { return a.n.String() }
+
+func (a *atIncr[A]) element(at int) Option[A] {
+	values := a.input.Value()
+	if at < 0 || at >= len(values) {
+		return None[A]()
+	}
+	return Some(values[at])
+}
+
+type atOptionIncr[A any] struct {
+	*atIncr[A]
+}
+
+func (a *atOptionIncr[A]) Value() Option[A] { return a.value }
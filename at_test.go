@@ -0,0 +1,164 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_At(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, []int{0, 1, 2, 3, 4})
+	index := Var(g, 3)
+	a := At(g, v, index, nil)
+
+	var calls int
+	m := Map(g, a, func(v int) int {
+		calls++
+		return v * 10
+	})
+	om := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, a.Value())
+	testutil.Equal(t, 30, om.Value())
+	testutil.Equal(t, 1, calls)
+
+	v.Set([]int{0, 1, 2, 3, 4})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 30, om.Value())
+	testutil.Equal(t, 1, calls)
+
+	v.Set([]int{5, 6, 7, 3, 8})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 30, om.Value())
+	testutil.Equal(t, 1, calls)
+
+	v.Set([]int{5, 6, 7, 9, 8})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 90, om.Value())
+	testutil.Equal(t, 2, calls)
+
+	index.Set(1)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 60, om.Value())
+	testutil.Equal(t, 3, calls)
+}
+
+func Test_At_onlyChangedElements(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, []int{0, 1, 2, 3, 4})
+	calls := make([]int, 5)
+	observers := make([]ObserveIncr[int], 5)
+	for index := range calls {
+		index := index
+		m := Map(g, At(g, v, Return(g, index), nil), func(v int) int {
+			calls[index]++
+			return v
+		})
+		observers[index] = MustObserve(g, m)
+	}
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{1, 1, 1, 1, 1}, calls)
+
+	v.Set([]int{0, 1, 2, 30, 4})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{1, 1, 1, 2, 1}, calls)
+	testutil.Equal(t, 30, observers[3].Value())
+}
+
+func Test_At_eq(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, []string{"foo", "bar"})
+	a := At(g, v, Return(g, 1), func(a, b string) bool {
+		return len(a) == len(b)
+	})
+	oa := MustObserve(g, a)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", oa.Value())
+
+	v.Set([]string{"foo", "baz"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", oa.Value())
+
+	v.Set([]string{"foo", "buzz"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "buzz", oa.Value())
+}
+
+func Test_At_outOfRange(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, []int{0, 1, 2})
+	index := Var(g, 1)
+	a := At(g, v, index, nil)
+	oa := MustObserve(g, a)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, oa.Value())
+
+	index.Set(3)
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Matches(t, "index 3 out of range for slice of length 3", err.Error())
+	testutil.Equal(t, 1, oa.Value())
+}
+
+func Test_AtOption(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, []int{0, 1, 2})
+	index := Var(g, 1)
+	a := AtOption(g, v, index, nil)
+
+	var calls int
+	m := Map(g, a, func(v Option[int]) Option[int] {
+		calls++
+		return v
+	})
+	om := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, Some(1), om.Value())
+	testutil.Equal(t, 1, calls)
+
+	index.Set(5)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, None[int](), om.Value())
+	testutil.Equal(t, 2, calls)
+
+	v.Set([]int{3, 4, 5, 6})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, None[int](), om.Value())
+	testutil.Equal(t, 2, calls)
+
+	v.Set([]int{3, 4, 5, 6, 7, 8})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, Some(8), om.Value())
+	testutil.Equal(t, 3, calls)
+}
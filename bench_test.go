@@ -3,6 +3,7 @@ package incr
 import (
 	"context"
 	"fmt"
+	"math/bits"
 	"math/rand"
 	"testing"
 )
@@ -247,6 +248,26 @@ func Benchmark_Stabilize_nestedBinds_128(b *testing.B) {
 	benchmarkNestedBinds(128, b)
 }
 
+func Benchmark_Stabilize_constants_1024(b *testing.B) {
+	benchmarkConstants(1024, b)
+}
+
+func Benchmark_Stabilize_constants_reobserve_1024(b *testing.B) {
+	benchmarkConstantsReobserve(1024, b)
+}
+
+func Benchmark_Stabilize_constants_first_10k(b *testing.B) {
+	benchmarkConstantsFirstStabilize(10000, 10, b)
+}
+
+func Benchmark_recomputeHeap_removeMin_10k_1kHeights(b *testing.B) {
+	benchmarkRecomputeHeapRemoveMin(10000, 1000, b)
+}
+
+func Benchmark_recomputeHeap_setIterToMinHeight_10k_1kHeights(b *testing.B) {
+	benchmarkRecomputeHeapSetIterToMinHeight(10000, 1000, b)
+}
+
 func longer(a, b *string) *string {
 	if a == nil && b == nil {
 		return nil
@@ -265,10 +286,37 @@ func longer(a, b *string) *string {
 
 func ref[A any](v A) *A { return &v }
 
+func Benchmark_createDeepGraph_200(b *testing.B) {
+	benchmarkCreateDeepGraph(200, false, b)
+}
+
+func Benchmark_createDeepGraph_preallocateHeightLists_200(b *testing.B) {
+	benchmarkCreateDeepGraph(200, true, b)
+}
+
+func benchmarkCreateDeepGraph(depth int, preallocate bool, b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		var options []GraphOption
+		if preallocate {
+			options = append(options, OptGraphPreallocateHeightListsSize(depth+1))
+		}
+		graph := New(options...)
+		var cursor Incr[int] = Var(graph, 0)
+		for x := 0; x < depth; x++ {
+			cursor = Map(graph, cursor, func(v int) int { return v + 1 })
+		}
+		_ = MustObserve(graph, cursor)
+	}
+}
+
 func makeBenchmarkGraph(size int, preallocate bool) (*Graph, []Incr[*string]) {
 	var options []GraphOption
 	if preallocate {
-		options = append(options, OptGraphPreallocateNodesSize(size<<1))
+		options = append(options,
+			OptGraphPreallocateNodesSize(size<<1),
+			OptGraphPreallocateHeightListsSize(bits.Len(uint(size))+1),
+		)
 	}
 	graph := New(options...)
 	nodes := make([]Incr[*string], size)
@@ -335,6 +383,7 @@ func makeBenchmarkRecombinantGraph(size int) (*Graph, VarIncr[*string], ObserveI
 }
 
 func benchmarkCreateGraph(size int, preallocate bool, b *testing.B) {
+	b.ReportAllocs()
 	for x := 0; x < b.N; x++ {
 		_, _ = makeBenchmarkGraph(size, preallocate)
 	}
@@ -575,3 +624,143 @@ func makeNestedBindGraph(g *Graph, depth int, bindControl VarIncr[int]) ObserveI
 	om := MustObserve(g, m)
 	return om
 }
+
+// makeSparseRecomputeHeapNodes returns nodes spread across
+// a given number of heights, with the heights spaced out so that
+// there are empty heights between each occupied height.
+func makeSparseRecomputeHeapNodes(g *Graph, numNodes, numHeights int) (maxHeight int, nodes []INode) {
+	const spacing = 4
+	maxHeight = numHeights * spacing
+	nodes = make([]INode, numNodes)
+	for x := 0; x < numNodes; x++ {
+		nodes[x] = newHeightIncr(g, (x%numHeights)*spacing)
+	}
+	return
+}
+
+func benchmarkRecomputeHeapRemoveMin(numNodes, numHeights int, b *testing.B) {
+	g := New()
+	maxHeight, nodes := makeSparseRecomputeHeapNodes(g, numNodes, numHeights)
+	rh := newRecomputeHeap(maxHeight)
+	b.ResetTimer()
+	for x := 0; x < b.N; x++ {
+		rh.add(nodes...)
+		for rh.numItems > 0 {
+			_, _ = rh.removeMinUnsafe()
+		}
+	}
+}
+
+func benchmarkRecomputeHeapSetIterToMinHeight(numNodes, numHeights int, b *testing.B) {
+	g := New()
+	maxHeight, nodes := makeSparseRecomputeHeapNodes(g, numNodes, numHeights)
+	rh := newRecomputeHeap(maxHeight)
+	var iter recomputeHeapListIter
+	b.ResetTimer()
+	for x := 0; x < b.N; x++ {
+		rh.add(nodes...)
+		for rh.numItems > 0 {
+			rh.setIterToMinHeight(&iter)
+			for _, ok := iter.Next(); ok; _, ok = iter.Next() {
+			}
+		}
+	}
+}
+
+func makeConstantsGraph(size int) (*Graph, VarIncr[int], Incr[int]) {
+	graph := New()
+	input := Var(graph, 0)
+	inputs := make([]Incr[int], 0, size+1)
+	inputs = append(inputs, input)
+	for x := 0; x < size; x++ {
+		inputs = append(inputs, Return(graph, x))
+	}
+	output := MapN(graph, sum, inputs...)
+	return graph, input, output
+}
+
+func benchmarkConstants(size int, b *testing.B) {
+	graph, input, output := makeConstantsGraph(size)
+	_ = MustObserve(graph, output)
+	ctx := context.Background()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		input.Set(n)
+		if err := graph.Stabilize(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkConstantsReobserve(size int, b *testing.B) {
+	graph, input, output := makeConstantsGraph(size)
+	ctx := context.Background()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		o := MustObserve(graph, output)
+		input.Set(n)
+		if err := graph.Stabilize(ctx); err != nil {
+			b.Fatal(err)
+		}
+		o.Unobserve(ctx)
+	}
+}
+
+func benchmarkConstantsFirstStabilize(size, maps int, b *testing.B) {
+	ctx := context.Background()
+	var recomputes uint64
+	for n := 0; n < b.N; n++ {
+		graph := New()
+		perMap := size / maps
+		outputs := make([]Incr[int], 0, maps)
+		for x := 0; x < maps; x++ {
+			inputs := make([]Incr[int], 0, perMap)
+			for y := 0; y < perMap; y++ {
+				inputs = append(inputs, Constant(graph, x*perMap+y))
+			}
+			outputs = append(outputs, MapN(graph, sum, inputs...))
+		}
+		_ = MustObserve(graph, MapN(graph, sum, outputs...))
+		if err := graph.Stabilize(ctx); err != nil {
+			b.Fatal(err)
+		}
+		recomputes += graph.numNodesRecomputed
+	}
+	b.ReportMetric(float64(recomputes)/float64(b.N), "recomputes/op")
+}
+
+func Benchmark_recomputeHeap_dense_denselyOccupied(b *testing.B) {
+	benchmarkRecomputeHeap(newRecomputeHeap(5001), 1, b)
+}
+
+func Benchmark_recomputeHeap_sparse_denselyOccupied(b *testing.B) {
+	benchmarkRecomputeHeap(newSparseRecomputeHeap(0), 1, b)
+}
+
+func Benchmark_recomputeHeap_dense_sparselyOccupied(b *testing.B) {
+	benchmarkRecomputeHeap(newRecomputeHeap(5001), 125, b)
+}
+
+func Benchmark_recomputeHeap_sparse_sparselyOccupied(b *testing.B) {
+	benchmarkRecomputeHeap(newSparseRecomputeHeap(0), 125, b)
+}
+
+// benchmarkRecomputeHeap adds nodes at 40 heights spaced a given
+// distance apart to a recompute heap and then removes them in height order.
+func benchmarkRecomputeHeap(rh *recomputeHeap, spacing int, b *testing.B) {
+	g := New()
+	var nodes []INode
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 8; y++ {
+			nodes = append(nodes, newHeightIncr(g, x*spacing))
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		rh.add(nodes...)
+		for rh.numItems > 0 {
+			_, _ = rh.removeMinUnsafe()
+		}
+	}
+}
@@ -40,11 +40,30 @@ type BindFunc[A, B any] func(Scope, A) Incr[B]
 // BindContextFunc is the type of bind function.
 type BindContextFunc[A, B any] func(context.Context, Scope, A) (Incr[B], error)
 
+// BindWithDefault is like Bind but the bind delegate can return nil to bind to nothing,
+// in which case the value of the bind is a given default value rather than the zero value.
+//
+// Whether the bind is bound to a node can be checked with [BindIncr.IsBound].
+func BindWithDefault[A, B any](scope Scope, input Incr[A], fn BindFunc[A, B], defaultValue B) BindIncr[B] {
+	main := newBind(scope, input, func(_ context.Context, bs Scope, va A) (Incr[B], error) {
+		return fn(bs, va), nil
+	})
+	main.bind.defaultValue = defaultValue
+	return main
+}
+
 // BindContext is like Bind but allows the bind delegate to take a context and return an error.
 //
 // If an error returned, the bind is aborted, the error listener(s) will fire for the node, and the
 // computation will stop.
+//
+// If the bind delegate returns nil the bind is bound to nothing, and
+// its value is the zero value; see [BindWithDefault].
 func BindContext[A, B any](scope Scope, input Incr[A], fn BindContextFunc[A, B]) BindIncr[B] {
+	return newBind(scope, input, fn)
+}
+
+func newBind[A, B any](scope Scope, input Incr[A], fn BindContextFunc[A, B]) *bindMainIncr[A, B] {
 	bind := &bind[A, B]{
 		graph: scope.scopeGraph(),
 		lhs:   input,
@@ -89,6 +108,9 @@ type BindIncr[A any] interface {
 	IShouldBeInvalidated
 	IBindMain
 	fmt.Stringer
+	// IsBound returns if the bind is currently bound to a node, that is, if
+	// the bind delegate returned a non-nil node the last time it was called.
+	IsBound() bool
 }
 
 // IBindMain holds the methods specific to the bind main node.
@@ -115,13 +137,15 @@ var (
 // bind is a root struct that holds shared
 // information for both the main and the lhs-change.
 type bind[A, B any] struct {
-	graph     *Graph
-	lhs       Incr[A]
-	rhs       Incr[B]
-	rhsNodes  []INode
-	fn        BindContextFunc[A, B]
-	main      *bindMainIncr[A, B]
-	lhsChange *bindLeftChangeIncr[A, B]
+	graph    *Graph
+	lhs      Incr[A]
+	rhs      Incr[B]
+	rhsNodes []INode
+	fn       BindContextFunc[A, B]
+	// defaultValue is the value of the bind if it isn't bound to a node.
+	defaultValue B
+	main         *bindMainIncr[A, B]
+	lhsChange    *bindLeftChangeIncr[A, B]
 }
 
 func (b *bind[A, B]) isTopScope() bool       { return false }
@@ -134,6 +158,10 @@ func (b *bind[A, B]) addScopeNode(n INode) {
 	b.rhsNodes = append(b.rhsNodes, n)
 }
 
+func (b *bind[A, B]) scopeNodes() []INode {
+	return b.rhsNodes
+}
+
 func (b *bind[A, B]) String() string {
 	return fmt.Sprintf("{%v}", b.main)
 }
@@ -167,12 +195,15 @@ func (b *bindMainIncr[A, B]) Stabilize(ctx context.Context) error {
 	if b.bind.rhs != nil {
 		b.value = b.bind.rhs.Value()
 	} else {
-		var zero B
-		b.value = zero
+		b.value = b.bind.defaultValue
 	}
 	return nil
 }
 
+func (b *bindMainIncr[A, B]) IsBound() bool {
+	return b.bind.rhs != nil
+}
+
 func (b *bindMainIncr[A, B]) Invalidate() {
 	for _, n := range b.bind.rhsNodes {
 		GraphForNode(b).invalidateNode(n)
@@ -221,6 +252,13 @@ func (b *bindLeftChangeIncr[A, B]) Stabilize(ctx context.Context) (err error) {
 	if err = GraphForNode(b).changeParent(b.bind.main, oldRhs, b.bind.rhs); err != nil {
 		return err
 	}
+	if tracer := GraphForNode(b).structuredTracer; tracer != nil && !SameNode(oldRhs, b.bind.rhs) {
+		var rhs NodeMetadata
+		if b.bind.rhs != nil {
+			rhs = b.bind.rhs.Node().nodeMetadata()
+		}
+		tracer.OnBind(ctx, b.bind.main.n.nodeMetadata(), rhs)
+	}
 	if oldRhs != nil {
 		// there is a graph configuration option in js that allows
 		// for (2) different behaviors here. the commented out below
@@ -2,6 +2,7 @@ package incr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -449,7 +450,7 @@ func Test_Bind_error(t *testing.T) {
 	_ = MustObserve(g, o)
 	err := g.Stabilize(ctx)
 	testutil.NotNil(t, err)
-	testutil.Equal(t, "this is just a test", err.Error())
+	testutil.Equal(t, "this is just a test", errors.Unwrap(err).Error())
 }
 
 func Test_Bind_nested(t *testing.T) {
@@ -1398,3 +1399,78 @@ func Test_Bind_errors(t *testing.T) {
 	testutil.NotNil(t, o.Value())
 	testutil.Equal(t, *o.Value(), 3)
 }
+
+func Test_BindWithDefault(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	bound := Var(g, true)
+	value := Var(g, "hello")
+	b := BindWithDefault(g, bound, func(bs Scope, bound bool) Incr[string] {
+		if bound {
+			return Map(bs, value, ident)
+		}
+		return nil
+	}, "default")
+
+	var calls int
+	m := Map(g, b, func(v string) string {
+		calls++
+		return v
+	})
+	om := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, b.IsBound())
+	testutil.Equal(t, "hello", om.Value())
+	testutil.Equal(t, 1, calls)
+
+	bound.Set(false)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, b.IsBound())
+	testutil.Equal(t, "default", b.Value())
+	testutil.Equal(t, "default", om.Value())
+	testutil.Equal(t, 2, calls)
+
+	// the bind is no longer linked to the value
+	value.Set("world")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, b.IsBound())
+	testutil.Equal(t, "default", om.Value())
+	testutil.Equal(t, 2, calls)
+
+	bound.Set(true)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, b.IsBound())
+	testutil.Equal(t, "world", om.Value())
+	testutil.Equal(t, 3, calls)
+}
+
+func Test_Bind_nil_IsBound(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	bound := Var(g, false)
+	b := Bind(g, bound, func(bs Scope, bound bool) Incr[string] {
+		if bound {
+			return Return(bs, "hello")
+		}
+		return nil
+	})
+	ob := MustObserve(g, b)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, b.IsBound())
+	testutil.Equal(t, "", ob.Value())
+
+	bound.Set(true)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, b.IsBound())
+	testutil.Equal(t, "hello", ob.Value())
+}
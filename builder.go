@@ -0,0 +1,77 @@
+package incr
+
+import "context"
+
+// Wrap returns a [Builder] for a given incremental, which can be used
+// to chain node constructors in method style, e.g.
+//
+//	o := incr.Wrap(g, v).Map(strings.TrimSpace).Map2(suffix, concat).MustObserve()
+//
+// Each method calls the corresponding constructor within the given scope,
+// and does not change the semantics of the nodes created.
+func Wrap[A any](scope Scope, input Incr[A]) Builder[A] {
+	return Builder[A]{scope: scope, incr: input}
+}
+
+// Builder is a wrapper around an incremental that enables chaining
+// node constructors as methods.
+//
+// Because methods cannot have type parameters, the methods on [Builder] produce
+// incrementals of the same type as the input; to change the type use [BuilderMap].
+type Builder[A any] struct {
+	scope Scope
+	incr  Incr[A]
+}
+
+// BuilderMap applies a function that changes the type of a
+// builder's incremental with [Map], and returns a new builder.
+func BuilderMap[A, B any](b Builder[A], fn func(A) B) Builder[B] {
+	return Wrap(b.scope, Map(b.scope, b.incr, fn))
+}
+
+// Unwrap returns the incremental the builder wraps.
+func (b Builder[A]) Unwrap() Incr[A] {
+	return b.incr
+}
+
+// Map wraps the builder's incremental with a [Map] node.
+func (b Builder[A]) Map(fn func(A) A) Builder[A] {
+	return Wrap(b.scope, Map(b.scope, b.incr, fn))
+}
+
+// MapContext wraps the builder's incremental with a [MapContext] node.
+func (b Builder[A]) MapContext(fn func(context.Context, A) (A, error)) Builder[A] {
+	return Wrap(b.scope, MapContext(b.scope, b.incr, fn))
+}
+
+// Map2 wraps the builder's incremental and another input with a [Map2] node.
+//
+// The builder's incremental is passed as the first argument of the function.
+func (b Builder[A]) Map2(other Incr[A], fn func(A, A) A) Builder[A] {
+	return Wrap(b.scope, Map2(b.scope, b.incr, other, fn))
+}
+
+// Cutoff wraps the builder's incremental with a [Cutoff] node.
+func (b Builder[A]) Cutoff(fn CutoffFunc[A]) Builder[A] {
+	return Wrap(b.scope, Cutoff(b.scope, b.incr, fn))
+}
+
+// Watch wraps the builder's incremental with a [Watch] node.
+//
+// The watch node's values can be read by asserting the
+// unwrapped incremental as a [WatchIncr].
+func (b Builder[A]) Watch(opts ...WatchOption) Builder[A] {
+	return Wrap[A](b.scope, Watch(b.scope, b.incr, opts...))
+}
+
+// Observe observes the builder's incremental with [Observe] in
+// the builder scope's graph, ending the chain.
+func (b Builder[A]) Observe() (ObserveIncr[A], error) {
+	return Observe(b.scope.scopeGraph(), b.incr)
+}
+
+// MustObserve observes the builder's incremental with [MustObserve] in
+// the builder scope's graph, ending the chain.
+func (b Builder[A]) MustObserve() ObserveIncr[A] {
+	return MustObserve(b.scope.scopeGraph(), b.incr)
+}
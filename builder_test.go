@@ -0,0 +1,86 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Builder(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "  hello ")
+	suffix := Var(g, "!")
+
+	var cutoffs int
+	w := Wrap(g, v).
+		Map(strings.TrimSpace).
+		MapContext(func(_ context.Context, vv string) (string, error) {
+			return strings.ToUpper(vv), nil
+		}).
+		Cutoff(func(oldv, newv string) bool {
+			if oldv == newv {
+				cutoffs++
+				return true
+			}
+			return false
+		}).
+		Map2(suffix, concat).
+		Watch()
+	o := w.MustObserve()
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "HELLO!", o.Value())
+
+	testutil.Equal(t, "watch", w.Unwrap().Node().Kind())
+	testutil.Equal(t, "map2", w.Unwrap().Node().Parents()[0].Node().Kind())
+
+	v.Set("hello")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, cutoffs)
+
+	suffix.Set("?")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "HELLO?", o.Value())
+	testutil.Equal(t, []string{"HELLO!", "HELLO?"}, w.Unwrap().(WatchIncr[string]).Values())
+}
+
+func Test_Builder_scope(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "foo")
+	b := Bind(g, v, func(bs Scope, vv string) Incr[string] {
+		return Wrap(bs, Return(bs, vv)).Map(strings.ToUpper).Unwrap()
+	})
+	o, err := Wrap(g, b).Observe()
+	testutil.NoError(t, err)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "FOO", o.Value())
+	rhs := b.(*bindMainIncr[string, string]).bind.rhs
+	testutil.Equal(t, "map", rhs.Node().Kind())
+	testutil.Equal(t, false, rhs.Node().createdIn.isTopScope(), "the map should be created in the bind scope")
+}
+
+func Test_BuilderMap(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, 2)
+	o := BuilderMap(Wrap(g, v).Map(func(vv int) int { return vv * 2 }), func(vv int) string {
+		return fmt.Sprint(vv)
+	}).Map(func(vv string) string { return vv + "!" }).MustObserve()
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "4!", o.Value())
+}
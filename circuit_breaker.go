@@ -0,0 +1,113 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// CircuitBreaker returns an incremental that passes through the value of a given
+// input incremental while the input is healthy, and that stops the input from being
+// recomputed after the input, or the nodes it depends on, return errors in a given
+// number of consecutive stabilizations.
+//
+// Once the threshold is reached the circuit is "open"; the breaker unlinks the input
+// at the end of the stabilization, keeps the last value the input successfully computed,
+// and no longer recomputes the input (unless another necessary node depends on it).
+// The errors returned still call the error handlers of the nodes that returned them,
+// and are returned by stabilization, up to and including the error that opens the circuit.
+//
+// Use [CircuitBreakerIncr.Reset] to close the circuit and link the input again.
+//
+// A threshold less than one is treated as one.
+func CircuitBreaker[A any](scope Scope, input Incr[A], threshold int) CircuitBreakerIncr[A] {
+	return WithinScope(scope, &circuitBreakerIncr[A]{
+		n:         NewNode("circuit_breaker"),
+		input:     input,
+		threshold: max(threshold, 1),
+	})
+}
+
+// CircuitBreakerIncr is a type of incremental that stops recomputing its
+// input after repeated errors.
+type CircuitBreakerIncr[A any] interface {
+	Incr[A]
+	// IsOpen returns if the circuit is open, that is, if the input
+	// has errored enough times that it's no longer recomputed.
+	IsOpen() bool
+	// Reset closes the circuit, resetting the count of consecutive
+	// errors and linking the input again such that it's recomputed
+	// on the next stabilization.
+	//
+	// It should not be called during stabilization.
+	Reset() error
+}
+
+var (
+	_ CircuitBreakerIncr[string] = (*circuitBreakerIncr[string])(nil)
+	_ IParents                   = (*circuitBreakerIncr[string])(nil)
+	_ IStabilize                 = (*circuitBreakerIncr[string])(nil)
+	_ iAncestorError             = (*circuitBreakerIncr[string])(nil)
+	_ fmt.Stringer               = (*circuitBreakerIncr[string])(nil)
+)
+
+type circuitBreakerIncr[A any] struct {
+	n         *Node
+	input     Incr[A]
+	threshold int
+	errors    int
+	open      bool
+	value     A
+}
+
+func (cb *circuitBreakerIncr[A]) Parents() []INode {
+	if cb.open {
+		return nil
+	}
+	return []INode{cb.input}
+}
+
+func (cb *circuitBreakerIncr[A]) Node() *Node { return cb.n }
+
+func (cb *circuitBreakerIncr[A]) Value() A { return cb.value }
+
+func (cb *circuitBreakerIncr[A]) IsOpen() bool { return cb.open }
+
+func (cb *circuitBreakerIncr[A]) Stabilize(_ context.Context) error {
+	if cb.open {
+		return nil
+	}
+	cb.errors = 0
+	cb.value = cb.input.Value()
+	return nil
+}
+
+func (cb *circuitBreakerIncr[A]) Reset() error {
+	cb.errors = 0
+	if !cb.open {
+		return nil
+	}
+	cb.open = false
+	if !cb.n.isNecessary() {
+		return nil
+	}
+	return GraphForNode(cb).addChild(cb, cb.input)
+}
+
+func (cb *circuitBreakerIncr[A]) String() string { return cb.n.String() }
+
+// onAncestorError is called at the end of a stabilization in which the input,
+// or a node it depends on, returned an error, and opens the circuit once
+// errors have been returned in too many stabilizations in a row.
+func (cb *circuitBreakerIncr[A]) onAncestorError(_ context.Context, _ error) {
+	if cb.open {
+		return
+	}
+	cb.errors++
+	if cb.errors < cb.threshold {
+		return
+	}
+	cb.open = true
+	if cb.n.isNecessary() {
+		_ = GraphForNode(cb).changeParent(cb, cb.input, nil)
+	}
+}
@@ -0,0 +1,175 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_CircuitBreaker(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	failing := true
+	var recomputes int
+	m := MapContext(g, v, func(_ context.Context, vv string) (string, error) {
+		recomputes++
+		if failing && vv != "a" {
+			return "", fmt.Errorf("this is just a test")
+		}
+		return vv + "!", nil
+	})
+	var errors int
+	m.Node().OnError(func(_ context.Context, _ error) {
+		errors++
+	})
+	cb := CircuitBreaker(g, m, 2)
+	o := MustObserve(g, Map(g, cb, ident))
+
+	testutil.Equal(t, "circuit_breaker", cb.Node().Kind())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a!", o.Value())
+	testutil.Equal(t, false, cb.IsOpen())
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, 1, errors)
+	testutil.Equal(t, false, cb.IsOpen())
+
+	v.Set("c")
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, 2, errors)
+	testutil.Equal(t, true, cb.IsOpen())
+	testutil.Equal(t, false, g.Has(m), "the input should be unlinked once the circuit opens")
+	testutil.Equal(t, "a!", o.Value())
+
+	recomputes = 0
+	v.Set("d")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, recomputes)
+	testutil.Equal(t, 2, errors)
+	testutil.Equal(t, "a!", o.Value())
+	testutil.NoError(t, g.Validate())
+
+	failing = false
+	err = cb.Reset()
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, cb.IsOpen())
+	testutil.Equal(t, true, g.Has(m))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, recomputes)
+	testutil.Equal(t, "d!", o.Value())
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_CircuitBreaker_consecutive(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, 0)
+	m := MapContext(g, v, func(_ context.Context, vv int) (int, error) {
+		if vv < 0 {
+			return 0, fmt.Errorf("this is just a test")
+		}
+		return vv, nil
+	})
+	cb := CircuitBreaker(g, m, 2)
+	o := MustObserve(g, cb)
+
+	for _, value := range []int{-1, 1, -1, 2, -1} {
+		v.Set(value)
+		_ = g.Stabilize(ctx)
+		testutil.Equal(t, false, cb.IsOpen(), "a success should reset the consecutive error count")
+	}
+	testutil.Equal(t, 2, o.Value())
+
+	v.Set(-2)
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, true, cb.IsOpen())
+}
+
+func Test_CircuitBreaker_Reset_unobserved(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, -1)
+	m := MapContext(g, v, func(_ context.Context, vv int) (int, error) {
+		if vv < 0 {
+			return 0, fmt.Errorf("this is just a test")
+		}
+		return vv, nil
+	})
+	cb := CircuitBreaker(g, m, 0)
+	o := MustObserve(g, cb)
+
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, true, cb.IsOpen(), "a threshold of zero should be treated as one")
+
+	o.Unobserve(ctx)
+	err = cb.Reset()
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, cb.IsOpen())
+	testutil.Equal(t, false, g.Has(m))
+
+	v.Set(3)
+	o = MustObserve(g, cb)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, o.Value())
+}
+
+func Test_CircuitBreaker_ancestorError(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, 0)
+	failing := MapContext(g, v, func(_ context.Context, vv int) (int, error) {
+		if vv < 0 {
+			return 0, fmt.Errorf("this is just a test")
+		}
+		return vv, nil
+	})
+	m := Map(g, failing, func(vv int) int { return vv * 2 })
+	cb := CircuitBreaker(g, m, 2)
+	o := MustObserve(g, cb)
+
+	var openDuringStabilization []bool
+	failing.Node().OnError(func(_ context.Context, _ error) {
+		openDuringStabilization = append(openDuringStabilization, cb.IsOpen())
+	})
+
+	v.Set(1)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, o.Value())
+
+	v.Set(-1)
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, false, cb.IsOpen())
+
+	v.Set(-2)
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, true, cb.IsOpen(), "errors from the nodes the input depends on should be counted")
+	testutil.Equal(t, []bool{false, false}, openDuringStabilization, "the circuit should open at the end of the stabilization")
+	testutil.Equal(t, false, g.Has(m))
+	testutil.Equal(t, false, g.Has(failing))
+	testutil.Equal(t, 2, o.Value())
+	testutil.NoError(t, g.Validate())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+}
@@ -0,0 +1,401 @@
+package incr
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Clone returns an independent copy of the graph, e.g. to change the inputs
+// of the copy and compare the results with the original graph.
+//
+// The copy has the same structure as the graph, that is, the same links and heights,
+// and each node holds a copy of the value of the node it was copied from. Values are
+// copied shallowly, such that slices and maps are shared with the original graph, and
+// the functions of the nodes are shared by reference; they should not mutate state.
+//
+// The nodes in the copy have new identifiers; the map returned is from the identifiers
+// of the nodes in the graph to the nodes in the copy, such that you can e.g. set the
+// value of the copy of a [Var] node by asserting that the copy is a [VarIncr].
+//
+// Handlers registered on the graph or on its nodes are not copied, because they
+// often hold references to the original graph or nodes.
+//
+// Nodes that are not tracked by the graph are only copied if they're inputs of nodes
+// that are, and weak observers (see [ObserveWeak]) are only copied if the node they
+// observe is copied.
+//
+// Only the common node types support being copied (e.g. [Var], [Return], [Func], [Map]
+// through [Map3], [MapN], [Cutoff], [Cutoff2], [Always], [Previous], [Freeze], observers
+// and sentinels), and an error is returned if the graph holds any other kind of node,
+// including [Bind] nodes, or nodes created within a bind scope.
+//
+// It must not be called during stabilization.
+func (graph *Graph) Clone() (*Graph, map[Identifier]INode, error) {
+	if atomic.LoadInt32(&graph.status) != StatusNotStabilizing {
+		return nil, nil, ErrAlreadyStabilizing
+	}
+	c := &graphCloner{
+		from: graph,
+		to: New(
+			OptGraphMaxHeight(len(graph.adjustHeightsHeap.nodesByHeight)),
+			OptGraphParallelism(graph.parallelism),
+			OptGraphClearRecomputeHeapOnError(graph.clearRecomputeHeapOnError),
+			OptGraphTrackRecomputeTimes(graph.trackRecomputeTimes),
+			OptGraphMaxRecomputesPerStabilize(int(graph.maxRecomputesPerStabilize)),
+			OptGraphDisablePanicRecovery(graph.disablePanicRecovery),
+			OptGraphSkipEmptyStabilizations(graph.skipEmptyStabilizations),
+			OptGraphRecomputeHeapSparse(graph.recomputeHeap.isSparse()),
+			OptGraphUniqueLabels(graph.uniqueLabels),
+			OptGraphStrictObservation(graph.strictObservation),
+			OptGraphIdentifierProvider(graph.identifierProvider),
+		),
+		clones: make(map[Identifier]INode),
+	}
+	c.to.deterministicOrdering = graph.deterministicOrdering
+
+	graph.nodesMu.Lock()
+	tracked := make([]INode, 0, len(graph.nodes))
+	for _, n := range graph.nodes {
+		tracked = append(tracked, n)
+	}
+	graph.nodesMu.Unlock()
+	graph.observersMu.Lock()
+	for _, o := range graph.observers {
+		tracked = append(tracked, o)
+	}
+	graph.observersMu.Unlock()
+	graph.sentinelsMu.Lock()
+	for _, s := range graph.sentinels {
+		tracked = append(tracked, s)
+	}
+	graph.sentinelsMu.Unlock()
+
+	for _, n := range tracked {
+		c.cloneOf(n)
+	}
+	// weak observers are only reachable through the nodes they observe.
+	for index := 0; index < len(c.originals); index++ {
+		for _, o := range c.originals[index].Node().weakObservers {
+			c.cloneOf(o)
+		}
+	}
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+	for _, n := range c.originals {
+		c.relink(n)
+	}
+
+	to := c.to
+	to.label = graph.label
+	to.metadata = graph.metadata
+	to.stabilizationNum = graph.stabilizationNum
+	to.lastStabilizedAt = graph.lastStabilizedAt
+	to.nodeOrder = graph.nodeOrder
+	to.numNodes = graph.numNodes
+	for _, n := range tracked {
+		cloned := c.clones[n.Node().id]
+		switch typed := cloned.(type) {
+		case IObserver:
+			to.observers[typed.Node().id] = typed
+		case ISentinel:
+			to.sentinels[typed.Node().id] = typed
+		default:
+			to.nodes[typed.Node().id] = typed
+			to.indexLabelUnsafe(typed, typed.Node().label)
+		}
+		if n.Node().heightInRecomputeHeap != HeightUnset {
+			to.recomputeHeap.addIfNotPresent(cloned, n.Node().recomputeReason)
+		}
+	}
+
+	output := make(map[Identifier]INode, len(c.clones))
+	for id, cloned := range c.clones {
+		output[id] = cloned
+	}
+	return to, output, nil
+}
+
+// iClone is implemented by nodes that can be copied by [Graph.Clone].
+type iClone interface {
+	// clone returns a copy of the node with a given copy of its node metadata,
+	// using the cloner to get the copies of the node's inputs.
+	clone(c *graphCloner, n *Node) INode
+}
+
+// graphCloner holds the state of a call to [Graph.Clone].
+type graphCloner struct {
+	from, to  *Graph
+	clones    map[Identifier]INode
+	originals []INode
+	err       error
+}
+
+// cloneOf returns the copy of a given node, copying it (and its inputs)
+// if it hasn't been copied yet.
+//
+// If the node can't be copied the cloner's error is set, and the
+// node itself is returned so that callers can continue.
+func (c *graphCloner) cloneOf(n INode) INode {
+	if n == nil {
+		return nil
+	}
+	if cloned, ok := c.clones[n.Node().id]; ok {
+		return cloned
+	}
+	if c.err != nil {
+		return n
+	}
+	typed, ok := n.(iClone)
+	if !ok {
+		c.err = fmt.Errorf("clone; node %v does not support cloning", n)
+		return n
+	}
+	if n.Node().createdIn != Scope(c.from) {
+		c.err = fmt.Errorf("clone; node %v was created within a bind scope, cannot continue", n)
+		return n
+	}
+	cloned := typed.clone(c, c.cloneNode(n.Node()))
+	c.clones[n.Node().id] = cloned
+	c.originals = append(c.originals, n)
+	return cloned
+}
+
+// cloneNode returns a copy of a node's metadata with a new identifier, without
+// its handlers and the references to other nodes, which are set by relink.
+func (c *graphCloner) cloneNode(n *Node) *Node {
+	cloned := new(Node)
+	*cloned = *n
+	cloned.createdIn = c.to
+	if c.to.identifierProvider != nil {
+		cloned.id = c.to.identifierProvider()
+	} else {
+		cloned.id = NewIdentifier()
+	}
+	cloned.parents = nil
+	cloned.children = nil
+	cloned.observers = nil
+	cloned.weakObservers = nil
+	cloned.sentinels = nil
+	cloned.heightInRecomputeHeap = HeightUnset
+	cloned.heightInAdjustHeightsHeap = HeightUnset
+	cloned.nextInRecomputeHeap = nil
+	cloned.previousInRecomputeHeap = nil
+	cloned.onUpdateHandlers = nil
+	cloned.onErrorHandlers = nil
+	cloned.onAbortedHandlers = nil
+	cloned.onRecomputeStartHandlers = nil
+	cloned.onRecomputeEndHandlers = nil
+	cloned.onObservedHandlers = nil
+	cloned.onUnobservedHandlers = nil
+	// the interface shortcuts are bound to the original node, and are
+	// set for the copy by relink; we reset them here such that shortcuts
+	// the copy doesn't implement aren't left pointing at the original.
+	cloned.stabilizeFn = nil
+	cloned.shouldBeInvalidatedFn = nil
+	cloned.staleFn = nil
+	cloned.cutoffFn = nil
+	cloned.parentsFn = nil
+	cloned.invalidateFn = nil
+	cloned.shouldRecomputeFn = nil
+	return cloned
+}
+
+// relink points the node metadata of the copy of a given
+// node at the copies of the nodes it references.
+func (c *graphCloner) relink(n INode) {
+	nn := n.Node()
+	cloned := c.clones[nn.id]
+	cn := cloned.Node()
+	for _, p := range nn.parents {
+		cn.parents = append(cn.parents, c.clones[p.Node().id])
+	}
+	for _, child := range nn.children {
+		cn.children = append(cn.children, c.clones[child.Node().id])
+	}
+	for _, o := range nn.observers {
+		cn.observers = append(cn.observers, c.clones[o.Node().id].(IObserver))
+	}
+	for _, o := range nn.weakObservers {
+		cn.weakObservers = append(cn.weakObservers, c.clones[o.Node().id].(IObserver))
+	}
+	for _, s := range nn.sentinels {
+		cn.sentinels = append(cn.sentinels, c.clones[s.Node().id].(ISentinel))
+	}
+	cn.initializeFrom(cloned)
+}
+
+// cloneInput returns the copy of a given input of a node.
+func cloneInput[A any](c *graphCloner, input Incr[A]) Incr[A] {
+	if input == nil {
+		return nil
+	}
+	return c.cloneOf(input).(Incr[A])
+}
+
+var (
+	_ iClone = (*varIncr[string])(nil)
+	_ iClone = (*returnIncr[string])(nil)
+	_ iClone = (*funcIncr[string])(nil)
+	_ iClone = (*mapIncr[string, string])(nil)
+	_ iClone = (*map2Incr[string, string, string])(nil)
+	_ iClone = (*map3Incr[string, string, string, string])(nil)
+	_ iClone = (*mapNIncr[string, string])(nil)
+	_ iClone = (*cutoffIncr[string])(nil)
+	_ iClone = (*cutoff2Incr[string, string])(nil)
+	_ iClone = (*alwaysIncr[string])(nil)
+	_ iClone = (*alwaysWhenIncr[string])(nil)
+	_ iClone = (*alwaysThrottledIncr[string])(nil)
+	_ iClone = (*previousIncr[string])(nil)
+	_ iClone = (*lagIncr[string])(nil)
+	_ iClone = (*freezeIncr[string])(nil)
+	_ iClone = (*observeIncr[string])(nil)
+	_ iClone = (*observeManyIncr)(nil)
+	_ iClone = (*sentinelIncr)(nil)
+)
+
+func (vn *varIncr[T]) clone(_ *graphCloner, n *Node) INode {
+	return &varIncr[T]{
+		n:                           n,
+		setAt:                       vn.setAt,
+		value:                       vn.value,
+		setDuringStabilizationValue: vn.setDuringStabilizationValue,
+		setDuringStabilization:      vn.setDuringStabilization,
+	}
+}
+
+func (r *returnIncr[A]) clone(_ *graphCloner, n *Node) INode {
+	cloned := *r
+	cloned.n = n
+	return &cloned
+}
+
+func (f *funcIncr[T]) clone(_ *graphCloner, n *Node) INode {
+	cloned := *f
+	cloned.n = n
+	return &cloned
+}
+
+func (mn *mapIncr[A, B]) clone(c *graphCloner, n *Node) INode {
+	cloned := *mn
+	cloned.n = n
+	cloned.a = cloneInput(c, mn.a)
+	cloned.parents = []INode{cloned.a}
+	return &cloned
+}
+
+func (mn *map2Incr[A, B, C]) clone(c *graphCloner, n *Node) INode {
+	cloned := *mn
+	cloned.n = n
+	cloned.a = cloneInput(c, mn.a)
+	cloned.b = cloneInput(c, mn.b)
+	cloned.parents = []INode{cloned.a, cloned.b}
+	return &cloned
+}
+
+func (mn *map3Incr[A, B, C, D]) clone(c *graphCloner, n *Node) INode {
+	cloned := *mn
+	cloned.n = n
+	cloned.a = cloneInput(c, mn.a)
+	cloned.b = cloneInput(c, mn.b)
+	cloned.c = cloneInput(c, mn.c)
+	cloned.parents = []INode{cloned.a, cloned.b, cloned.c}
+	return &cloned
+}
+
+func (mn *mapNIncr[A, B]) clone(c *graphCloner, n *Node) INode {
+	cloned := *mn
+	cloned.n = n
+	cloned.inputs = make([]Incr[A], len(mn.inputs))
+	for index, input := range mn.inputs {
+		cloned.inputs[index] = cloneInput(c, input)
+	}
+	return &cloned
+}
+
+func (ci *cutoffIncr[A]) clone(c *graphCloner, n *Node) INode {
+	cloned := *ci
+	cloned.n = n
+	cloned.i = cloneInput(c, ci.i)
+	return &cloned
+}
+
+func (ci *cutoff2Incr[A, B]) clone(c *graphCloner, n *Node) INode {
+	cloned := *ci
+	cloned.n = n
+	cloned.e = cloneInput(c, ci.e)
+	cloned.i = cloneInput(c, ci.i)
+	return &cloned
+}
+
+func (a *alwaysIncr[A]) clone(c *graphCloner, n *Node) INode {
+	cloned := *a
+	cloned.n = n
+	cloned.input = cloneInput(c, a.input)
+	cloned.parents = []INode{cloned.input}
+	return &cloned
+}
+
+func (a *alwaysWhenIncr[A]) clone(c *graphCloner, n *Node) INode {
+	cloned := *a
+	cloned.n = n
+	cloned.input = cloneInput(c, a.input)
+	cloned.parents = []INode{cloned.input}
+	return &cloned
+}
+
+func (a *alwaysThrottledIncr[A]) clone(c *graphCloner, n *Node) INode {
+	cloned := *a
+	cloned.n = n
+	cloned.input = cloneInput(c, a.input)
+	cloned.parents = []INode{cloned.input}
+	return &cloned
+}
+
+func (p *previousIncr[A]) clone(c *graphCloner, n *Node) INode {
+	cloned := *p
+	cloned.n = n
+	cloned.input = cloneInput(c, p.input)
+	return &cloned
+}
+
+func (l *lagIncr[A]) clone(c *graphCloner, n *Node) INode {
+	cloned := *l
+	cloned.n = n
+	cloned.input = cloneInput(c, l.input)
+	cloned.history = new(queue[A])
+	for _, v := range l.history.values() {
+		cloned.history.push(v)
+	}
+	return &cloned
+}
+
+func (f *freezeIncr[A]) clone(c *graphCloner, n *Node) INode {
+	cloned := *f
+	cloned.n = n
+	cloned.i = cloneInput(c, f.i)
+	return &cloned
+}
+
+func (o *observeIncr[A]) clone(c *graphCloner, n *Node) INode {
+	return &observeIncr[A]{
+		n:          n,
+		observed:   cloneInput(c, o.observed),
+		unobserved: o.unobserved,
+		weak:       o.weak,
+	}
+}
+
+func (o *observeManyIncr) clone(c *graphCloner, n *Node) INode {
+	return &observeManyIncr{
+		n:        n,
+		observed: c.cloneOf(o.observed),
+	}
+}
+
+func (s *sentinelIncr) clone(c *graphCloner, n *Node) INode {
+	cloned := *s
+	cloned.n = n
+	cloned.watched = c.cloneOf(s.watched)
+	return &cloned
+}
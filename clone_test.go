@@ -0,0 +1,208 @@
+package incr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_Clone(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	g.SetLabel("original")
+
+	v0 := Var(g, 1)
+	v1 := Var(g, 2)
+	c := Return(g, 10)
+	m := Map3(g, v0, v1, c, func(a, b, c int) int { return a + b + c })
+	m.Node().SetLabel("sum")
+	co := Cutoff(g, m, func(oldv, newv int) bool { return newv-oldv == 1 })
+	o := MustObserve(g, Map(g, co, func(vv int) int { return vv * 2 }))
+
+	var originalUpdates int
+	o.OnUpdate(func(_ context.Context, _ int) { originalUpdates++ })
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 26, o.Value())
+	testutil.Equal(t, 1, originalUpdates)
+
+	clone, clones, err := g.Clone()
+	testutil.NoError(t, err)
+	testutil.NotNil(t, clone)
+	testutil.Equal(t, "original", clone.Label())
+	testutil.Equal(t, g.stabilizationNum, clone.stabilizationNum)
+	testutil.Equal(t, len(g.nodes), len(clone.nodes))
+	testutil.Equal(t, len(g.observers), len(clone.observers))
+	testutil.NoError(t, clone.Validate())
+
+	cm := clones[m.Node().ID()]
+	testutil.NotEqual(t, m.Node().ID(), cm.Node().ID())
+	testutil.Equal(t, "sum", cm.Node().Label())
+	testutil.Equal(t, m.Node().height, cm.Node().height)
+	testutil.Equal(t, 13, cm.(Incr[int]).Value())
+	testutil.Equal(t, true, clone.Has(cm))
+	testutil.Equal(t, false, g.Has(cm))
+
+	co2 := clones[o.Node().ID()].(ObserveIncr[int])
+	testutil.Equal(t, 26, co2.Value())
+
+	// changing the copy doesn't change the original.
+	clones[v0.Node().ID()].(VarIncr[int]).Set(5)
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+	err = clone.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 34, co2.Value())
+	testutil.Equal(t, 26, o.Value())
+	testutil.Equal(t, 1, v0.Value())
+	testutil.Equal(t, 1, originalUpdates, "handlers should not be copied")
+
+	// the copy keeps the cutoff behavior of the original.
+	clones[v1.Node().ID()].(VarIncr[int]).Set(3)
+	err = clone.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 34, co2.Value())
+
+	// and changing the original doesn't change the copy.
+	v1.Set(10)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 42, o.Value())
+	testutil.Equal(t, 34, co2.Value())
+	testutil.Equal(t, 0, clone.recomputeHeap.len())
+}
+
+func Test_Graph_Clone_recomputeHeap(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	m := Map(g, v, func(vv string) string { return vv + "!" })
+	o := MustObserve(g, m)
+
+	// clone before the first stabilization so the nodes are still in the recompute heap.
+	clone, clones, err := g.Clone()
+	testutil.NoError(t, err)
+	testutil.Equal(t, g.recomputeHeap.len(), clone.recomputeHeap.len())
+	testutil.Equal(t, true, clone.recomputeHeap.has(clones[m.Node().ID()]))
+	testutil.Equal(t, false, clone.recomputeHeap.has(m))
+
+	err = clone.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello!", clones[o.Node().ID()].(ObserveIncr[string]).Value())
+	testutil.Equal(t, "", o.Value())
+	testutil.Equal(t, true, g.recomputeHeap.has(m))
+}
+
+func Test_Graph_Clone_unobservedInputs(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, 1)
+	m := Map(g, v, func(vv int) int { return vv + 1 })
+	w := ObserveWeak(g, v)
+	s := MapN(g, sum, v, Return(g, 2))
+	o := MustObserve(g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	clone, clones, err := g.Clone()
+	testutil.NoError(t, err)
+
+	// m isn't an input of any tracked node so it isn't copied.
+	_, ok := clones[m.Node().ID()]
+	testutil.Equal(t, false, ok)
+
+	cw := clones[w.Node().ID()].(ObserveIncr[int])
+	testutil.Equal(t, false, clone.Has(cw))
+	clones[v.Node().ID()].(VarIncr[int]).Set(5)
+
+	err = clone.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5, cw.Value())
+	testutil.Equal(t, 7, clones[o.Node().ID()].(ObserveIncr[int]).Value())
+	testutil.Equal(t, 3, o.Value())
+	testutil.Equal(t, 1, w.Value())
+	testutil.NoError(t, clone.Validate())
+}
+
+func Test_Graph_Clone_unsupported(t *testing.T) {
+	g := New()
+
+	v := Var(g, "a")
+	b := Bind(g, v, func(bs Scope, vv string) Incr[string] {
+		return Return(bs, vv)
+	})
+	_ = MustObserve(g, b)
+
+	clone, clones, err := g.Clone()
+	testutil.Error(t, err)
+	testutil.Nil(t, clone)
+	testutil.Nil(t, clones)
+}
+
+func Test_Graph_Clone_errorsWhileStabilizing(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	var cloneErr error
+	m := Map(g, Var(g, "a"), func(vv string) string {
+		_, _, cloneErr = g.Clone()
+		return vv
+	})
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, ErrAlreadyStabilizing, cloneErr)
+}
+
+func Test_Graph_Clone_always(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	now := time.Date(2024, 01, 01, 12, 0, 0, 0, time.UTC)
+	clock := func(_ context.Context) time.Time { return now }
+
+	v := Var(g, "a")
+	at := AlwaysThrottled(g, v, time.Minute, clock)
+	var shouldRecompute bool
+	aw := AlwaysWhen(g, v, func(_ context.Context) (bool, error) { return shouldRecompute, nil })
+	_ = MustObserve(g, at)
+	_ = MustObserve(g, aw)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	original := at.(*alwaysThrottledIncr[string])
+	testutil.Equal(t, now, original.lastRecomputeAt)
+
+	clone, clones, err := g.Clone()
+	testutil.NoError(t, err)
+
+	cat, ok := clones[at.Node().ID()].(*alwaysThrottledIncr[string])
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, time.Minute, cat.minInterval)
+	caw, ok := clones[aw.Node().ID()].(*alwaysWhenIncr[string])
+	testutil.Equal(t, true, ok)
+	testutil.NotNil(t, caw.n.shouldRecomputeFn)
+
+	// stabilizing the copy doesn't change the original.
+	now = now.Add(time.Hour)
+	err = clone.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, now, cat.lastRecomputeAt)
+	testutil.Equal(t, now.Add(-time.Hour), original.lastRecomputeAt)
+
+	// the copy keeps the predicate of the original.
+	changedAt := caw.n.changedAt
+	err = clone.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, changedAt, caw.n.changedAt)
+	shouldRecompute = true
+	err = clone.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, clone.stabilizationNum-1, caw.n.changedAt)
+}
@@ -29,6 +29,22 @@ func CutoffContext[A any](bs Scope, i Incr[A], fn CutoffContextFunc[A]) Incr[A]
 	})
 }
 
+// CutoffErrorPolicy determines how an error returned by the cutoff function
+// of a node is handled, set with [Node.SetCutoffErrorPolicy].
+type CutoffErrorPolicy int
+
+// CutoffErrorPolicy values.
+const (
+	// CutoffErrorPolicyStrict treats cutoff errors like any other error returned
+	// by a node, that is, the node is not recomputed and, unless stabilizing in
+	// parallel, stabilization stops. This is the default.
+	CutoffErrorPolicyStrict CutoffErrorPolicy = iota
+	// CutoffErrorPolicyPropagateValue reports cutoff errors to the node's
+	// error handlers, but otherwise treats the node as not cut off, such
+	// that its value propagates and stabilization continues.
+	CutoffErrorPolicyPropagateValue
+)
+
 // CutoffFunc is a function that implements cutoff checking.
 type CutoffFunc[A any] func(A, A) bool
 
@@ -0,0 +1,82 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// Cutoff3 returns a new cutoff incremental that takes two auxiliary
+// inputs, e.g. an epsilon and a mode flag.
+func Cutoff3[A, B, C any](bs Scope, a Incr[A], b Incr[B], input Incr[C], fn Cutoff3Func[A, B, C]) Incr[C] {
+	return Cutoff3Context[A, B, C](bs, a, b, input, func(_ context.Context, av A, bv B, oldv, newv C) (bool, error) {
+		return fn(av, bv, oldv, newv), nil
+	})
+}
+
+// Cutoff3Context returns a new cutoff incremental that takes two auxiliary
+// inputs, e.g. an epsilon and a mode flag.
+//
+// The goal of the cutoff incremental is to stop recomputation at a given
+// node if the difference between the previous and latest values are not
+// significant enough to warrant a full recomputation of the children of this node.
+//
+// If the auxiliary inputs change the cutoff function is evaluated again
+// with the latest auxiliary values, but the auxiliary inputs changing
+// does not bypass the cutoff function.
+func Cutoff3Context[A, B, C any](bs Scope, a Incr[A], b Incr[B], input Incr[C], fn Cutoff3ContextFunc[A, B, C]) Incr[C] {
+	return WithinScope(bs, &cutoff3Incr[A, B, C]{
+		n:  NewNode("cutoff3"),
+		fn: fn,
+		a:  a,
+		b:  b,
+		i:  input,
+	})
+}
+
+var (
+	_ Incr[string] = (*cutoff3Incr[int, bool, string])(nil)
+	_ IStabilize   = (*cutoff3Incr[int, bool, string])(nil)
+	_ ICutoff      = (*cutoff3Incr[int, bool, string])(nil)
+	_ fmt.Stringer = (*cutoff3Incr[int, bool, string])(nil)
+)
+
+// Cutoff3Func is a function that implements cutoff checking.
+type Cutoff3Func[A, B, C any] func(A, B, C, C) bool
+
+// Cutoff3ContextFunc is a function that implements cutoff checking
+// and takes a context.
+type Cutoff3ContextFunc[A, B, C any] func(context.Context, A, B, C, C) (bool, error)
+
+// cutoff3Incr is a concrete implementation of Incr for
+// the cutoff operator with two auxiliary inputs.
+type cutoff3Incr[A, B, C any] struct {
+	n     *Node
+	a     Incr[A]
+	b     Incr[B]
+	i     Incr[C]
+	value C
+	fn    Cutoff3ContextFunc[A, B, C]
+}
+
+func (c *cutoff3Incr[A, B, C]) Parents() []INode {
+	return []INode{c.a, c.b, c.i}
+}
+
+func (c *cutoff3Incr[A, B, C]) Value() C {
+	return c.value
+}
+
+func (c *cutoff3Incr[A, B, C]) Stabilize(ctx context.Context) error {
+	c.value = c.i.Value()
+	return nil
+}
+
+func (c *cutoff3Incr[A, B, C]) Cutoff(ctx context.Context) (bool, error) {
+	return c.fn(ctx, c.a.Value(), c.b.Value(), c.value, c.i.Value())
+}
+
+func (c *cutoff3Incr[A, B, C]) Node() *Node {
+	return c.n
+}
+
+func (c *cutoff3Incr[A, B, C]) String() string { return c.n.String() }
@@ -0,0 +1,72 @@
+package incr
+
+import (
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// CutoffSliceEqual is a [CutoffFunc] that cuts off recomputation if
+// the previous and latest slices have the same length and elements.
+//
+// A nil slice and an empty slice are considered equal.
+//
+//	c := incr.Cutoff(g, v, incr.CutoffSliceEqual[string])
+func CutoffSliceEqual[T comparable](oldv, newv []T) bool {
+	return slices.Equal(oldv, newv)
+}
+
+// CutoffMapEqual is a [CutoffFunc] that cuts off recomputation if
+// the previous and latest maps have the same keys and values.
+//
+// A nil map and an empty map are considered equal.
+//
+//	c := incr.Cutoff(g, v, incr.CutoffMapEqual[string, int])
+func CutoffMapEqual[K, V comparable](oldv, newv map[K]V) bool {
+	return maps.Equal(oldv, newv)
+}
+
+// CutoffDeepEqual is a [CutoffFunc] that cuts off recomputation if the
+// previous and latest values are equal as determined by [reflect.DeepEqual].
+//
+// Note that unlike [CutoffSliceEqual] and [CutoffMapEqual], a nil
+// slice or map is not considered equal to an empty one.
+//
+//	c := incr.Cutoff(g, v, incr.CutoffDeepEqual[[]*Order])
+func CutoffDeepEqual[T any](oldv, newv T) bool {
+	return reflect.DeepEqual(oldv, newv)
+}
+
+// CutoffEpsilon returns a [CutoffFunc] that cuts off recomputation if the
+// absolute difference between the previous and latest values is
+// less than or equal to a given epsilon.
+//
+// If both values are NaN the values are considered unchanged and recomputation
+// is cut off; if only one of the values is NaN recomputation is not cut off.
+//
+//	c := incr.Cutoff(g, v, incr.CutoffEpsilon(0.01))
+func CutoffEpsilon(epsilon float64) CutoffFunc[float64] {
+	return func(oldv, newv float64) bool {
+		return Cutoff2Epsilon(epsilon, oldv, newv)
+	}
+}
+
+// Cutoff2Epsilon is a [Cutoff2Func] that cuts off recomputation if the
+// absolute difference between the previous and latest values is
+// less than or equal to the epsilon input.
+//
+// NaN values are handled the same way as [CutoffEpsilon].
+//
+//	c := incr.Cutoff2(g, epsilon, v, incr.Cutoff2Epsilon)
+func Cutoff2Epsilon(epsilon, oldv, newv float64) bool {
+	oldIsNaN, newIsNaN := math.IsNaN(oldv), math.IsNaN(newv)
+	if oldIsNaN || newIsNaN {
+		return oldIsNaN && newIsNaN
+	}
+	if oldv == newv {
+		// handles infinities, whose difference is NaN.
+		return true
+	}
+	return math.Abs(newv-oldv) <= epsilon
+}
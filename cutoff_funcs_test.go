@@ -0,0 +1,162 @@
+package incr
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_CutoffSliceEqual(t *testing.T) {
+	testutil.Equal(t, true, CutoffSliceEqual[int](nil, nil))
+	testutil.Equal(t, true, CutoffSliceEqual(nil, []int{}))
+	testutil.Equal(t, true, CutoffSliceEqual([]int{}, nil))
+	testutil.Equal(t, true, CutoffSliceEqual([]int{1, 2, 3}, []int{1, 2, 3}))
+	testutil.Equal(t, false, CutoffSliceEqual([]int{1, 2, 3}, []int{1, 2}))
+	testutil.Equal(t, false, CutoffSliceEqual([]int{1, 2, 3}, []int{3, 2, 1}))
+	testutil.Equal(t, false, CutoffSliceEqual(nil, []int{1}))
+}
+
+func Test_CutoffMapEqual(t *testing.T) {
+	testutil.Equal(t, true, CutoffMapEqual[string, int](nil, nil))
+	testutil.Equal(t, true, CutoffMapEqual(nil, map[string]int{}))
+	testutil.Equal(t, true, CutoffMapEqual(map[string]int{}, nil))
+	testutil.Equal(t, true, CutoffMapEqual(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2, "a": 1}))
+	testutil.Equal(t, false, CutoffMapEqual(map[string]int{"a": 1}, map[string]int{"a": 2}))
+	testutil.Equal(t, false, CutoffMapEqual(map[string]int{"a": 1}, map[string]int{"b": 1}))
+	testutil.Equal(t, false, CutoffMapEqual(nil, map[string]int{"a": 1}))
+}
+
+func Test_CutoffDeepEqual(t *testing.T) {
+	type order struct {
+		ID    string
+		Lines []int
+	}
+	testutil.Equal(t, true, CutoffDeepEqual[[]int](nil, nil))
+	testutil.Equal(t, false, CutoffDeepEqual(nil, []int{}))
+	testutil.Equal(t, false, CutoffDeepEqual(map[string]int{}, nil))
+	testutil.Equal(t, true, CutoffDeepEqual(
+		[]*order{{ID: "a", Lines: []int{1, 2}}},
+		[]*order{{ID: "a", Lines: []int{1, 2}}},
+	))
+	testutil.Equal(t, false, CutoffDeepEqual(
+		[]*order{{ID: "a", Lines: []int{1, 2}}},
+		[]*order{{ID: "a", Lines: []int{1, 3}}},
+	))
+}
+
+func Test_CutoffEpsilon(t *testing.T) {
+	fn := CutoffEpsilon(0.1)
+	testutil.Equal(t, true, fn(1.0, 1.0))
+	testutil.Equal(t, true, fn(1.0, 1.05))
+	testutil.Equal(t, true, fn(1.05, 1.0))
+	testutil.Equal(t, false, fn(1.0, 1.2))
+	testutil.Equal(t, false, fn(1.2, 1.0))
+
+	testutil.Equal(t, true, fn(math.NaN(), math.NaN()))
+	testutil.Equal(t, false, fn(math.NaN(), 1.0))
+	testutil.Equal(t, false, fn(1.0, math.NaN()))
+	testutil.Equal(t, true, fn(math.Inf(1), math.Inf(1)))
+	testutil.Equal(t, false, fn(math.Inf(-1), math.Inf(1)))
+	testutil.Equal(t, false, fn(1.0, math.Inf(1)))
+}
+
+func Test_Cutoff_CutoffSliceEqual(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, []string{"a", "b"})
+	c := Cutoff(g, v, CutoffSliceEqual[string])
+	var calls int
+	m := Map(g, c, func(vs []string) int {
+		calls++
+		return len(vs)
+	})
+	om := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, om.Value())
+	testutil.Equal(t, 1, calls)
+
+	v.Set([]string{"a", "b"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+
+	v.Set([]string{"a", "b", "c"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, om.Value())
+	testutil.Equal(t, 2, calls)
+}
+
+func Test_Cutoff_CutoffMapEqual_always(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, map[string]int{"a": 1})
+	a := Always(g, v)
+	c := Cutoff(g, a, CutoffMapEqual[string, int])
+	var calls int
+	m := Map(g, c, func(vs map[string]int) int {
+		calls++
+		return len(vs)
+	})
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+
+	// the always node recomputes every pass, but the cutoff
+	// should keep the map from recomputing.
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+
+	v.Set(map[string]int{"a": 1})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+
+	v.Set(map[string]int{"a": 2})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, calls)
+}
+
+func Test_Cutoff2_Cutoff2Epsilon(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	e := Var(g, 0.1)
+	v := Var(g, 1.0)
+	c := Cutoff2(g, e, v, Cutoff2Epsilon)
+	var calls int
+	m := Map(g, c, func(vv float64) float64 {
+		calls++
+		return vv
+	})
+	om := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+
+	v.Set(1.05)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+	testutil.Equal(t, 1.0, om.Value())
+
+	v.Set(math.NaN())
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, calls)
+
+	v.Set(math.NaN())
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, calls)
+}
@@ -0,0 +1,87 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// CutoffN returns a new cutoff incremental that takes any number
+// of auxiliary inputs of the same type.
+func CutoffN[A, B any](bs Scope, input Incr[B], fn CutoffNFunc[A, B], aux ...Incr[A]) Incr[B] {
+	return CutoffNContext[A, B](bs, input, func(_ context.Context, auxv []A, oldv, newv B) (bool, error) {
+		return fn(auxv, oldv, newv), nil
+	}, aux...)
+}
+
+// CutoffNContext returns a new cutoff incremental that takes any number
+// of auxiliary inputs of the same type.
+//
+// The values of the auxiliary inputs are passed to the cutoff function
+// in the order the inputs are given.
+//
+// If the auxiliary inputs change the cutoff function is evaluated again
+// with the latest auxiliary values, but the auxiliary inputs changing
+// does not bypass the cutoff function.
+func CutoffNContext[A, B any](bs Scope, input Incr[B], fn CutoffNContextFunc[A, B], aux ...Incr[A]) Incr[B] {
+	return WithinScope(bs, &cutoffNIncr[A, B]{
+		n:   NewNode("cutoff_n"),
+		fn:  fn,
+		aux: aux,
+		i:   input,
+	})
+}
+
+var (
+	_ Incr[string] = (*cutoffNIncr[int, string])(nil)
+	_ IStabilize   = (*cutoffNIncr[int, string])(nil)
+	_ ICutoff      = (*cutoffNIncr[int, string])(nil)
+	_ fmt.Stringer = (*cutoffNIncr[int, string])(nil)
+)
+
+// CutoffNFunc is a function that implements cutoff checking.
+type CutoffNFunc[A, B any] func([]A, B, B) bool
+
+// CutoffNContextFunc is a function that implements cutoff checking
+// and takes a context.
+type CutoffNContextFunc[A, B any] func(context.Context, []A, B, B) (bool, error)
+
+// cutoffNIncr is a concrete implementation of Incr for
+// the cutoff operator with any number of auxiliary inputs.
+type cutoffNIncr[A, B any] struct {
+	n     *Node
+	aux   []Incr[A]
+	i     Incr[B]
+	value B
+	fn    CutoffNContextFunc[A, B]
+}
+
+func (c *cutoffNIncr[A, B]) Parents() []INode {
+	output := make([]INode, 0, len(c.aux)+1)
+	for _, a := range c.aux {
+		output = append(output, a)
+	}
+	return append(output, c.i)
+}
+
+func (c *cutoffNIncr[A, B]) Value() B {
+	return c.value
+}
+
+func (c *cutoffNIncr[A, B]) Stabilize(ctx context.Context) error {
+	c.value = c.i.Value()
+	return nil
+}
+
+func (c *cutoffNIncr[A, B]) Cutoff(ctx context.Context) (bool, error) {
+	auxv := make([]A, len(c.aux))
+	for index, a := range c.aux {
+		auxv[index] = a.Value()
+	}
+	return c.fn(ctx, auxv, c.value, c.i.Value())
+}
+
+func (c *cutoffNIncr[A, B]) Node() *Node {
+	return c.n
+}
+
+func (c *cutoffNIncr[A, B]) String() string { return c.n.String() }
@@ -0,0 +1,59 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// DistinctBy returns an incremental that cuts off propagation of the value of
+// a given input unless the key of the value, as returned by a given key
+// function, changes, e.g. to only react when the identifier of a large value changes.
+//
+// Changes to the value that don't change its key are not reflected in the value
+// of the node, that is, the node holds the first value seen with the current key.
+func DistinctBy[A any, K comparable](scope Scope, input Incr[A], key func(A) K) Incr[A] {
+	return WithinScope(scope, &distinctByIncr[A, K]{
+		n:     NewNode("distinct_by"),
+		input: input,
+		key:   key,
+	})
+}
+
+var (
+	_ Incr[string] = (*distinctByIncr[string, int])(nil)
+	_ IParents     = (*distinctByIncr[string, int])(nil)
+	_ ICutoff      = (*distinctByIncr[string, int])(nil)
+	_ IStabilize   = (*distinctByIncr[string, int])(nil)
+	_ fmt.Stringer = (*distinctByIncr[string, int])(nil)
+)
+
+type distinctByIncr[A any, K comparable] struct {
+	n     *Node
+	input Incr[A]
+	key   func(A) K
+	// last is the key of the value.
+	last  K
+	value A
+}
+
+func (d *distinctByIncr[A, K]) Parents() []INode {
+	return []INode{d.input}
+}
+
+func (d *distinctByIncr[A, K]) Node() *Node { return d.n }
+
+func (d *distinctByIncr[A, K]) Value() A { return d.value }
+
+// Cutoff cuts off the node if its key is unchanged, unless the node hasn't
+// been computed yet, in which case there is no previous key to compare to.
+func (d *distinctByIncr[A, K]) Cutoff(_ context.Context) (bool, error) {
+	return d.n.changedAt > 0 && d.key(d.input.Value()) == d.last, nil
+}
+
+func (d *distinctByIncr[A, K]) Stabilize(_ context.Context) error {
+	d.value = d.input.Value()
+	d.last = d.key(d.value)
+	return nil
+}
+
+func (d *distinctByIncr[A, K]) String() string { return d.n.String() }
@@ -0,0 +1,76 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+type distinctByTestValue struct {
+	ID      int
+	Payload string
+}
+
+func Test_DistinctBy(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, distinctByTestValue{ID: 0, Payload: "foo"})
+	d := DistinctBy(g, v, func(v distinctByTestValue) int { return v.ID })
+
+	var calls int
+	m := Map(g, d, func(v distinctByTestValue) string {
+		calls++
+		return v.Payload
+	})
+	om := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", om.Value())
+	testutil.Equal(t, 1, calls)
+
+	v.Set(distinctByTestValue{ID: 0, Payload: "bar"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", om.Value())
+	testutil.Equal(t, "foo", d.Value().Payload)
+	testutil.Equal(t, 1, calls)
+
+	v.Set(distinctByTestValue{ID: 1, Payload: "bar"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", om.Value())
+	testutil.Equal(t, 2, calls)
+
+	v.Set(distinctByTestValue{ID: 1, Payload: "baz"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", om.Value())
+	testutil.Equal(t, 2, calls)
+
+	v.Set(distinctByTestValue{ID: 0, Payload: "baz"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "baz", om.Value())
+	testutil.Equal(t, 3, calls)
+}
+
+func Test_DistinctBy_firstValueHasZeroKey(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "")
+	d := DistinctBy(g, v, func(v string) int { return len(v) })
+	od := MustObserve(g, d)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, uint64(1), d.Node().changedAt)
+	testutil.Equal(t, "", od.Value())
+
+	v.Set("foo")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", od.Value())
+}
@@ -17,7 +17,17 @@ import (
 //
 // As an for an example of a program that renders a graph with `Dot`,
 // look at `examples/benchmark/main.go`.
-func Dot(wr io.Writer, g *Graph) (err error) {
+//
+// Observers are drawn as ellipses and sentinels as diamonds. Nodes are written
+// in height and then identifier order so that the output is stable between runs
+// for graphs that use deterministic identifiers (see [OptGraphIdentifierProvider])
+// and can be compared with a diff.
+func Dot(wr io.Writer, g *Graph, opts ...DotOption) (err error) {
+	var options DotOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// NOTE(wc): a word on the below
 	// basically we panic anywhere we use the `writef` helper
 	// specifically where it can error.
@@ -26,25 +36,10 @@ func Dot(wr io.Writer, g *Graph) (err error) {
 		err, _ = recover().(error)
 	}()
 
-	writef := func(indent int, format string, args ...any) {
-		_, writeErr := io.WriteString(wr, strings.Repeat("\t", indent)+fmt.Sprintf(format, args...)+"\n")
-		if writeErr != nil {
-			panic(writeErr)
-		}
-	}
+	writef := dotWritef(wr)
 
 	writef(0, "digraph {")
-	nodes := make([]INode, 0, len(g.nodes)+len(g.observers))
-	for _, n := range g.nodes {
-		nodes = append(nodes, n)
-	}
-	for _, o := range g.observers {
-		nodes = append(nodes, o)
-	}
-	for _, o := range g.sentinels {
-		nodes = append(nodes, o)
-	}
-
+	nodes := dotNodes(g, options)
 	slices.SortStableFunc(nodes, nodeSorter)
 
 	nodeLabels := make(map[Identifier]string)
@@ -59,16 +54,23 @@ func Dot(wr io.Writer, g *Graph) (err error) {
 		if n.Node().height != HeightUnset {
 			nodeInternalLabelParts = append(nodeInternalLabelParts, fmt.Sprintf("height: %d", n.Node().height))
 		}
+		if options.ChangedAt {
+			nodeInternalLabelParts = append(nodeInternalLabelParts, fmt.Sprintf("changed at: %d", n.Node().changedAt))
+		}
 		if value := ExpertNode(n).Value(); value != nil {
 			nodeInternalLabelParts = append(nodeInternalLabelParts, fmt.Sprintf("value: %v", value))
 		}
 		nodeInternalLabel := strings.Join(nodeInternalLabelParts, "\n")
-		label := fmt.Sprintf(`label = "%s" shape = "box3d"`, escapeForDot(nodeInternalLabel))
-		color := ` fillcolor = "white" style="filled" fontcolor="black"`
+		label := fmt.Sprintf(`label = "%s" shape = "%s"`, escapeForDot(nodeInternalLabel), dotShape(n))
+		style := "filled"
+		if options.BindScopes && !g.HasID(n.Node().id) {
+			style = "filled,dashed"
+		}
+		color := fmt.Sprintf(` fillcolor = "white" style="%s" fontcolor="black"`, style)
 		if n.Node().setAt >= (g.stabilizationNum - 1) {
-			color = ` fillcolor = "red" style="filled" fontcolor="white"`
+			color = fmt.Sprintf(` fillcolor = "red" style="%s" fontcolor="white"`, style)
 		} else if n.Node().changedAt >= (g.stabilizationNum - 1) {
-			color = ` fillcolor = "pink" style="filled" fontcolor="black"`
+			color = fmt.Sprintf(` fillcolor = "pink" style="%s" fontcolor="black"`, style)
 		}
 		writef(1, "node [%s%s]; %s", label, color, nodeLabel)
 		nodeLabels[n.Node().id] = nodeLabel
@@ -77,9 +79,14 @@ func Dot(wr io.Writer, g *Graph) (err error) {
 		nodeLabel := nodeLabels[n.Node().id]
 		for _, p := range n.Node().children {
 			childLabel, ok := nodeLabels[p.Node().id]
-			if ok {
-				writef(1, "%s -> %s;", nodeLabel, childLabel)
+			if !ok {
+				continue
+			}
+			if _, isBindMain := p.(IBindMain); isBindMain && options.BindScopes {
+				writef(1, `%s -> %s [style = "dashed"];`, nodeLabel, childLabel)
+				continue
 			}
+			writef(1, "%s -> %s;", nodeLabel, childLabel)
 		}
 		for _, o := range n.Node().observers {
 			childLabel, ok := nodeLabels[o.Node().id]
@@ -87,11 +94,114 @@ func Dot(wr io.Writer, g *Graph) (err error) {
 				writef(1, "%s -> %s;", nodeLabel, childLabel)
 			}
 		}
+		if typed, ok := n.(IBindChange); ok && options.BindScopes {
+			for _, rn := range typed.RightScopeNodes() {
+				if scopeLabel, ok := nodeLabels[rn.Node().id]; ok {
+					writef(1, `%s -> %s [style = "dotted" arrowhead = "none"];`, nodeLabel, scopeLabel)
+				}
+			}
+		}
 	}
 	writef(0, "}")
 	return
 }
 
+// DotOption mutates [DotOptions].
+type DotOption func(*DotOptions)
+
+// DotWithBindScopes sets if [Dot] should also include the nodes that were created
+// within bind scopes but that aren't currently tracked by the graph, drawn with dashed
+// outlines, and draw the edges into bind nodes with dashed lines and the edges from
+// bind nodes to the nodes created in their scopes with dotted lines.
+func DotWithBindScopes(bindScopes bool) func(*DotOptions) {
+	return func(do *DotOptions) {
+		do.BindScopes = bindScopes
+	}
+}
+
+// DotWithChangedAt sets if [Dot] should annotate each node
+// with the stabilization it last changed in.
+func DotWithChangedAt(changedAt bool) func(*DotOptions) {
+	return func(do *DotOptions) {
+		do.ChangedAt = changedAt
+	}
+}
+
+// DotOptions are options for [Dot].
+type DotOptions struct {
+	BindScopes bool
+	ChangedAt  bool
+}
+
+// dotNodes returns the nodes the graph is tracking, including observers and
+// sentinels, and the nodes created within bind scopes if that option is set.
+func dotNodes(g *Graph, options DotOptions) (nodes []INode) {
+	seen := make(map[Identifier]struct{})
+	addNode := func(n INode) {
+		if _, ok := seen[n.Node().id]; ok {
+			return
+		}
+		seen[n.Node().id] = struct{}{}
+		nodes = append(nodes, n)
+	}
+	g.nodesMu.Lock()
+	for _, n := range g.nodes {
+		addNode(n)
+	}
+	g.nodesMu.Unlock()
+	g.observersMu.Lock()
+	for _, o := range g.observers {
+		addNode(o)
+	}
+	g.observersMu.Unlock()
+	g.sentinelsMu.Lock()
+	for _, s := range g.sentinels {
+		addNode(s)
+	}
+	g.sentinelsMu.Unlock()
+	if !options.BindScopes {
+		return
+	}
+	// include the nodes created within bind scopes that are
+	// not necessary (and as a result not tracked by the graph).
+	for index := 0; index < len(nodes); index++ {
+		if typed, ok := nodes[index].(IBindChange); ok {
+			for _, n := range typed.RightScopeNodes() {
+				addNode(n)
+			}
+		}
+	}
+	return
+}
+
+// dotShape returns the shape of a given node.
+func dotShape(n INode) string {
+	if _, ok := n.(IObserver); ok {
+		return "ellipse"
+	}
+	if _, ok := n.(ISentinel); ok {
+		return "diamond"
+	}
+	return "box3d"
+}
+
+// dotWritef returns a helper that writes each line directly to the
+// writer as we go rather than building the output in memory.
+//
+// The helper panics if the write fails, which the caller should recover.
+func dotWritef(wr io.Writer) func(int, string, ...any) {
+	return func(indent int, format string, args ...any) {
+		for x := 0; x < indent; x++ {
+			if _, writeErr := io.WriteString(wr, "\t"); writeErr != nil {
+				panic(writeErr)
+			}
+		}
+		if _, writeErr := fmt.Fprintf(wr, format+"\n", args...); writeErr != nil {
+			panic(writeErr)
+		}
+	}
+}
+
 // escapeForDot escapes double quotes and backslashes, and replaces Graphviz's
 // "center" character (\n) with a left-justified character.
 // See https://graphviz.org/docs/attr-types/escString/ for more info.
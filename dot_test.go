@@ -35,3 +35,143 @@ func Test_Dot(t *testing.T) {
 	testutil.Equal(t, true, strings.Contains(buffer.String(), v0.Node().id.Short()))
 	testutil.Equal(t, true, strings.Contains(buffer.String(), v1.Node().id.Short()))
 }
+
+func Test_Dot_deterministicIdentifiers(t *testing.T) {
+	makeGraph := func() *Graph {
+		g := New(OptGraphIdentifierProvider(NewCounterIdentifierProvider()))
+		v0 := Var(g, "foo")
+		v1 := Var(g, "bar")
+		m2 := Map2(g, v0, v1, concat)
+		m3 := Map2(g, m2, Return(g, "const"), concat)
+		_ = Sentinel(g, func() bool { return true }, m2)
+		_ = MustObserve(g, m3)
+		_ = g.Stabilize(testContext())
+		return g
+	}
+
+	buffer0 := new(bytes.Buffer)
+	err := Dot(buffer0, makeGraph())
+	testutil.NoError(t, err)
+
+	buffer1 := new(bytes.Buffer)
+	err = Dot(buffer1, makeGraph())
+	testutil.NoError(t, err)
+
+	testutil.NotEqual(t, "", buffer0.String())
+	testutil.Equal(t, buffer0.String(), buffer1.String())
+}
+
+func Test_Dot_bindScopes(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphIdentifierProvider(NewCounterIdentifierProvider()))
+	t1 := Map(g, Return(g, "hello"), func(v string) string { return v + " world!" })
+	t1.Node().SetLabel("t1")
+	sw := Var(g, true)
+	sw.Node().SetLabel("sw")
+	t2 := Bind(g, sw, func(scope Scope, swv bool) Incr[string] {
+		if swv {
+			return Map(scope, t1, func(v string) string { return v + " Ipsum" })
+		}
+		// this node is created in the bind scope but is never linked.
+		_ = Return(scope, "unused")
+		return Map(scope, Return(scope, "lorem"), func(v string) string { return v + " Ipsum" })
+	})
+	t2.Node().SetLabel("t2")
+	_ = MustObserve(g, t2)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	err = Dot(buffer, g, DotWithBindScopes(true), DotWithChangedAt(true))
+	testutil.NoError(t, err)
+	testutil.Equal(t, dotBindScopesTrueGolden, buffer.String())
+
+	sw.Set(false)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	buffer = new(bytes.Buffer)
+	err = Dot(buffer, g, DotWithBindScopes(true), DotWithChangedAt(true))
+	testutil.NoError(t, err)
+	testutil.Equal(t, dotBindScopesFalseGolden, buffer.String())
+}
+
+const dotBindScopesTrueGolden = `digraph {
+	node [label = "bind:00000006
+label: t2
+height: 3
+changed at: 1
+value: hello world! Ipsum" shape = "box3d" fillcolor = "pink" style="filled" fontcolor="black"]; n1
+	node [label = "map:00000008
+height: 2
+changed at: 1
+value: hello world! Ipsum" shape = "box3d" fillcolor = "pink" style="filled" fontcolor="black"]; n2
+	node [label = "bind-lhs-change:00000005
+height: 1
+changed at: 1" shape = "box3d" fillcolor = "pink" style="filled" fontcolor="black"]; n3
+	node [label = "map:00000003
+label: t1
+height: 1
+changed at: 1
+value: hello world!" shape = "box3d" fillcolor = "pink" style="filled" fontcolor="black"]; n4
+	node [label = "var:00000004
+label: sw
+height: 0
+changed at: 0
+value: true" shape = "box3d" fillcolor = "white" style="filled" fontcolor="black"]; n5
+	node [label = "return:00000002
+height: 0
+changed at: 1
+value: hello" shape = "box3d" fillcolor = "pink" style="filled" fontcolor="black"]; n6
+	node [label = "observer:00000007
+changed at: 0
+value: hello world! Ipsum" shape = "ellipse" fillcolor = "white" style="filled" fontcolor="black"]; n7
+	n1 -> n7;
+	n2 -> n1 [style = "dashed"];
+	n3 -> n1 [style = "dashed"];
+	n3 -> n2 [style = "dotted" arrowhead = "none"];
+	n4 -> n2;
+	n5 -> n3;
+	n6 -> n4;
+}
+`
+
+const dotBindScopesFalseGolden = `digraph {
+	node [label = "bind:00000006
+label: t2
+height: 4
+changed at: 2
+value: lorem Ipsum" shape = "box3d" fillcolor = "pink" style="filled" fontcolor="black"]; n1
+	node [label = "map:0000000b
+height: 3
+changed at: 2
+value: lorem Ipsum" shape = "box3d" fillcolor = "pink" style="filled" fontcolor="black"]; n2
+	node [label = "return:0000000a
+height: 2
+changed at: 2
+value: lorem" shape = "box3d" fillcolor = "pink" style="filled" fontcolor="black"]; n3
+	node [label = "bind-lhs-change:00000005
+height: 1
+changed at: 2" shape = "box3d" fillcolor = "pink" style="filled" fontcolor="black"]; n4
+	node [label = "var:00000004
+label: sw
+height: 0
+changed at: 2
+value: false" shape = "box3d" fillcolor = "red" style="filled" fontcolor="white"]; n5
+	node [label = "return:00000009
+changed at: 0
+value: unused" shape = "box3d" fillcolor = "white" style="filled,dashed" fontcolor="black"]; n6
+	node [label = "observer:00000007
+changed at: 0
+value: lorem Ipsum" shape = "ellipse" fillcolor = "white" style="filled" fontcolor="black"]; n7
+	n1 -> n7;
+	n2 -> n1 [style = "dashed"];
+	n3 -> n2;
+	n4 -> n1 [style = "dashed"];
+	n4 -> n6 [style = "dotted" arrowhead = "none"];
+	n4 -> n3 [style = "dotted" arrowhead = "none"];
+	n4 -> n2 [style = "dotted" arrowhead = "none"];
+	n5 -> n4;
+}
+`
@@ -0,0 +1,115 @@
+package incr
+
+// newDynamicInputs returns the dynamic inputs for a given list of inputs.
+func newDynamicInputs[A, S any](inputs []Incr[A]) dynamicInputs[A, S] {
+	return dynamicInputs[A, S]{
+		inputs: inputs,
+		seen:   make([]dynamicInputSeen[S], len(inputs)),
+	}
+}
+
+// dynamicInputs holds the inputs of a node that can add and remove inputs
+// after it's created, and that only reads the inputs that changed since
+// it was last recomputed, along with some state the node keeps per input.
+type dynamicInputs[A, S any] struct {
+	inputs []Incr[A]
+	// seen holds, for each input by index, the changedAt of the input
+	// as of when it was last read and the node's state for the input.
+	seen []dynamicInputSeen[S]
+}
+
+type dynamicInputSeen[S any] struct {
+	ok        bool
+	changedAt uint64
+	state     S
+}
+
+func (di *dynamicInputs[A, S]) parents() []INode {
+	output := make([]INode, len(di.inputs))
+	for i := 0; i < len(di.inputs); i++ {
+		output[i] = di.inputs[i]
+	}
+	return output
+}
+
+// add adds an input to a given node.
+func (di *dynamicInputs[A, S]) add(n INode, i Incr[A]) error {
+	di.inputs = append(di.inputs, i)
+	di.seen = append(di.seen, dynamicInputSeen[S]{})
+	if n.Node().height != HeightUnset {
+		graph := GraphForNode(n)
+		if err := graph.addChild(n, i); err != nil {
+			return err
+		}
+		// the input may not be recomputed if it's already
+		// up to date, so make sure we pick up its value.
+		graph.setStale(n, RecomputeReasonLinked)
+	}
+	return nil
+}
+
+// remove removes the input with a given identifier from a given node,
+// calling a given function with the node's state for the input if the
+// input has been read.
+//
+// If none of the inputs have the identifier [ErrInputNotFound] is returned.
+func (di *dynamicInputs[A, S]) remove(n INode, id Identifier, fn func(S)) error {
+	var removed Incr[A]
+	inputs := make([]Incr[A], 0, len(di.inputs))
+	seen := make([]dynamicInputSeen[S], 0, len(di.seen))
+	for index, i := range di.inputs {
+		if i.Node().id != id {
+			inputs = append(inputs, i)
+			seen = append(seen, di.seen[index])
+			continue
+		}
+		removed = i
+		if s := di.seen[index]; s.ok && fn != nil {
+			fn(s.state)
+		}
+	}
+	if removed == nil {
+		return ErrInputNotFound
+	}
+	di.inputs, di.seen = inputs, seen
+	return removeInput(n, removed)
+}
+
+// stale returns if the inputs must all be read again when a given node
+// is recomputed, that is, if the node's changedAt was reset because it
+// was unobserved, in which case the values read may no longer be
+// accurate, or if the graph is recomputing all nodes.
+func (di *dynamicInputs[A, S]) stale(n INode) bool {
+	return n.Node().changedAt == 0 || GraphForNode(n).recomputingAll
+}
+
+// reset forgets the inputs that have been read, and
+// the node's state for them, such that they're read again.
+func (di *dynamicInputs[A, S]) reset() {
+	di.seen = make([]dynamicInputSeen[S], len(di.inputs))
+}
+
+// eachChanged reads the inputs that changed since they were last
+// read (or haven't been read), calling a given function with the
+// value of each, the node's state for the input, and if the input
+// had been read before.
+func (di *dynamicInputs[A, S]) eachChanged(fn func(value A, state *S, seen bool)) {
+	for index, i := range di.inputs {
+		s := &di.seen[index]
+		changedAt := i.Node().changedAt
+		if s.ok && s.changedAt == changedAt {
+			continue
+		}
+		seen := s.ok
+		s.ok, s.changedAt = true, changedAt
+		fn(i.Value(), &s.state, seen)
+	}
+}
+
+// each calls a given function with the node's
+// state for each input in order.
+func (di *dynamicInputs[A, S]) each(fn func(S)) {
+	for _, s := range di.seen {
+		fn(s.state)
+	}
+}
@@ -0,0 +1,59 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_dynamicInputs(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	js := JoinStrings(g, "", v0, v1).(*joinStringsIncr)
+	_ = MustObserve(g, js)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	type read struct {
+		value string
+		seen  bool
+	}
+	var reads []read
+	eachChanged := func() {
+		reads = nil
+		js.inputs.eachChanged(func(value string, state *string, seen bool) {
+			*state = value
+			reads = append(reads, read{value, seen})
+		})
+	}
+
+	eachChanged()
+	testutil.Empty(t, reads)
+
+	v1.Set("c")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "ac", js.Value())
+
+	js.inputs.reset()
+	eachChanged()
+	testutil.Equal(t, []read{{"a", false}, {"c", false}}, reads)
+
+	var removed []string
+	err = js.inputs.remove(js, v0.Node().ID(), func(value string) {
+		removed = append(removed, value)
+	})
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"a"}, removed)
+	testutil.Equal(t, 1, len(js.inputs.parents()))
+
+	err = js.inputs.remove(js, v0.Node().ID(), func(value string) {
+		removed = append(removed, value)
+	})
+	testutil.Equal(t, ErrInputNotFound, err)
+	testutil.Equal(t, []string{"a"}, removed)
+}
@@ -0,0 +1,104 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// RisingEdge returns an incremental that is true only for the stabilization
+// in which the input transitions from false to true, and false otherwise.
+//
+// The first value of the input is not considered a transition, that is, the
+// node is false after the first stabilization even if the input is true.
+//
+// The node is cut off unless its value changes, such that update handlers registered
+// on it are called when it becomes true, and again when it's reset to false on
+// the following stabilization, but not for stabilizations in which the input
+// stays true (or false).
+func RisingEdge(scope Scope, input Incr[bool]) Incr[bool] {
+	return WithinScope(scope, &edgeIncr{
+		n:      NewNode("rising_edge"),
+		input:  input,
+		rising: true,
+	})
+}
+
+// FallingEdge returns an incremental that is true only for the stabilization
+// in which the input transitions from true to false, and false otherwise.
+//
+// It otherwise behaves like [RisingEdge].
+func FallingEdge(scope Scope, input Incr[bool]) Incr[bool] {
+	return WithinScope(scope, &edgeIncr{
+		n:     NewNode("falling_edge"),
+		input: input,
+	})
+}
+
+var (
+	_ Incr[bool]   = (*edgeIncr)(nil)
+	_ IParents     = (*edgeIncr)(nil)
+	_ IAlwaysWhen  = (*edgeIncr)(nil)
+	_ ICutoff      = (*edgeIncr)(nil)
+	_ IStabilize   = (*edgeIncr)(nil)
+	_ fmt.Stringer = (*edgeIncr)(nil)
+)
+
+type edgeIncr struct {
+	n      *Node
+	input  Incr[bool]
+	rising bool
+	// previous is the value of the input as of the last
+	// time the node was recomputed or cut off.
+	previous    bool
+	hasPrevious bool
+	// next holds the value computed by the cutoff,
+	// to be applied by stabilize.
+	next    bool
+	hasNext bool
+	value   bool
+}
+
+func (e *edgeIncr) Parents() []INode {
+	return []INode{e.input}
+}
+
+func (e *edgeIncr) Node() *Node { return e.n }
+
+func (e *edgeIncr) Value() bool { return e.value }
+
+func (e *edgeIncr) Always() {}
+
+// ShouldRecompute returns if the node needs to be reset to false after a transition,
+// otherwise the node is only recomputed if its input changes.
+func (e *edgeIncr) ShouldRecompute(_ context.Context) (bool, error) {
+	return e.value, nil
+}
+
+func (e *edgeIncr) Cutoff(_ context.Context) (bool, error) {
+	e.next = e.advance()
+	if e.n.changedAt > 0 && e.next == e.value {
+		return true, nil
+	}
+	e.hasNext = true
+	return false, nil
+}
+
+func (e *edgeIncr) Stabilize(_ context.Context) error {
+	if !e.hasNext {
+		e.next = e.advance()
+	}
+	e.value = e.next
+	e.hasNext = false
+	return nil
+}
+
+func (e *edgeIncr) String() string { return e.n.String() }
+
+// advance records the current value of the input, returning
+// if it's a transition from the previously recorded value.
+func (e *edgeIncr) advance() bool {
+	current := e.input.Value()
+	isEdge := e.hasPrevious && current != e.previous && current == e.rising
+	e.previous, e.hasPrevious = current, true
+	return isEdge
+}
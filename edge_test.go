@@ -0,0 +1,129 @@
+package incr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_RisingEdge(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, false)
+	re := RisingEdge(g, v)
+	testutil.Equal(t, "rising_edge", re.Node().Kind())
+
+	var updates, transitions int
+	re.Node().OnUpdate(func(_ context.Context) {
+		updates++
+		if re.Value() {
+			transitions++
+		}
+	})
+	_ = MustObserve(g, re)
+
+	steps := []struct {
+		Input       bool
+		Expected    bool
+		Transitions int
+	}{
+		{false, false, 0},
+		{true, true, 1},
+		{true, false, 1},
+		{true, false, 1},
+		{false, false, 1},
+		{false, false, 1},
+		{true, true, 2},
+		{false, false, 2},
+		{true, true, 3},
+	}
+	for index, step := range steps {
+		v.Set(step.Input)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, step.Expected, re.Value(), index)
+		testutil.Equal(t, step.Transitions, transitions, index)
+	}
+	// the node is updated when it's first computed, on each
+	// transition, and each time it's reset after a transition.
+	testutil.Equal(t, 6, updates)
+}
+
+func Test_RisingEdge_initialTrue(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, true)
+	re := RisingEdge(g, v)
+	_ = MustObserve(g, re)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, re.Value())
+
+	v.Set(false)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, re.Value())
+
+	v.Set(true)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, re.Value())
+}
+
+func Test_FallingEdge(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, true)
+	fe := FallingEdge(g, v)
+	testutil.Equal(t, "falling_edge", fe.Node().Kind())
+
+	var recomputes int
+	m := Map(g, fe, func(vv bool) bool {
+		recomputes++
+		return vv
+	})
+	o := MustObserve(g, m)
+
+	steps := []struct {
+		Input    bool
+		Expected bool
+	}{
+		{true, false},
+		{false, true},
+		{false, false},
+		{false, false},
+		{true, false},
+		{false, true},
+		{true, false},
+	}
+	for index, step := range steps {
+		v.Set(step.Input)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, step.Expected, o.Value(), index)
+	}
+	testutil.Equal(t, 5, recomputes)
+}
+
+func Test_RisingEdge_parallel(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, false)
+	re := RisingEdge(g, v)
+	o := MustObserve(g, re)
+
+	err := g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, o.Value())
+
+	v.Set(true)
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, o.Value())
+
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, o.Value())
+}
@@ -1,8 +1,97 @@
 package incr
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
 
 var (
 	// ErrAlreadyStabilizing is returned if you're already stabilizing a graph.
 	ErrAlreadyStabilizing = errors.New("stabilize; already stabilizing, cannot continue")
+	// ErrNodeAlreadyInGraph is returned if you attempt to attach a node that belongs
+	// to one graph to a different graph, e.g. by observing it or linking it.
+	//
+	// Use [MigrateNode] to move nodes between graphs.
+	ErrNodeAlreadyInGraph = errors.New("node already belongs to a different graph")
+	// ErrStabilizationAborted is returned by stabilization if a node, e.g. an [AbortIf] node,
+	// stops the stabilization early.
+	//
+	// Unlike other errors returned by nodes it is returned as is (that is, not wrapped in a [NodeError]),
+	// it does not call the node's error handlers, and the nodes that have not been recomputed
+	// yet are left in place so that the graph can be stabilized again.
+	ErrStabilizationAborted = errors.New("stabilize; aborted")
+	// ErrRecomputeBudgetExceeded is returned by stabilization if more nodes would be recomputed
+	// than the maximum set with [OptGraphMaxRecomputesPerStabilize].
+	ErrRecomputeBudgetExceeded = errors.New("stabilize; recompute budget exceeded")
+	// ErrInputNotFound is returned when removing an input from a node that can add and
+	// remove inputs over time, e.g. with [MapNIncr.RemoveInput], by an identifier that
+	// isn't the identifier of one of the node's inputs.
+	ErrInputNotFound = errors.New("remove input; input not found")
 )
+
+// NodeError is an error returned by stabilization that wraps an error
+// returned by a node's stabilize or cutoff function with the
+// identifying information of the node that produced it.
+//
+// Use [errors.As] to extract the [NodeError] from an error returned by stabilization,
+// and [errors.Is] or [errors.Unwrap] to reach the original error.
+type NodeError struct {
+	ID     Identifier
+	Kind   string
+	Label  string
+	Height int
+	Err    error
+}
+
+// Error implements error.
+func (ne *NodeError) Error() string {
+	if ne.Label != "" {
+		return fmt.Sprintf("%s[%s]:%s@%d: %v", ne.Kind, ne.ID.Short(), ne.Label, ne.Height, ne.Err)
+	}
+	return fmt.Sprintf("%s[%s]@%d: %v", ne.Kind, ne.ID.Short(), ne.Height, ne.Err)
+}
+
+// Unwrap returns the original error returned by the node.
+func (ne *NodeError) Unwrap() error {
+	return ne.Err
+}
+
+func newNodeError(n *Node, err error) *NodeError {
+	return &NodeError{
+		ID:     n.id,
+		Kind:   n.kind,
+		Label:  n.label,
+		Height: n.height,
+		Err:    err,
+	}
+}
+
+// PanicError is an error returned by stabilization, wrapped in a [NodeError],
+// if a node's function panics while the node is recomputed.
+//
+// Use [errors.As] to extract the [PanicError] from an error returned by stabilization.
+type PanicError struct {
+	// Value is the value the node panicked with.
+	Value any
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack string
+}
+
+// Error implements error.
+func (pe *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", pe.Value)
+}
+
+// Unwrap returns the value the node panicked with if it's an error.
+func (pe *PanicError) Unwrap() error {
+	err, _ := pe.Value.(error)
+	return err
+}
+
+func newPanicError(value any) *PanicError {
+	return &PanicError{
+		Value: value,
+		Stack: string(debug.Stack()),
+	}
+}
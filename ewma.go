@@ -0,0 +1,71 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// EWMA returns an incremental that maintains an exponentially weighted
+// moving average of the values of the input incremental.
+//
+// The average is advanced each time the input changes; the first value seen
+// initializes the average, and each subsequent value is blended in as:
+//
+//	average = alpha*value + (1-alpha)*average
+//
+// The alpha value should be in the range (0, 1], where larger values weight
+// recent values more heavily.
+func EWMA(scope Scope, input Incr[float64], alpha float64) Incr[float64] {
+	return WithinScope(scope, &ewmaIncr{
+		n:     NewNode("ewma"),
+		input: input,
+		alpha: alpha,
+	})
+}
+
+var (
+	_ Incr[float64] = (*ewmaIncr)(nil)
+	_ IParents      = (*ewmaIncr)(nil)
+	_ ICutoff       = (*ewmaIncr)(nil)
+	_ IStabilize    = (*ewmaIncr)(nil)
+	_ fmt.Stringer  = (*ewmaIncr)(nil)
+)
+
+type ewmaIncr struct {
+	n           *Node
+	input       Incr[float64]
+	alpha       float64
+	initialized bool
+	changes     inputChanges
+	value       float64
+}
+
+func (e *ewmaIncr) Parents() []INode {
+	return []INode{e.input}
+}
+
+func (e *ewmaIncr) Node() *Node { return e.n }
+
+func (e *ewmaIncr) Value() float64 { return e.value }
+
+func (e *ewmaIncr) Cutoff(_ context.Context) (bool, error) {
+	return !e.changes.changed(e.input), nil
+}
+
+func (e *ewmaIncr) Stabilize(_ context.Context) error {
+	if !e.changes.advance(e, e.input) {
+		return nil
+	}
+	newValue := e.input.Value()
+	if !e.initialized {
+		e.value = newValue
+		e.initialized = true
+		return nil
+	}
+	e.value = e.alpha*newValue + (1-e.alpha)*e.value
+	return nil
+}
+
+func (e *ewmaIncr) String() string {
+	return e.n.String()
+}
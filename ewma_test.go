@@ -0,0 +1,58 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_EWMA(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 10.0)
+	e := EWMA(g, v, 0.5)
+	o := MustObserve(g, e)
+
+	testutil.Matches(t, `ewma\[(.*)\]`, e.(*ewmaIncr).String())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10.0, o.Value())
+
+	v.Set(20)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 15.0, o.Value())
+
+	// the average only advances when the input changes.
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 15.0, o.Value())
+
+	v.Set(5)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10.0, o.Value())
+}
+
+func Test_EWMA_setStale(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 10.0)
+	e := EWMA(g, v, 0.5)
+	o := MustObserve(g, e)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	v.Set(20)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 15.0, o.Value())
+
+	// recomputing the node without the input changing
+	// shouldn't blend the input into the average again.
+	g.SetStale(e)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 15.0, o.Value())
+}
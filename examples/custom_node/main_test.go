@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wcharczuk/go-incr"
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Custom(t *testing.T) {
+	ctx := context.Background()
+	graph := incr.New()
+	v := incr.Var(graph, "hello")
+	c := Custom(graph, v)
+	oc := incr.MustObserve(graph, c)
+
+	err := graph.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello", oc.Value())
+
+	v.Set("world")
+	err = graph.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "world", oc.Value())
+}
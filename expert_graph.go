@@ -33,6 +33,15 @@ type IExpertGraph interface {
 	// NumObservers returns the current count of observers the [Graph] is tracking.
 	NumObservers() uint64
 
+	// NumSentinels returns the current count of sentinels the [Graph] is tracking.
+	NumSentinels() uint64
+
+	// MaxHeightSeen returns the largest height that has been assigned to a node
+	// in the [Graph] over its lifetime.
+	//
+	// This is useful when tuning [OptGraphMaxHeight] and [OptGraphPreallocateHeightListsSize].
+	MaxHeightSeen() int
+
 	// StabilizationNum returns the current stabilization number of the [Graph].
 	StabilizationNum() uint64
 
@@ -46,6 +55,18 @@ type IExpertGraph interface {
 	// RecomputeHeapLen returns the current length of the recompute heap.
 	RecomputeHeapLen() int
 
+	// RecomputeHeapHeights returns the number of height blocks the recompute heap can hold,
+	// or for sparse recompute heaps (see [OptGraphRecomputeHeapSparse]) the number it has allocated.
+	//
+	// This is useful when tuning [OptGraphRecomputeHeapInitialHeights].
+	RecomputeHeapHeights() int
+
+	// RecomputeHeapHeightListsAllocated returns the number of height blocks in the recompute heap
+	// that have had their backing list allocated.
+	//
+	// This is useful when tuning [OptGraphPreallocateHeightListsSize].
+	RecomputeHeapHeightListsAllocated() int
+
 	// RecomputeHeapIDs returns the node identifiers that are held in the recompute heap.
 	//
 	// This is useful when saving the state of a [Graph] to an external store.
@@ -75,6 +96,14 @@ func (eg *expertGraph) NumObservers() uint64 {
 	return uint64(len(eg.graph.observers))
 }
 
+func (eg *expertGraph) NumSentinels() uint64 {
+	return uint64(len(eg.graph.sentinels))
+}
+
+func (eg *expertGraph) MaxHeightSeen() int {
+	return eg.graph.adjustHeightsHeap.maxHeightSeen
+}
+
 func (eg *expertGraph) NumNodesRecomputed() uint64 {
 	return eg.graph.numNodesRecomputed
 }
@@ -97,7 +126,7 @@ func (eg *expertGraph) SetStabilizationNum(stabilizationNum uint64) {
 
 func (eg *expertGraph) RecomputeHeapAdd(nodes ...INode) {
 	for _, n := range nodes {
-		eg.graph.recomputeHeap.add(n)
+		eg.graph.recomputeHeap.addIfNotPresent(n, RecomputeReasonSetStale)
 	}
 }
 
@@ -105,17 +134,21 @@ func (eg *expertGraph) RecomputeHeapLen() int {
 	return eg.graph.recomputeHeap.len()
 }
 
+func (eg *expertGraph) RecomputeHeapHeights() int {
+	return eg.graph.recomputeHeap.numHeights()
+}
+
+func (eg *expertGraph) RecomputeHeapHeightListsAllocated() int {
+	return eg.graph.recomputeHeap.numHeightListsAllocated()
+}
+
 func (eg *expertGraph) RecomputeHeapIDs() []Identifier {
 	output := make([]Identifier, 0, eg.graph.recomputeHeap.numItems)
-	for _, height := range eg.graph.recomputeHeap.heights {
-		if height != nil {
-			cursor := height.head
-			for cursor != nil {
-				output = append(output, cursor.Node().id)
-				cursor = cursor.Node().nextInRecomputeHeap
-			}
+	eg.graph.recomputeHeap.eachListUnsafe(func(_ int, l *recomputeHeapList) {
+		for cursor := l.head; cursor != nil; cursor = cursor.Node().nextInRecomputeHeap {
+			output = append(output, cursor.Node().id)
 		}
-	}
+	})
 	return output
 }
 
@@ -63,3 +63,22 @@ func Test_ExpertGraph_RecomputeHeapIDs(t *testing.T) {
 	testutil.Any(t, recomputeHeapIDs, func(id Identifier) bool { return id == n1.n.id })
 	testutil.Any(t, recomputeHeapIDs, func(id Identifier) bool { return id == n2.n.id })
 }
+
+func Test_ExpertGraph_sizing(t *testing.T) {
+	g := New(OptGraphMaxHeight(64), OptGraphPreallocateHeightListsSize(8))
+	eg := ExpertGraph(g)
+
+	testutil.Equal(t, 64, eg.RecomputeHeapHeights())
+	testutil.Equal(t, 8, eg.RecomputeHeapHeightListsAllocated())
+	testutil.Equal(t, 0, eg.MaxHeightSeen())
+	testutil.Equal(t, 0, eg.NumSentinels())
+
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	m1 := Map(g, m0, ident)
+	_ = MustObserve(g, m1)
+	_ = Sentinel(g, func() bool { return true }, m1)
+
+	testutil.Equal(t, 2, eg.MaxHeightSeen())
+	testutil.Equal(t, 1, eg.NumSentinels())
+}
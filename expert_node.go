@@ -49,6 +49,14 @@ type IExpertNode interface {
 	IsStale() bool
 	IsInRecomputeHeap() bool
 
+	// RecomputeReason returns the reason the node is in the recompute heap, or
+	// [RecomputeReasonNone] if it is not in the recompute heap.
+	RecomputeReason() RecomputeReason
+	// LastRecomputeReason returns the reason the node was recomputed in the
+	// current (or most recent) stabilization, or [RecomputeReasonNone] if
+	// it was not recomputed in that stabilization.
+	LastRecomputeReason() RecomputeReason
+
 	Always() bool
 	SetAlways(bool)
 
@@ -149,8 +157,23 @@ func (en *expertNode) SetNumChanges(numChanges uint64) {
 	en.node.numChanges = numChanges
 }
 
-func (en *expertNode) IsNecessary() bool       { return en.node.isNecessary() }
-func (en *expertNode) IsStale() bool           { return en.node.isStale() }
+func (en *expertNode) IsNecessary() bool { return en.node.isNecessary() }
+func (en *expertNode) IsStale() bool     { return en.node.isStale() }
+
+func (en *expertNode) RecomputeReason() RecomputeReason {
+	if en.node.heightInRecomputeHeap == HeightUnset {
+		return RecomputeReasonNone
+	}
+	return en.node.recomputeReason
+}
+
+func (en *expertNode) LastRecomputeReason() RecomputeReason {
+	graph := GraphForNode(en.incr)
+	if graph == nil || en.node.recomputedAt != graph.latestStabilizationNum() {
+		return RecomputeReasonNone
+	}
+	return en.node.lastRecomputeReason
+}
 func (en *expertNode) IsInRecomputeHeap() bool { return en.node.heightInRecomputeHeap != HeightUnset }
 
 func (en *expertNode) Always() bool { return en.node.always }
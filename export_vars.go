@@ -0,0 +1,95 @@
+package incr
+
+import (
+	"fmt"
+)
+
+// ExportVars returns the values of the [Var] nodes the graph is
+// tracking keyed by the identifier of each var.
+//
+// Only vars that are necessary, that is, observed by at least one observer,
+// are tracked by the graph and included in the export.
+//
+// Used with [Graph.ImportVars] this enables "warm" restarts; you can
+// rebuild the graph structure in code, with deterministic identifiers
+// (see [OptGraphIdentifierProvider]), import the previously exported var
+// values and stabilize to reproduce the previous computation.
+func (graph *Graph) ExportVars() map[Identifier]any {
+	output := make(map[Identifier]any)
+	graph.nodesMu.Lock()
+	defer graph.nodesMu.Unlock()
+	for id, n := range graph.nodes {
+		if typed, ok := n.(iVarValue); ok {
+			output[id] = typed.exportValue()
+		}
+	}
+	return output
+}
+
+// ImportVars sets the values of the [Var] nodes the graph is tracking
+// from a map of values keyed by the identifier of each var, typically as
+// returned by [Graph.ExportVars].
+//
+// Identifiers that do not correspond to a var tracked by the graph are ignored.
+//
+// If a value's type does not match the type of its var an error is returned
+// naming the var, and none of the values are imported.
+func (graph *Graph) ImportVars(values map[Identifier]any) error {
+	vars := make(map[Identifier]iVarValue, len(values))
+	graph.nodesMu.Lock()
+	for id := range values {
+		if typed, ok := graph.nodes[id].(iVarValue); ok {
+			vars[id] = typed
+		}
+	}
+	graph.nodesMu.Unlock()
+	for id, v := range vars {
+		if err := v.checkImportValue(values[id]); err != nil {
+			return err
+		}
+	}
+	for id, v := range vars {
+		v.importValue(values[id])
+	}
+	return nil
+}
+
+// iVarValue is the internal interface used to export and import
+// var values without knowing the var's type.
+type iVarValue interface {
+	INode
+	exportValue() any
+	checkImportValue(any) error
+	importValue(any)
+}
+
+var _ iVarValue = (*varIncr[string])(nil)
+
+func (vn *varIncr[T]) exportValue() any {
+	vn.mu.Lock()
+	defer vn.mu.Unlock()
+	return vn.latestValueUnsafe()
+}
+
+func (vn *varIncr[T]) checkImportValue(v any) error {
+	if _, ok := vn.castImportValue(v); !ok {
+		var zero T
+		return fmt.Errorf("import vars; var %v has type %T but import value has type %T", vn, zero, v)
+	}
+	return nil
+}
+
+func (vn *varIncr[T]) importValue(v any) {
+	typed, _ := vn.castImportValue(v)
+	vn.Set(typed)
+}
+
+func (vn *varIncr[T]) castImportValue(v any) (typed T, ok bool) {
+	if v == nil {
+		// a nil value is only valid for interface typed vars.
+		ok = any(typed) == nil
+		return
+	}
+	typed, ok = v.(T)
+	return
+}
@@ -0,0 +1,111 @@
+package incr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_ExportVars_ImportVars(t *testing.T) {
+	ctx := testContext()
+
+	build := func() (*Graph, VarIncr[string], VarIncr[int], ObserveIncr[string]) {
+		g := New(OptGraphIdentifierProvider(NewCounterIdentifierProvider()))
+		v0 := Var(g, "a")
+		v1 := Var(g, 1)
+		m := Map2(g, v0, v1, func(a string, b int) string {
+			return fmt.Sprintf("%s-%d", a, b)
+		})
+		o := MustObserve(g, m)
+		return g, v0, v1, o
+	}
+
+	g, v0, v1, o := build()
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a-1", o.Value())
+
+	v0.Set("b")
+	v1.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b-2", o.Value())
+
+	exported := g.ExportVars()
+	testutil.Equal(t, 2, len(exported))
+	testutil.Equal(t, "b", exported[v0.Node().ID()])
+	testutil.Equal(t, 2, exported[v1.Node().ID()])
+
+	restored, rv0, rv1, ro := build()
+	testutil.Equal(t, v0.Node().ID(), rv0.Node().ID())
+	testutil.Equal(t, v1.Node().ID(), rv1.Node().ID())
+
+	err = restored.ImportVars(exported)
+	testutil.NoError(t, err)
+	err = restored.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b-2", ro.Value())
+}
+
+func Test_Graph_ExportVars_unobserved(t *testing.T) {
+	g := New()
+	v0 := Var(g, "a")
+	_ = Var(g, "b")
+	_ = MustObserve(g, v0)
+
+	exported := g.ExportVars()
+	testutil.Equal(t, 1, len(exported))
+	testutil.Equal(t, "a", exported[v0.Node().ID()])
+}
+
+func Test_Graph_ImportVars_unknownIDs(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	o := MustObserve(g, v)
+
+	err := g.ImportVars(map[Identifier]any{
+		NewIdentifier(): "nope",
+		v.Node().ID():   "b",
+	})
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b", o.Value())
+}
+
+func Test_Graph_ImportVars_typeMismatch(t *testing.T) {
+	g := New()
+	v0 := Var(g, "a")
+	v1 := Var(g, 1)
+	v1.Node().SetLabel("the-int-var")
+	_ = g.MustObserveMany(testContext(), v0, v1)
+
+	err := g.ImportVars(map[Identifier]any{
+		v0.Node().ID(): "b",
+		v1.Node().ID(): "not-an-int",
+	})
+	testutil.Error(t, err)
+	testutil.Matches(t, "the-int-var", err.Error())
+	testutil.Equal(t, "a", v0.Value())
+	testutil.Equal(t, 1, v1.Value())
+}
+
+func Test_Graph_ImportVars_nil(t *testing.T) {
+	g := New()
+	v0 := Var[error](g, fmt.Errorf("this is just a test"))
+	v1 := Var(g, "a")
+	_ = g.MustObserveMany(testContext(), v0, v1)
+
+	err := g.ImportVars(map[Identifier]any{
+		v0.Node().ID(): nil,
+	})
+	testutil.NoError(t, err)
+	testutil.Nil(t, v0.Value())
+
+	err = g.ImportVars(map[Identifier]any{
+		v1.Node().ID(): nil,
+	})
+	testutil.Error(t, err)
+}
@@ -0,0 +1,19 @@
+package incr
+
+// Flatten returns an incremental that passes through the value of the
+// incremental held by a given input incremental.
+//
+// When the input changes to hold a different incremental, the flatten node
+// is relinked to the new incremental; when the held incremental's value changes
+// the flatten node is recomputed even if the input does not change.
+//
+// It is largely a "macro" for a [Bind] whose bind function returns its input, and
+// is intended for the case where the held incrementals were created outside the bind,
+// that is, they are not invalidated when the input changes.
+func Flatten[A any](scope Scope, input Incr[Incr[A]]) BindIncr[A] {
+	b := Bind(scope, input, func(_ Scope, inner Incr[A]) Incr[A] {
+		return inner
+	})
+	b.Node().SetKind("flatten")
+	return b
+}
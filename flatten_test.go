@@ -0,0 +1,72 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Flatten(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	a := Var(g, "a")
+	am := Map(g, a, ident)
+	b := Var(g, "b")
+	bm := Map(g, b, ident)
+
+	outer := Var[Incr[string]](g, am)
+	f := Flatten(g, outer)
+	o := MustObserve(g, f)
+
+	testutil.Equal(t, "flatten", f.Node().Kind())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+	testutil.Equal(t, true, g.Has(am))
+	testutil.Equal(t, false, g.Has(bm))
+
+	// the inner node changing should update the flatten
+	// without the outer changing.
+	a.Set("a-changed")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a-changed", o.Value())
+
+	outer.Set(bm)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b", o.Value())
+	testutil.Equal(t, false, g.Has(am))
+	testutil.Equal(t, true, g.Has(bm))
+
+	// the previous inner node should no longer update the flatten.
+	a.Set("a-changed-again")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b", o.Value())
+
+	b.Set("b-changed")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b-changed", o.Value())
+}
+
+func Test_Flatten_nil(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	outer := Var[Incr[string]](g, nil)
+	f := Flatten(g, outer)
+	o := MustObserve(g, f)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "", o.Value())
+
+	outer.Set(Return(g, "hello"))
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello", o.Value())
+}
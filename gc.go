@@ -0,0 +1,106 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// GC sweeps the nodes the graph is tracking and removes any that are no longer
+// necessary, that is, nodes that are not observed either directly or through one of
+// their children, returning the number of nodes that were collected.
+//
+// Typically the graph removes nodes as they become unnecessary, e.g. as bind nodes
+// change their right-hand sides, but nodes that were linked by hand (or through
+// the [ExpertGraph] interface) can linger; GC reclaims them in long-lived graphs.
+//
+// Collected nodes are unlinked from their parents, which may in turn collect
+// the parents if they are no longer necessary as a result.
+//
+// GC cannot be called while the graph is stabilizing.
+func (graph *Graph) GC(ctx context.Context) (collected int, err error) {
+	if err = graph.ensureNotStabilizing(ctx); err != nil {
+		return
+	}
+
+	graph.nodesMu.Lock()
+	before := len(graph.nodes)
+	var candidates []INode
+	for _, n := range graph.nodes {
+		if !n.Node().isNecessary() {
+			candidates = append(candidates, n)
+		}
+	}
+	graph.nodesMu.Unlock()
+
+	for _, n := range candidates {
+		// a candidate may have already been collected because
+		// one of its children was collected before it.
+		if !graph.Has(n) || n.Node().isNecessary() {
+			continue
+		}
+		for _, p := range copySlice(n.Node().parents) {
+			graph.removeParent(n, p)
+		}
+		graph.removeNode(n)
+	}
+
+	graph.nodesMu.Lock()
+	collected = before - len(graph.nodes)
+	graph.nodesMu.Unlock()
+	TracePrintf(ctx, "gc collected %d node(s)", collected)
+	return
+}
+
+// RemoveNode explicitly removes a node that is no longer necessary from the graph, e.g.
+// a node created within a [Bind] function that was abandoned when the bind changed
+// its right-hand side.
+//
+// The node is unlinked from its parents (a node with children is necessary), removed from the recompute heap
+// and the graph's node tracking, and detached from the scope it was created in such that
+// [GraphForNode] returns nil for it; it must not be used again afterwards.
+//
+// An error is returned if the node is necessary, that is, observed directly or through
+// one of its children, if it's watched by a sentinel or a weak observer, or if it's
+// still referenced by the bind scope it was created in.
+//
+// RemoveNode cannot be called while the graph is stabilizing.
+func (graph *Graph) RemoveNode(n INode) error {
+	if n == nil {
+		return fmt.Errorf("remove node; node is unset")
+	}
+	if atomic.LoadInt32(&graph.status) != StatusNotStabilizing {
+		return ErrAlreadyStabilizing
+	}
+	if GraphForNode(n) != graph {
+		return fmt.Errorf("remove node; node %v does not belong to graph %s", n, graph.id.Short())
+	}
+	nn := n.Node()
+	if nn.isNecessary() {
+		return fmt.Errorf("remove node; node %v is necessary, cannot continue", n)
+	}
+	if len(nn.sentinels) > 0 {
+		return fmt.Errorf("remove node; node %v is watched by a sentinel, cannot continue", n)
+	}
+	if len(nn.weakObservers) > 0 {
+		return fmt.Errorf("remove node; node %v is observed weakly, cannot continue", n)
+	}
+	if scope := nn.createdIn; !scope.isTopScope() {
+		for _, sn := range scope.scopeNodes() {
+			if SameNode(sn, n) {
+				return fmt.Errorf("remove node; node %v is still referenced by the bind scope %v it was created in, cannot continue", n, scope)
+			}
+		}
+	}
+
+	for _, p := range copySlice(nn.parents) {
+		graph.unlink(n, p)
+	}
+	if graph.Has(n) {
+		graph.removeNode(n)
+	} else if nn.heightInRecomputeHeap != HeightUnset {
+		graph.recomputeHeap.remove(n)
+	}
+	nn.createdIn = nil
+	return nil
+}
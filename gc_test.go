@@ -0,0 +1,190 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_GC(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	o := MustObserve(g, m0)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	collected, err := g.GC(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, collected)
+
+	// simulate nodes that were linked by hand and never observed.
+	m1 := Map(g, v, ident)
+	m2 := Map(g, m1, ident)
+	g.addNode(m1)
+	g.link(m1, v)
+	g.addNode(m2)
+	g.link(m2, m1)
+	testutil.Equal(t, true, g.Has(m1))
+	testutil.Equal(t, true, g.Has(m2))
+	testutil.Equal(t, 2, len(v.Node().children))
+
+	collected, err = g.GC(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, collected)
+
+	testutil.Equal(t, false, g.Has(m1))
+	testutil.Equal(t, false, g.Has(m2))
+	testutil.Equal(t, true, g.Has(v))
+	testutil.Equal(t, true, g.Has(m0))
+	testutil.Equal(t, 1, len(v.Node().children))
+	testutil.Empty(t, m1.Node().children)
+	testutil.Empty(t, m2.Node().parents)
+
+	v.Set("world")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "world", o.Value())
+}
+
+func Test_Graph_GC_collectsParents(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	_ = MustObserve(g, m0)
+
+	// m1 is only necessary because of m2, which is not observed.
+	m1 := Map(g, v, ident)
+	m2 := Map(g, m1, ident)
+	g.addNode(m1)
+	g.link(m1, v)
+	g.addNode(m2)
+	g.link(m2, m1)
+
+	numNodes := g.numNodes
+	collected, err := g.GC(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, collected)
+	testutil.Equal(t, numNodes-2, g.numNodes)
+}
+
+func Test_Graph_GC_stabilizing(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	g.status = StatusStabilizing
+
+	_, err := g.GC(ctx)
+	testutil.Equal(t, ErrAlreadyStabilizing, err)
+}
+
+func Test_Graph_RemoveNode(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	var orphan Incr[string]
+	b := Bind(g, v, func(bs Scope, vv string) Incr[string] {
+		if vv == "a" {
+			orphan = Return(bs, "orphan")
+			// simulate a node that was tracked by hand and then abandoned.
+			g.addNode(orphan)
+		}
+		return Map(bs, Return(bs, vv), ident)
+	})
+	o := MustObserve(g, b)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+	testutil.NotNil(t, orphan)
+
+	stats := g.Stats()
+	testutil.Equal(t, 1, stats.NumUnnecessary)
+	testutil.Equal(t, 2, stats.NumNodesByKind["return"])
+
+	err = g.RemoveNode(orphan)
+	testutil.Error(t, err)
+	testutil.Matches(t, "still referenced by the bind scope", err.Error())
+	testutil.Equal(t, true, g.Has(orphan))
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b", o.Value())
+
+	stats = g.Stats()
+	testutil.Equal(t, 1, stats.NumUnnecessary)
+	numNodes := stats.NumNodes
+
+	err = g.RemoveNode(orphan)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.Has(orphan))
+	testutil.Nil(t, GraphForNode(orphan))
+
+	stats = g.Stats()
+	testutil.Equal(t, 0, stats.NumUnnecessary)
+	testutil.Equal(t, numNodes-1, stats.NumNodes)
+	testutil.Equal(t, 1, stats.NumNodesByKind["return"])
+
+	v.Set("c")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "c", o.Value())
+}
+
+func Test_Graph_RemoveNode_necessary(t *testing.T) {
+	g := New()
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	_ = MustObserve(g, m0)
+
+	err := g.RemoveNode(v)
+	testutil.Error(t, err)
+	testutil.Matches(t, "is necessary", err.Error())
+	testutil.Equal(t, true, g.Has(v))
+
+	err = g.RemoveNode(nil)
+	testutil.Error(t, err)
+
+	err = g.RemoveNode(Return(New(), "other"))
+	testutil.Error(t, err)
+	testutil.Matches(t, "does not belong to graph", err.Error())
+}
+
+func Test_Graph_RemoveNode_unlinks(t *testing.T) {
+	g := New()
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	_ = MustObserve(g, m0)
+
+	// simulate nodes that were linked by hand and never observed.
+	m1 := Map(g, v, ident)
+	m2 := Map(g, m1, ident)
+	g.addNode(m1)
+	g.link(m1, v)
+	g.addNode(m2)
+	g.link(m2, m1)
+	m2.Node().height = 2
+	g.recomputeHeap.addIfNotPresent(m2, RecomputeReasonNone)
+
+	err := g.RemoveNode(m1)
+	testutil.Error(t, err)
+	testutil.Matches(t, "is necessary", err.Error())
+
+	err = g.RemoveNode(m2)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.Has(m2))
+	testutil.Equal(t, false, g.recomputeHeap.has(m2))
+	testutil.Empty(t, m2.Node().parents)
+	testutil.Empty(t, m1.Node().children)
+
+	err = g.RemoveNode(m1)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.Has(m1))
+	testutil.Equal(t, 1, len(v.Node().children))
+}
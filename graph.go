@@ -1,10 +1,12 @@
 package incr
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -29,21 +31,46 @@ func New(opts ...GraphOption) *Graph {
 	for _, opt := range opts {
 		opt(&options)
 	}
-	return &Graph{
-		id:                        NewIdentifier(),
+	graphID := NewIdentifier
+	if options.IdentifierProvider != nil {
+		graphID = options.IdentifierProvider
+	}
+	graph := &Graph{
+		id:                        graphID(),
+		identifierProvider:        options.IdentifierProvider,
 		parallelism:               options.Parallelism,
 		clearRecomputeHeapOnError: options.ClearRecomputeHeapOnError,
+		deterministicOrdering:     options.DeterministicOrdering,
+		trackRecomputeTimes:       options.TrackRecomputeTimes,
+		maxRecomputesPerStabilize: uint64(max(options.MaxRecomputesPerStabilize, 0)),
+		disablePanicRecovery:      options.DisablePanicRecovery,
+		skipEmptyStabilizations:   options.SkipEmptyStabilizations,
+		uniqueLabels:              options.UniqueLabels,
+		strictObservation:         options.StrictObservation,
 		stabilizationNum:          1,
 		status:                    StatusNotStabilizing,
 		nodes:                     allocateMapWithSize[Identifier, INode](options.PreallocateNodesSize),
+		labels:                    make(map[string][]INode),
+		unnecessaryVars:           make(map[Identifier]INode),
 		observers:                 allocateMapWithSize[Identifier, IObserver](options.PreallocateObserversSize),
 		sentinels:                 allocateMapWithSize[Identifier, ISentinel](options.PreallocateSentinelsSize),
-		recomputeHeap:             newRecomputeHeap(options.MaxHeight),
 		adjustHeightsHeap:         newAdjustHeightsHeap(options.MaxHeight),
 		setDuringStabilization:    make(map[Identifier]INode),
 		handleAfterStabilization:  make(map[Identifier][]func(context.Context)),
 		propagateInvalidityQueue:  new(queue[INode]),
+		changed:                   make(chan struct{}, 1),
+	}
+	if options.RecomputeHeapSparse {
+		graph.recomputeHeap = newSparseRecomputeHeap(options.RecomputeHeapInitialHeights)
+	} else if options.RecomputeHeapInitialHeights > 0 {
+		graph.recomputeHeap = newRecomputeHeap(min(options.RecomputeHeapInitialHeights, options.MaxHeight))
+	} else {
+		graph.recomputeHeap = newRecomputeHeap(options.MaxHeight)
 	}
+	if options.PreallocateHeightListsSize > 0 {
+		graph.recomputeHeap.preallocateHeightLists(options.PreallocateHeightListsSize)
+	}
+	return graph
 }
 
 func allocateMapWithSize[K comparable, V any](size int) map[K]V {
@@ -103,6 +130,51 @@ func OptGraphPreallocateSentinelsSize(size int) func(*GraphOptions) {
 	}
 }
 
+// OptGraphPreallocateHeightListsSize preallocates the per-height lists within
+// the recompute heap for heights from zero up to (but not including) the given size.
+//
+// This is useful for very large graphs where you know ahead of time roughly how
+// tall the graph will be, and want to avoid allocating these lists as nodes are added.
+//
+// The size will be capped at the number of heights the recompute heap starts with, which
+// is the max height of the graph unless set with [OptGraphRecomputeHeapInitialHeights].
+// If not provided, the lists will be allocated lazily as nodes are added at each height.
+//
+// The lists are kept when the recompute heap is cleared, e.g. on error
+// with [OptGraphClearRecomputeHeapOnError].
+func OptGraphPreallocateHeightListsSize(size int) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.PreallocateHeightListsSize = size
+	}
+}
+
+// OptGraphRecomputeHeapInitialHeights sets the number of heights the recompute heap's
+// lookup of lists by height is sized for when the graph is created, rather than the max
+// height of the graph (see [OptGraphMaxHeight]); the lookup grows as nodes are added
+// at taller heights.
+//
+// For sparse recompute heaps (see [OptGraphRecomputeHeapSparse]), which otherwise
+// start empty, this presizes the map of lists by height.
+func OptGraphRecomputeHeapInitialHeights(heights int) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.RecomputeHeapInitialHeights = heights
+	}
+}
+
+// OptGraphIdentifierProvider sets the function used to generate identifiers
+// for the graph itself, and for nodes created within the graph's scopes.
+//
+// Nodes created with a scope of the graph will have their identifiers drawn from this
+// provider instead of the global provider (see [SetIdentifierProvider]).
+//
+// Passing a deterministic provider, e.g. one returned by [NewCounterIdentifierProvider],
+// makes node identifiers reproducible between runs for graphs that are built identically.
+func OptGraphIdentifierProvider(ip func() Identifier) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.IdentifierProvider = ip
+	}
+}
+
 // OptGraphClearRecomputeHeapOnError controls a setting for whether or not the
 // recompute heap is cleared of nodes on stabilization error.
 //
@@ -116,14 +188,137 @@ func OptGraphClearRecomputeHeapOnError(shouldClear bool) func(*GraphOptions) {
 	}
 }
 
+// OptGraphDeterministicOrdering sets if the graph should recompute the nodes
+// with the same height in the order they were created in (see [Node.Order])
+// during serial stabilization.
+//
+// By default nodes with the same height are recomputed in the order they
+// were added to the recompute heap, which is faster but can vary between
+// runs, e.g. if update handlers have side effects that depend on ordering.
+//
+// With this option update handlers are also called in the order their nodes were created in.
+//
+// Ordering has a small cost as each height block is sorted before it's recomputed.
+//
+// Parallel stabilization does not make recompute ordering guarantees and ignores this option
+// when recomputing nodes.
+func OptGraphDeterministicOrdering() func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.DeterministicOrdering = true
+	}
+}
+
+// OptGraphTrackRecomputeTimes sets if the graph should record the time each
+// node is recomputed, as returned by [Node.LastRecomputedAt].
+//
+// By default recompute times are not tracked, avoiding a call to [time.Now]
+// for each recomputed node.
+func OptGraphTrackRecomputeTimes(track bool) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.TrackRecomputeTimes = track
+	}
+}
+
+// OptGraphMaxRecomputesPerStabilize sets the maximum number of nodes that can
+// be recomputed in a single stabilization, after which the stabilization is
+// stopped and returns an error wrapping [ErrRecomputeBudgetExceeded].
+//
+// This protects against runaway graphs, e.g. where a logic bug causes nodes to
+// be marked stale repeatedly within a stabilization. The node that would have exceeded
+// the budget, and any other nodes not yet recomputed, are left in the recompute heap.
+//
+// By default (or if the maximum is zero or less) the number of recomputes is unlimited.
+func OptGraphMaxRecomputesPerStabilize(maxRecomputes int) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.MaxRecomputesPerStabilize = maxRecomputes
+	}
+}
+
+// OptGraphDisablePanicRecovery sets if the graph should let panics in node
+// functions propagate out of stabilization.
+//
+// By default the graph recovers panics raised while recomputing a node and
+// converts them to a [PanicError] that is returned by stabilization, calling
+// the node's error handlers, such that the graph can be stabilized again.
+func OptGraphDisablePanicRecovery(disable bool) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.DisablePanicRecovery = disable
+	}
+}
+
+// OptGraphSkipEmptyStabilizations sets if [Graph.Stabilize] and [Graph.ParallelStabilize]
+// should return immediately if there is no work to do, as determined by [Graph.NeedsStabilization].
+//
+// When a stabilization is skipped the stabilization start and end handlers are not
+// called and the stabilization number is not advanced.
+//
+// By default every call to stabilize is a full stabilization; use [Graph.StabilizeIfNeeded]
+// to skip stabilizations with no work to do on a per call basis.
+func OptGraphSkipEmptyStabilizations(skip bool) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.SkipEmptyStabilizations = skip
+	}
+}
+
+// OptGraphRecomputeHeapSparse sets if the recompute heap should allocate the lists
+// for each height as they're needed, keeping the heights that have lists in a
+// priority queue, rather than holding a list for each height up to the max height.
+//
+// This saves the memory for the lists of unoccupied heights in tall graphs (see
+// [OptGraphMaxHeight]) that only have nodes at a few heights to recompute at a
+// time. The default recompute heap is typically faster, as it finds the next
+// occupied height with a bitset rather than a map and a priority queue.
+//
+// [OptGraphPreallocateHeightListsSize] has no effect on sparse recompute heaps.
+func OptGraphRecomputeHeapSparse(sparse bool) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.RecomputeHeapSparse = sparse
+	}
+}
+
+// OptGraphUniqueLabels sets if observing a node should return an error if the node,
+// or any of the nodes that become necessary as a result, has the same label as a node
+// the graph already observes (see [Node.SetLabel] and [Graph.FindByLabel]).
+//
+// Labels are only checked when nodes are observed; nodes that are relabeled after
+// they're observed, or that become necessary during stabilization (e.g. the
+// right-hand sides of [Bind] nodes), are indexed without being checked.
+func OptGraphUniqueLabels(unique bool) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.UniqueLabels = unique
+	}
+}
+
+// OptGraphStrictObservation sets if setting a var that isn't necessary, that is, a var that
+// isn't observed (directly or through its children) and as a result isn't recomputed, is
+// traced when the next stabilization starts and counted in [GraphStats.NumUnnecessaryVarSets].
+//
+// The vars set while not necessary can be listed with [Graph.UnnecessaryVars].
+func OptGraphStrictObservation(strict bool) func(*GraphOptions) {
+	return func(g *GraphOptions) {
+		g.StrictObservation = strict
+	}
+}
+
 // GraphOptions are options for graphs.
 type GraphOptions struct {
-	MaxHeight                 int
-	Parallelism               int
-	PreallocateNodesSize      int
-	PreallocateObserversSize  int
-	PreallocateSentinelsSize  int
-	ClearRecomputeHeapOnError bool
+	MaxHeight                   int
+	Parallelism                 int
+	PreallocateNodesSize        int
+	PreallocateObserversSize    int
+	PreallocateSentinelsSize    int
+	PreallocateHeightListsSize  int
+	RecomputeHeapInitialHeights int
+	ClearRecomputeHeapOnError   bool
+	DeterministicOrdering       bool
+	TrackRecomputeTimes         bool
+	MaxRecomputesPerStabilize   int
+	DisablePanicRecovery        bool
+	SkipEmptyStabilizations     bool
+	RecomputeHeapSparse         bool
+	UniqueLabels                bool
+	StrictObservation           bool
+	IdentifierProvider          func() Identifier
 }
 
 const (
@@ -147,6 +342,9 @@ type Graph struct {
 	id Identifier
 	// label is a descriptive label for the graph
 	label string
+	// identifierProvider is an optional function used to
+	// generate identifiers for nodes created within the graph.
+	identifierProvider func() Identifier
 
 	// parallelism is the degree of parallelism used when processing nodes
 	// with the [parallelBatch] iterator.
@@ -155,11 +353,65 @@ type Graph struct {
 	// clearRecomputeHeapOnError controls if we should clear the recomputeHeap on error.
 	clearRecomputeHeapOnError bool
 
+	// deterministicOrdering controls if we should recompute nodes with the same
+	// height in the order they were created in during serial stabilization.
+	deterministicOrdering bool
+
+	// trackRecomputeTimes controls if we should record the time
+	// each node is recomputed.
+	trackRecomputeTimes bool
+
+	// maxRecomputesPerStabilize is the maximum number of nodes that can be
+	// recomputed in a single stabilization, or zero if unlimited.
+	maxRecomputesPerStabilize uint64
+	// stabilizationRecomputes is the number of nodes recomputed in the
+	// stabilization in progress if the recomputes are limited.
+	stabilizationRecomputes uint64
+	// recentRecomputeKinds are the kinds of the most recently recomputed nodes
+	// if the recomputes are limited, used to report exceeding the limit.
+	recentRecomputeKinds []string
+	// recentRecomputeKindsMu interlocks access to recentRecomputeKinds.
+	recentRecomputeKindsMu sync.Mutex
+
+	// disablePanicRecovery controls if panics in node functions
+	// propagate out of stabilization instead of being returned as errors.
+	disablePanicRecovery bool
+
+	// skipEmptyStabilizations controls if stabilizations with
+	// no work to do return immediately.
+	skipEmptyStabilizations bool
+
+	// uniqueLabels controls if observing nodes whose labels
+	// duplicate the labels of observed nodes returns an error.
+	uniqueLabels bool
+
+	// strictObservation controls if setting vars that
+	// aren't necessary is traced and counted.
+	strictObservation bool
+	// numUnnecessaryVarSets is the number of times vars that
+	// aren't necessary have been set with strict observation on.
+	numUnnecessaryVarSets uint64
+	// unnecessaryVars holds the vars that have been set while
+	// not necessary with strict observation on, by identifier.
+	unnecessaryVars map[Identifier]INode
+	// unnecessaryVarsPending holds the vars that have been set while not
+	// necessary since the last stabilization started, to be traced when the
+	// next stabilization starts.
+	unnecessaryVarsPending []INode
+	// unnecessaryVarsMu interlocks access to unnecessaryVars and unnecessaryVarsPending.
+	unnecessaryVarsMu sync.Mutex
+
+	// nodeOrder is the counter used to assign the creation order to nodes.
+	nodeOrder uint64
+
 	// nodesMu interlocks access to nodes
 	nodesMu sync.Mutex
 	// observed are the nodes that the graph currently observes
 	// organized by node id.
 	nodes map[Identifier]INode
+	// labels indexes the nodes that the graph currently
+	// observes by their labels, and is also guarded by nodesMu.
+	labels map[string][]INode
 
 	// observersMu interlocks access to observers
 	observersMu sync.Mutex
@@ -190,6 +442,16 @@ type Graph struct {
 	// handleAfterStabilizationMu coordinates access to handleAfterStabilization
 	handleAfterStabilizationMu sync.Mutex
 
+	// abortedBy are the nodes that returned [ErrStabilizationAborted]
+	// during the current stabilization.
+	abortedBy []INode
+	// abortedByMu coordinates access to abortedBy
+	abortedByMu sync.Mutex
+
+	// readMu interlocks readers (see [Graph.Read]) with
+	// stabilization passes that may update node values.
+	readMu readLock
+
 	// stabilizationNum is the version
 	// of the graph in respect to when
 	// nodes are considered stale or changed
@@ -202,6 +464,20 @@ type Graph struct {
 	status int32
 	// stabilizationStarted is the time of the stabilization pass currently in progress
 	stabilizationStarted time.Time
+	// lastStabilizedAt is the time the most recent stabilization pass finished.
+	lastStabilizedAt time.Time
+	// stabilizationStartedNumRecomputed is the value of numNodesRecomputed
+	// as of the start of the stabilization pass currently in progress.
+	stabilizationStartedNumRecomputed uint64
+	// lastStabilizeChanged is set if any nodes were recomputed
+	// during the most recent stabilization pass.
+	lastStabilizeChanged bool
+	// structuredTracer is the structured tracer found on the context
+	// for the stabilization pass currently in progress, if any.
+	structuredTracer StructuredTracer
+	// recomputingAll is set for the stabilization pass started by
+	// [Graph.RecomputeAll] and disables skipping and cutting off nodes.
+	recomputingAll bool
 	// numNodes are the total number of nodes found during
 	// discovery and is typically used for testing
 	numNodes uint64
@@ -218,12 +494,49 @@ type Graph struct {
 	// manage yourself.
 	metadata any
 
+	// changed is signaled when nodes are marked stale or vars are set
+	// during a stabilization, and is used by [Graph.StabilizeOnChange].
+	changed chan struct{}
+
+	// recording is the recording started with [Graph.StartRecording], if any.
+	recording atomic.Pointer[Recording]
+
+	// defaultContext is an optional hook applied to the context
+	// passed to each stabilization before it's used.
+	defaultContext func(context.Context) context.Context
+
 	// onStabilizationStart are optional hooks called when stabilization starts.
 	onStabilizationStart []func(context.Context)
 
 	// onStabilizationEnd are optional hooks called when stabilization ends.
 	onStabilizationEnd []func(context.Context, time.Time, error)
 
+	// stabilizationContext is the context for the stabilization
+	// pass currently in progress, if any, and is passed to node
+	// lifecycle handlers.
+	stabilizationContext context.Context
+	// pendingUnobserved are the nodes that stopped being necessary during
+	// the stabilization in progress, whose unobserved handlers are called
+	// at the end of the stabilization if they are still not necessary.
+	pendingUnobserved []INode
+	// pendingUnobservedMu interlocks access to pendingUnobserved.
+	pendingUnobservedMu sync.Mutex
+
+	// onNodeAdded are optional hooks called when the graph starts tracking a node.
+	onNodeAdded []func(INode)
+	// onNodeRemoved are optional hooks called when the graph stops tracking a node.
+	onNodeRemoved []func(INode)
+
+	// onStabilizationEndStats are optional hooks called when stabilization
+	// ends with the stats for the stabilization.
+	onStabilizationEndStats []func(context.Context, StabilizationStats, error)
+	// heightHistogram is the number of nodes recomputed at each height
+	// during the stabilization in progress; it is only tracked if there
+	// are stabilization end stats handlers.
+	heightHistogram []int
+	// heightHistogramMu interlocks access to the heightHistogram during parallel stabilization.
+	heightHistogramMu sync.Mutex
+
 	propagateInvalidityQueue *queue[INode]
 }
 
@@ -252,12 +565,59 @@ func (graph *Graph) SetMetadata(metadata any) {
 	graph.metadata = metadata
 }
 
+// StabilizationNum returns the number of the stabilization in progress, or
+// of the most recent stabilization if the graph is not stabilizing.
+//
+// Stabilization numbers start at one and increase by one with each stabilization,
+// and are zero if the graph hasn't been stabilized yet.
+func (graph *Graph) StabilizationNum() uint64 {
+	return graph.latestStabilizationNum()
+}
+
+// LastStabilizedAt returns the time the most recent stabilization finished,
+// or the zero time if the graph hasn't been stabilized yet.
+func (graph *Graph) LastStabilizedAt() time.Time {
+	return graph.lastStabilizedAt
+}
+
+// LastStabilizeChanged returns if any nodes were recomputed during the most
+// recent stabilization, e.g. to skip work that depends on the graph's values
+// if nothing changed; nodes that skip recomputing, e.g. [AlwaysWhen] nodes
+// whose predicate returns false, aren't counted.
+//
+// It returns false while a stabilization is in progress, and after a
+// stabilization that was skipped because there was no work to do (see
+// [OptGraphSkipEmptyStabilizations] and [Graph.StabilizeIfNeeded]).
+func (graph *Graph) LastStabilizeChanged() bool {
+	return graph.lastStabilizeChanged
+}
+
 // IsStabilizing returns if the graph is currently stabilizing.
 func (graph *Graph) IsStabilizing() bool {
 	return atomic.LoadInt32(&graph.status) != StatusNotStabilizing
 }
 
-// IsObserving returns if a graph is observing a given node.
+// Read calls a given function while holding a read lock that excludes
+// stabilization passes, that is, it blocks while a stabilization is recomputing
+// nodes and prevents a stabilization from recomputing nodes until the function returns.
+//
+// Use this to read the values of multiple nodes from other goroutines
+// and observe them as they were at the end of the same stabilization.
+//
+// Between the steps of a stabilization started with [Graph.StabilizeStart]
+// no nodes are being recomputed, so Read doesn't block and observes the
+// values of the nodes as of the last step.
+//
+// Calls to Read (and [ObserveIncr.ValueStable]) can be nested. Read must not be
+// called from within node functions, and you must not stabilize the graph
+// from within the function, or it will deadlock.
+func (graph *Graph) Read(fn func()) {
+	graph.readMu.RLock()
+	defer graph.readMu.RUnlock()
+	fn()
+}
+
+// Has returns if a graph is observing a given node.
 func (graph *Graph) Has(gn INode) (ok bool) {
 	graph.nodesMu.Lock()
 	_, ok = graph.nodes[gn.Node().id]
@@ -265,6 +625,64 @@ func (graph *Graph) Has(gn INode) (ok bool) {
 	return
 }
 
+// IsObservingID returns if a graph is observing a node with a given identifier.
+//
+// It is the same as [Graph.Has] but takes the node's identifier.
+func (graph *Graph) IsObservingID(id Identifier) (ok bool) {
+	graph.nodesMu.Lock()
+	_, ok = graph.nodes[id]
+	graph.nodesMu.Unlock()
+	return
+}
+
+// HasID returns if the graph is tracking a node with a given identifier,
+// including observers and sentinels.
+func (graph *Graph) HasID(id Identifier) (ok bool) {
+	_, ok = graph.GetNode(id)
+	return
+}
+
+// GetNode returns the node with a given identifier that the graph is
+// tracking, including observers and sentinels.
+//
+// The node returned is the live node, that is, you can attach handlers to it
+// or mark it stale with [Graph.SetStale].
+func (graph *Graph) GetNode(id Identifier) (n INode, ok bool) {
+	graph.nodesMu.Lock()
+	n, ok = graph.nodes[id]
+	graph.nodesMu.Unlock()
+	if ok {
+		return
+	}
+	graph.observersMu.Lock()
+	var on IObserver
+	on, ok = graph.observers[id]
+	graph.observersMu.Unlock()
+	if ok {
+		n = on
+		return
+	}
+	graph.sentinelsMu.Lock()
+	var sn ISentinel
+	sn, ok = graph.sentinels[id]
+	graph.sentinelsMu.Unlock()
+	if ok {
+		n = sn
+	}
+	return
+}
+
+// Height returns the current height of a given node within the graph.
+//
+// If the node is not necessary, i.e. it is not part of the graph, or the node
+// belongs to a different graph, this will return [HeightUnset].
+func (graph *Graph) Height(gn INode) int {
+	if GraphForNode(gn) != graph {
+		return HeightUnset
+	}
+	return gn.Node().height
+}
+
 // HasObserver returns if a graph has a given observer.
 func (graph *Graph) HasObserver(on IObserver) (ok bool) {
 	graph.observersMu.Lock()
@@ -281,6 +699,17 @@ func (graph *Graph) HasSentinel(sn ISentinel) (ok bool) {
 	return
 }
 
+// WithDefaultContext sets a hook that is applied to the context passed to each
+// stabilization, e.g. to add request scoped values or tracing to stabilizations
+// driven by a background loop whose context lacks them.
+//
+// The context returned by the hook is the context passed to node stabilize and
+// cutoff functions, bind delegates, update and error handlers, and the
+// stabilization start and end handlers.
+func (graph *Graph) WithDefaultContext(fn func(context.Context) context.Context) {
+	graph.defaultContext = fn
+}
+
 // OnStabilizationStart adds a stabilization start handler.
 func (graph *Graph) OnStabilizationStart(handler func(context.Context)) {
 	graph.onStabilizationStart = append(graph.onStabilizationStart, handler)
@@ -291,15 +720,86 @@ func (graph *Graph) OnStabilizationEnd(handler func(context.Context, time.Time,
 	graph.onStabilizationEnd = append(graph.onStabilizationEnd, handler)
 }
 
+// OnStabilizationEndStats adds a stabilization end handler that is passed
+// the [StabilizationStats] for the stabilization.
+//
+// Tracking the stats adds a small amount of overhead to each node recompute,
+// and is only done if at least one of these handlers is registered.
+func (graph *Graph) OnStabilizationEndStats(handler func(context.Context, StabilizationStats, error)) {
+	graph.onStabilizationEndStats = append(graph.onStabilizationEndStats, handler)
+}
+
+// OnNodeAdded adds a handler that is called when the graph starts
+// tracking a node, including observers and sentinels, e.g. when a node
+// becomes necessary because it is observed or because a [Bind] links it.
+//
+// When a [Bind] changes its right-hand side, the nodes of the new right-hand side
+// are added before the nodes of the previous right-hand side are removed, such that
+// nodes shared by both are not removed and added again.
+//
+// Handlers are called synchronously, and during stabilization if the
+// graph changes as a result of a [Bind]; they must not modify the graph.
+func (graph *Graph) OnNodeAdded(handler func(INode)) {
+	graph.onNodeAdded = append(graph.onNodeAdded, handler)
+}
+
+// OnNodeRemoved adds a handler that is called when the graph stops
+// tracking a node, including observers and sentinels, e.g. when a node
+// becomes unnecessary because it is unobserved or because a [Bind] unlinks it.
+//
+// See [Graph.OnNodeAdded] for the order handlers are called in when a [Bind] changes.
+func (graph *Graph) OnNodeRemoved(handler func(INode)) {
+	graph.onNodeRemoved = append(graph.onNodeRemoved, handler)
+}
+
 // Node helpers
 
 // SetStale sets a node as stale.
 func (graph *Graph) SetStale(gn INode) {
+	graph.record(RecordingEventSetStale, gn, nil)
+	graph.setStale(gn, RecomputeReasonSetStale)
+}
+
+// SetStaleMany sets many nodes as stale at once.
+func (graph *Graph) SetStaleMany(nodes ...INode) {
+	for _, gn := range nodes {
+		graph.record(RecordingEventSetStale, gn, nil)
+	}
+	graph.setStaleMany(nodes, RecomputeReasonSetStale)
+}
+
+// SetStaleWithPriority sets a node as stale, and makes sure it is
+// recomputed before the other nodes with the same height.
+//
+// This is useful if the node gates the recomputation of other nodes
+// with the same height, e.g. with a [Cutoff] or a [Sentinel].
+//
+// With [OptGraphDeterministicOrdering], nodes set stale with priority are recomputed
+// before the other nodes with the same height, most recent first, and the other
+// nodes in the order they were created in.
+func (graph *Graph) SetStaleWithPriority(gn INode) {
+	graph.record(RecordingEventSetStale, gn, nil)
+	gn.Node().setAt = graph.stabilizationNum
+	graph.recomputeHeap.addFront(gn, RecomputeReasonSetStale)
+	graph.notifyChanged()
+}
+
+// setStale marks a node as stale with a given reason without recording
+// the event, and should be used when the graph marks nodes as stale itself
+// rather than [Graph.SetStale], which is only for the public API.
+func (graph *Graph) setStale(gn INode, reason RecomputeReason) {
 	n := gn.Node()
 	n.setAt = graph.stabilizationNum
-	if gn.Node().heightInRecomputeHeap == HeightUnset {
-		graph.recomputeHeap.add(gn)
+	graph.recomputeHeap.addIfNotPresent(gn, reason)
+	graph.notifyChanged()
+}
+
+func (graph *Graph) setStaleMany(nodes []INode, reason RecomputeReason) {
+	for _, gn := range nodes {
+		gn.Node().setAt = graph.stabilizationNum
 	}
+	graph.recomputeHeap.addManyIfNotPresent(nodes, reason)
+	graph.notifyChanged()
 }
 
 //
@@ -312,6 +812,7 @@ func (graph *Graph) isScopeNecessary() bool { return true }
 func (graph *Graph) scopeGraph() *Graph     { return graph }
 func (graph *Graph) scopeHeight() int       { return HeightUnset }
 func (graph *Graph) addScopeNode(_ INode)   {}
+func (graph *Graph) scopeNodes() []INode    { return nil }
 func (graph *Graph) String() string         { return fmt.Sprintf("{graph:%s}", graph.id.Short()) }
 
 //
@@ -391,7 +892,7 @@ func (graph *Graph) addChild(child, parent INode) error {
 	}
 	graph.propagateInvalidity()
 	if child.Node().recomputedAt == 0 || graph.edgeIsStale(child, parent) {
-		graph.recomputeHeap.addIfNotPresent(child)
+		graph.recomputeHeap.addIfNotPresent(child, RecomputeReasonLinked)
 	}
 	return nil
 }
@@ -402,6 +903,7 @@ func (graph *Graph) changeParent(child, oldParent, newParent INode) error {
 			return nil
 		}
 		oldParent.Node().removeChild(child.Node().id)
+		child.Node().removeParent(oldParent.Node().id)
 		oldParent.Node().forceNecessary = true
 		if err := graph.addChild(child, newParent); err != nil {
 			return err
@@ -410,12 +912,16 @@ func (graph *Graph) changeParent(child, oldParent, newParent INode) error {
 		graph.checkIfUnnecessary(oldParent)
 		return nil
 	}
+	if oldParent == nil && newParent == nil {
+		return nil
+	}
 	if oldParent == nil {
 		return graph.addChild(child, newParent)
 	}
 
 	// newParent is nil
 	oldParent.Node().removeChild(child.Node().id)
+	child.Node().removeParent(oldParent.Node().id)
 	graph.checkIfUnnecessary(oldParent)
 	return nil
 }
@@ -427,18 +933,30 @@ func (graph *Graph) propagateInvalidity() {
 			if node.Node().shouldBeInvalidated() {
 				graph.invalidateNode(node)
 			} else {
-				graph.recomputeHeap.addIfNotPresent(node)
+				graph.recomputeHeap.addIfNotPresent(node, RecomputeReasonInvalidated)
 			}
 		}
 	}
 }
 
+// ensureNodeInGraph returns an error if a given node was created
+// within a different graph than this graph.
+func (graph *Graph) ensureNodeInGraph(n INode) error {
+	if other := GraphForNode(n); other != nil && other != graph {
+		return fmt.Errorf("%w; node %v belongs to graph %s, cannot attach to graph %s", ErrNodeAlreadyInGraph, n, other.id.Short(), graph.id.Short())
+	}
+	return nil
+}
+
 func (graph *Graph) link(child, parent INode) {
 	parent.Node().addChildren(child)
 	child.Node().addParents(parent)
 }
 
 func (graph *Graph) addChildWithoutAdjustingHeights(child, parent INode) error {
+	if err := graph.ensureNodeInGraph(parent); err != nil {
+		return err
+	}
 	wasNecessary := parent.Node().isNecessary()
 	graph.link(child, parent)
 	if !parent.Node().valid {
@@ -468,14 +986,35 @@ func (graph *Graph) becameNecessaryRecursive(node INode) (err error) {
 		}
 	}
 	for _, sentinels := range node.Node().sentinels {
-		graph.recomputeHeap.addIfNotPresent(sentinels)
+		graph.recomputeHeap.addIfNotPresent(sentinels, RecomputeReasonNecessary)
 	}
 	if node.Node().isStale() {
-		graph.recomputeHeap.addIfNotPresent(node)
+		if node.Node().isConstant() {
+			graph.recomputeConstant(node)
+			return
+		}
+		graph.recomputeHeap.addIfNotPresent(node, RecomputeReasonNecessary)
 	}
 	return
 }
 
+// recomputeConstant marks a constant node as recomputed and changed in the current
+// stabilization without adding it to the recompute heap, because its value is
+// available immediately.
+//
+// Its children will see it as changed and recompute as a result.
+func (graph *Graph) recomputeConstant(node INode) {
+	nn := node.Node()
+	nn.recomputedAt = graph.stabilizationNum
+	nn.changedAt = graph.stabilizationNum
+	nn.lastRecomputeReason = RecomputeReasonNecessary
+	if len(nn.onUpdateHandlers) > 0 {
+		graph.handleAfterStabilizationMu.Lock()
+		graph.handleAfterStabilization[nn.id] = nn.onUpdateHandlers
+		graph.handleAfterStabilizationMu.Unlock()
+	}
+}
+
 func (graph *Graph) becameNecessary(node INode) error {
 	if err := graph.becameNecessaryRecursive(node); err != nil {
 		return err
@@ -486,44 +1025,49 @@ func (graph *Graph) becameNecessary(node INode) error {
 
 func (graph *Graph) addNode(n INode) {
 	graph.nodesMu.Lock()
-	defer graph.nodesMu.Unlock()
-
 	gnn := n.Node()
 	_, graphAlreadyHasNode := graph.nodes[gnn.id]
 	if graphAlreadyHasNode {
+		graph.nodesMu.Unlock()
 		return
 	}
 	graph.numNodes++
 	gnn.initializeFrom(n)
 	graph.nodes[gnn.id] = n
+	graph.indexLabelUnsafe(n, gnn.label)
+	graph.nodesMu.Unlock()
+	graph.nodeAdded(n)
+	graph.nodeObserved(n)
 }
 
 func (graph *Graph) addObserver(on IObserver) {
 	graph.observersMu.Lock()
-	defer graph.observersMu.Unlock()
-
 	onn := on.Node()
 	_, graphAlreadyHasObserver := graph.observers[onn.id]
 	if graphAlreadyHasObserver {
+		graph.observersMu.Unlock()
 		return
 	}
 	graph.numNodes++
 	onn.initializeFrom(on)
 	graph.observers[onn.id] = on
+	graph.observersMu.Unlock()
+	graph.nodeAdded(on)
 }
 
 func (graph *Graph) addSentinel(sn ISentinel) {
 	graph.sentinelsMu.Lock()
-	defer graph.sentinelsMu.Unlock()
-
 	snn := sn.Node()
 	_, graphAlreadyHasSentinel := graph.sentinels[snn.id]
 	if graphAlreadyHasSentinel {
+		graph.sentinelsMu.Unlock()
 		return
 	}
 	graph.numNodes++
 	snn.initializeFrom(sn)
 	graph.sentinels[snn.id] = sn
+	graph.sentinelsMu.Unlock()
+	graph.nodeAdded(sn)
 }
 
 func (graph *Graph) removeObserver(on IObserver) {
@@ -531,6 +1075,7 @@ func (graph *Graph) removeObserver(on IObserver) {
 	delete(graph.observers, on.Node().id)
 	graph.observersMu.Unlock()
 	graph.zeroNode(on)
+	graph.nodeRemoved(on)
 }
 
 func (graph *Graph) removeSentinel(sn ISentinel) {
@@ -538,13 +1083,75 @@ func (graph *Graph) removeSentinel(sn ISentinel) {
 	delete(graph.sentinels, sn.Node().id)
 	graph.sentinelsMu.Unlock()
 	graph.zeroNode(sn)
+	graph.nodeRemoved(sn)
 }
 
 func (graph *Graph) removeNode(gn INode) {
 	graph.nodesMu.Lock()
+	if _, ok := graph.nodes[gn.Node().id]; ok {
+		graph.unindexLabelUnsafe(gn, gn.Node().label)
+	}
 	delete(graph.nodes, gn.Node().id)
 	graph.nodesMu.Unlock()
 	graph.zeroNode(gn)
+	graph.nodeRemoved(gn)
+	graph.nodeUnobserved(gn)
+}
+
+func (graph *Graph) nodeAdded(n INode) {
+	for _, handler := range graph.onNodeAdded {
+		handler(n)
+	}
+}
+
+// nodeObserved calls the observed handlers for a node that became
+// necessary unless they were already called for the node.
+func (graph *Graph) nodeObserved(n INode) {
+	nn := n.Node()
+	if nn.lifecycleObserved {
+		return
+	}
+	nn.lifecycleObserved = true
+	ctx := graph.stabilizationContext
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, handler := range nn.onObservedHandlers {
+		handler(ctx)
+	}
+}
+
+// nodeUnobserved calls the unobserved handlers for a node that is no longer
+// necessary, deferring them until the end of the stabilization if we're stabilizing.
+func (graph *Graph) nodeUnobserved(n INode) {
+	nn := n.Node()
+	if !nn.lifecycleObserved {
+		return
+	}
+	if ctx := graph.stabilizationContext; ctx != nil {
+		graph.pendingUnobservedMu.Lock()
+		graph.pendingUnobserved = append(graph.pendingUnobserved, n)
+		graph.pendingUnobservedMu.Unlock()
+		return
+	}
+	graph.runUnobservedHandlers(context.Background(), n)
+}
+
+func (graph *Graph) runUnobservedHandlers(ctx context.Context, n INode) {
+	nn := n.Node()
+	if !nn.lifecycleObserved {
+		return
+	}
+	nn.lifecycleObserved = false
+	for _, handler := range nn.onUnobservedHandlers {
+		handler(ctx)
+	}
+}
+
+func (graph *Graph) nodeRemoved(n INode) {
+	for _, handler := range graph.onNodeRemoved {
+		handler(n)
+	}
 }
 
 func (graph *Graph) zeroNode(n INode) {
@@ -561,8 +1168,14 @@ func (graph *Graph) zeroNode(n INode) {
 	graph.handleAfterStabilizationMu.Unlock()
 
 	nn.setAt = 0
-	nn.changedAt = 0
-	nn.recomputedAt = 0
+	nn.recomputeReason = RecomputeReasonNone
+	nn.lastRecomputeReason = RecomputeReasonNone
+	// constants keep their recompute state so that if they become
+	// necessary again they are not rescheduled for recomputation.
+	if !nn.isConstant() {
+		nn.changedAt = 0
+		nn.recomputedAt = 0
+	}
 
 	// mirror how we initialized the node
 	nn.valid = true
@@ -579,6 +1192,14 @@ func (graph *Graph) zeroNode(n INode) {
 }
 
 func (graph *Graph) observeNode(o IObserver, input INode) error {
+	if err := graph.ensureNodeInGraph(input); err != nil {
+		return err
+	}
+	if graph.uniqueLabels {
+		if err := graph.checkUniqueLabels(input); err != nil {
+			return err
+		}
+	}
 	graph.addObserver(o)
 	wasNecsesary := input.Node().isNecessary()
 	input.Node().addObservers(o)
@@ -590,6 +1211,10 @@ func (graph *Graph) observeNode(o IObserver, input INode) error {
 	graph.handleAfterStabilizationMu.Lock()
 	graph.handleAfterStabilization[o.Node().id] = o.Node().onUpdateHandlers
 	graph.handleAfterStabilizationMu.Unlock()
+	graph.record(RecordingEventObserve, input, func(e *RecordingEvent) {
+		e.ObserverID = o.Node().id
+	})
+	graph.notifyChanged()
 	return nil
 }
 
@@ -604,6 +1229,9 @@ func (graph *Graph) watchNode(sn ISentinel, input INode) error {
 }
 
 func (graph *Graph) unobserveNode(o IObserver, input INode) {
+	graph.record(RecordingEventUnobserve, input, func(e *RecordingEvent) {
+		e.ObserverID = o.Node().id
+	})
 	graph.removeObserver(o)
 	input.Node().removeObserver(o.Node().id)
 	graph.checkIfUnnecessary(input)
@@ -627,31 +1255,79 @@ func (graph *Graph) ensureNotStabilizing(ctx context.Context) error {
 	return nil
 }
 
+// claimStabilization marks the graph as stabilizing if it isn't already
+// stabilizing, such that a concurrent call that starts a stabilization can't
+// start between checking the status and starting the stabilization.
+//
+// The stabilization must then be started with [Graph.stabilizeStart], or the
+// claim released by setting the status back to [StatusNotStabilizing].
+func (graph *Graph) claimStabilization(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&graph.status, StatusNotStabilizing, StatusStabilizing) {
+		TracePrintf(ctx, "stabilize; already stabilizing, cannot continue")
+		return ErrAlreadyStabilizing
+	}
+	return nil
+}
+
+// latestStabilizationNum returns the stabilization number of the stabilization
+// in progress, or of the most recent stabilization if we're not stabilizing.
+func (graph *Graph) latestStabilizationNum() uint64 {
+	if atomic.LoadInt32(&graph.status) != StatusNotStabilizing {
+		return graph.stabilizationNum
+	}
+	return graph.stabilizationNum - 1
+}
+
 func (graph *Graph) stabilizeStart(ctx context.Context) context.Context {
-	atomic.StoreInt32(&graph.status, StatusStabilizing)
+	graph.record(RecordingEventStabilize, nil, nil)
+	graph.stabilizationStartedNumRecomputed = graph.numNodesRecomputed
+	graph.lastStabilizeChanged = false
+	if graph.defaultContext != nil {
+		ctx = graph.defaultContext(ctx)
+	}
+	ctx = WithStabilizationNumber(ctx, graph.stabilizationNum)
+	graph.stabilizeStartTraceUnnecessaryVars(ctx)
 	for _, handler := range graph.onStabilizationStart {
 		handler(ctx)
 	}
 	graph.stabilizationStarted = time.Now()
-	ctx = WithStabilizationNumber(ctx, graph.stabilizationNum)
+	graph.heightHistogram = graph.heightHistogram[:0]
+	if graph.maxRecomputesPerStabilize > 0 {
+		graph.stabilizationRecomputes = 0
+		graph.recentRecomputeKinds = graph.recentRecomputeKinds[:0]
+	}
+	graph.structuredTracer = GetStructuredTracer(ctx)
+	graph.stabilizationContext = ctx
 	TracePrintln(ctx, "stabilization starting")
 	return ctx
 }
 
 func (graph *Graph) stabilizeEnd(ctx context.Context, err error) {
 	defer func() {
+		graph.lastStabilizedAt = time.Now()
+		graph.lastStabilizeChanged = graph.numNodesRecomputed > graph.stabilizationStartedNumRecomputed
 		graph.stabilizationStarted = time.Time{}
+		graph.structuredTracer = nil
+		graph.stabilizationContext = nil
 		atomic.StoreInt32(&graph.status, StatusNotStabilizing)
 	}()
 	for _, handler := range graph.onStabilizationEnd {
 		handler(ctx, graph.stabilizationStarted, err)
 	}
+	if len(graph.onStabilizationEndStats) > 0 {
+		stats := graph.stabilizationStats()
+		for _, handler := range graph.onStabilizationEndStats {
+			handler(ctx, stats, err)
+		}
+	}
 	if err != nil {
 		TraceErrorf(ctx, "stabilization error: %v", err)
 		TracePrintf(ctx, "stabilization failed (%v elapsed)", time.Since(graph.stabilizationStarted).Round(time.Microsecond))
 	} else {
 		TracePrintf(ctx, "stabilization complete (%v elapsed)", time.Since(graph.stabilizationStarted).Round(time.Microsecond))
 	}
+	graph.stabilizeEndHandleAncestorError(ctx, err)
+	graph.stabilizeEndRunUnobservedHandlers(ctx)
 	graph.stabilizeEndRunUpdateHandlers(ctx)
 	graph.stabilizationNum++
 	graph.stabilizeEndHandleSetDuringStabilization(ctx)
@@ -660,13 +1336,87 @@ func (graph *Graph) stabilizeEnd(ctx context.Context, err error) {
 func (graph *Graph) stabilizeEndHandleSetDuringStabilization(ctx context.Context) {
 	graph.setDuringStabilizationMu.Lock()
 	defer graph.setDuringStabilizationMu.Unlock()
+	if len(graph.setDuringStabilization) == 0 {
+		return
+	}
+	graph.readMu.Lock()
+	defer graph.readMu.Unlock()
 	for _, n := range graph.setDuringStabilization {
+		// vars that aren't necessary haven't been added to the graph, and
+		// don't have a height, so we can't queue them (regardless of strict
+		// observation); as with [Var.Set] we set their value directly instead.
+		if !n.Node().isNecessary() {
+			if typed, ok := n.(IStabilize); ok {
+				_ = typed.Stabilize(ctx)
+			}
+			graph.varSetUnnecessary(n)
+			continue
+		}
 		_ = n.Node().maybeStabilize(ctx)
-		graph.SetStale(n)
+		graph.setStale(n, RecomputeReasonVarSet)
 	}
 	clear(graph.setDuringStabilization)
 }
 
+// iAncestorError is implemented by nodes that handle the errors returned by
+// the nodes they depend on (transitively), e.g. [CircuitBreaker].
+type iAncestorError interface {
+	onAncestorError(context.Context, error)
+}
+
+// stabilizeEndHandleAncestorError passes an error returned by a node during the
+// stabilization to the necessary nodes that depend on the node and handle ancestor
+// errors, not including the nodes that depend on those nodes.
+//
+// This is done at the end of the stabilization so that the handlers
+// can change the graph's structure, e.g. unlinking the node.
+func (graph *Graph) stabilizeEndHandleAncestorError(ctx context.Context, err error) {
+	var nodeErr *NodeError
+	if !errors.As(err, &nodeErr) {
+		return
+	}
+	n, ok := graph.GetNode(nodeErr.ID)
+	if !ok {
+		return
+	}
+	var handlers []iAncestorError
+	seen := map[Identifier]struct{}{
+		n.Node().id: {},
+	}
+	q := new(queue[INode])
+	q.push(n)
+	for q.len() > 0 {
+		n, _ = q.pop()
+		for _, c := range n.Node().children {
+			if _, ok := seen[c.Node().id]; ok || !c.Node().isNecessary() {
+				continue
+			}
+			seen[c.Node().id] = struct{}{}
+			if typed, ok := c.(iAncestorError); ok {
+				handlers = append(handlers, typed)
+				continue
+			}
+			q.push(c)
+		}
+	}
+	for _, h := range handlers {
+		h.onAncestorError(ctx, err)
+	}
+}
+
+func (graph *Graph) stabilizeEndRunUnobservedHandlers(ctx context.Context) {
+	graph.pendingUnobservedMu.Lock()
+	pending := graph.pendingUnobserved
+	graph.pendingUnobserved = nil
+	graph.pendingUnobservedMu.Unlock()
+	for _, n := range pending {
+		if graph.Has(n) {
+			continue
+		}
+		graph.runUnobservedHandlers(ctx, n)
+	}
+}
+
 func (graph *Graph) stabilizeEndRunUpdateHandlers(ctx context.Context) {
 	graph.handleAfterStabilizationMu.Lock()
 	defer graph.handleAfterStabilizationMu.Unlock()
@@ -678,42 +1428,116 @@ func (graph *Graph) stabilizeEndRunUpdateHandlers(ctx context.Context) {
 			TracePrintln(ctx, "stabilization calling user update handlers complete")
 		}()
 	}
-	for _, uhGroup := range graph.handleAfterStabilization {
-		for _, uh := range uhGroup {
-			uh(ctx)
+	if graph.deterministicOrdering {
+		for _, id := range graph.updateHandlerIDsOrdered() {
+			for _, uh := range graph.handleAfterStabilization[id] {
+				uh(ctx)
+			}
+		}
+	} else {
+		for _, uhGroup := range graph.handleAfterStabilization {
+			for _, uh := range uhGroup {
+				uh(ctx)
+			}
 		}
 	}
 	clear(graph.handleAfterStabilization)
 }
 
+// updateHandlerIDsOrdered returns the identifiers of the nodes with update
+// handlers to run in the order the nodes were created in.
+func (graph *Graph) updateHandlerIDsOrdered() []Identifier {
+	ids := make([]Identifier, 0, len(graph.handleAfterStabilization))
+	orders := make(map[Identifier]uint64, len(graph.handleAfterStabilization))
+	for id := range graph.handleAfterStabilization {
+		ids = append(ids, id)
+		if n, ok := graph.GetNode(id); ok {
+			orders[id] = n.Node().order
+		}
+	}
+	slices.SortFunc(ids, func(a, b Identifier) int {
+		return cmp.Compare(orders[a], orders[b])
+	})
+	return ids
+}
+
 // recompute starts the recompute cycle for the node
 // setting the recomputedAt field and possibly changing the value.
 func (graph *Graph) recompute(ctx context.Context, n INode, parallel bool) (err error) {
-	graph.numNodesRecomputed++
-
+	if !graph.disablePanicRecovery {
+		defer func() {
+			if r := recover(); r != nil {
+				err = graph.recomputeError(ctx, n, newPanicError(r))
+			}
+		}()
+	}
+	if graph.maxRecomputesPerStabilize > 0 {
+		if err = graph.checkRecomputeBudget(n); err != nil {
+			return
+		}
+	}
 	nn := n.Node()
-	nn.numRecomputes++
+
+	// check if we can skip the node before we update the recomputed at
+	// stabilization number so we can tell if any inputs changed, and
+	// before we count the node as recomputed.
+	var shouldSkip bool
+	if !graph.recomputingAll {
+		shouldSkip = nn.maybeSkipUnchanged()
+		if !shouldSkip {
+			shouldSkip, err = nn.maybeSkipAlways(ctx)
+		}
+	}
+	if !shouldSkip {
+		graph.numNodesRecomputed++
+		nn.numRecomputes++
+		if len(graph.onStabilizationEndStats) > 0 {
+			graph.recordRecomputeHeight(nn.height, parallel)
+		}
+	}
 	nn.recomputedAt = graph.stabilizationNum
+	if graph.trackRecomputeTimes {
+		nn.recomputedAtTime = time.Now()
+	}
+	nn.lastRecomputeReason = nn.recomputeReason
+	nn.recomputeReason = RecomputeReasonNone
+	if err != nil {
+		err = graph.recomputeError(ctx, n, err)
+		return
+	}
+	if shouldSkip {
+		return
+	}
 
 	var shouldCutoff bool
-	shouldCutoff, err = nn.maybeCutoff(ctx)
+	if !graph.recomputingAll {
+		shouldCutoff, err = nn.maybeCutoff(ctx)
+	}
+	if err != nil && nn.cutoffErrorPolicy == CutoffErrorPolicyPropagateValue && !errors.Is(err, ErrStabilizationAborted) {
+		// report the error but carry on as if the node wasn't cut off.
+		graph.notifyError(ctx, n, err)
+		err = nil
+		shouldCutoff = false
+	}
 	if err != nil {
-		for _, eh := range nn.onErrorHandlers {
-			eh(ctx, err)
-		}
+		err = graph.recomputeError(ctx, n, err)
 		return
 	}
 	if shouldCutoff {
+		if graph.structuredTracer != nil {
+			graph.structuredTracer.OnCutoff(ctx, nn.nodeMetadata())
+		}
 		return
 	}
 
 	graph.numNodesChanged++
 	nn.numChanges++
 
-	if err = nn.maybeStabilize(ctx); err != nil {
-		for _, eh := range nn.onErrorHandlers {
-			eh(ctx, err)
-		}
+	if graph.structuredTracer != nil {
+		graph.structuredTracer.OnRecompute(ctx, nn.nodeMetadata())
+	}
+	if err = graph.recomputeStabilize(ctx, nn); err != nil {
+		err = graph.recomputeError(ctx, n, err)
 		return
 	}
 
@@ -731,6 +1555,7 @@ func (graph *Graph) recompute(ctx context.Context, n INode, parallel bool) (err
 			isStale := c.Node().isStale()
 			isNotInRecomputeHeap := c.Node().heightInRecomputeHeap == HeightUnset
 			if isNecessary && isStale && isNotInRecomputeHeap {
+				c.Node().recomputeReason = RecomputeReasonParentChanged
 				graph.recomputeHeap.addNodeUnsafe(c)
 			}
 		}
@@ -741,6 +1566,7 @@ func (graph *Graph) recompute(ctx context.Context, n INode, parallel bool) (err
 			isStale := c.Node().isStale()
 			isNotInRecomputeHeap := c.Node().heightInRecomputeHeap == HeightUnset
 			if isNecessary && isStale && isNotInRecomputeHeap {
+				c.Node().recomputeReason = RecomputeReasonParentChanged
 				graph.recomputeHeap.addNodeUnsafe(c)
 			}
 		}
@@ -755,5 +1581,59 @@ func (graph *Graph) recompute(ctx context.Context, n INode, parallel bool) (err
 			graph.handleAfterStabilizationMu.Unlock()
 		}
 	}
+	for _, o := range nn.weakObservers {
+		if len(o.Node().onUpdateHandlers) > 0 {
+			graph.handleAfterStabilizationMu.Lock()
+			graph.handleAfterStabilization[o.Node().id] = o.Node().onUpdateHandlers
+			graph.handleAfterStabilizationMu.Unlock()
+		}
+	}
+	return
+}
+
+// recomputeError calls the error handlers for a node and
+// returns the error wrapped as a [NodeError].
+//
+// If the error is [ErrStabilizationAborted] the error handlers are not called
+// and the error is returned as is; the node is instead tracked so that it can
+// be added back to the recompute heap at the end of the stabilization.
+func (graph *Graph) recomputeError(ctx context.Context, n INode, err error) error {
+	if errors.Is(err, ErrStabilizationAborted) {
+		graph.abortedByMu.Lock()
+		graph.abortedBy = append(graph.abortedBy, n)
+		graph.abortedByMu.Unlock()
+		return err
+	}
+	graph.notifyError(ctx, n, err)
+	return newNodeError(n.Node(), err)
+}
+
+// notifyError reports an error returned by a node to the
+// structured tracer and the node's error handlers.
+func (graph *Graph) notifyError(ctx context.Context, n INode, err error) {
+	nn := n.Node()
+	if graph.structuredTracer != nil {
+		graph.structuredTracer.OnError(ctx, nn.nodeMetadata(), err)
+	}
+	for _, eh := range nn.onErrorHandlers {
+		eh(ctx, err)
+	}
+}
+
+// recomputeStabilize calls the stabilize function for a node, calling
+// any recompute start and end handlers around it.
+func (graph *Graph) recomputeStabilize(ctx context.Context, nn *Node) (err error) {
+	if len(nn.onRecomputeStartHandlers) == 0 && len(nn.onRecomputeEndHandlers) == 0 {
+		return nn.maybeStabilize(ctx)
+	}
+	for _, sh := range nn.onRecomputeStartHandlers {
+		sh(ctx)
+	}
+	start := time.Now()
+	err = nn.maybeStabilize(ctx)
+	elapsed := time.Since(start)
+	for _, eh := range nn.onRecomputeEndHandlers {
+		eh(ctx, elapsed)
+	}
 	return
 }
@@ -0,0 +1,69 @@
+package incr
+
+import "sync/atomic"
+
+// GraphStats are a snapshot of statistics about a graph.
+type GraphStats struct {
+	// NumNodes is the number of nodes the graph is tracking, including
+	// observers and sentinels.
+	NumNodes uint64
+	// NumObservers is the number of observers the graph is tracking.
+	NumObservers uint64
+	// NumNecessary is the number of nodes the graph is tracking that are
+	// observed, either directly or through one of their children.
+	NumNecessary uint64
+	// NumUnnecessary is the number of nodes the graph is tracking that are not observed,
+	// e.g. nodes linked by hand that can be reclaimed with [Graph.GC] or [Graph.RemoveNode].
+	NumUnnecessary uint64
+	// NumNodesByKind is the number of nodes the graph is tracking
+	// for each node kind (see [Node.Kind]), including observers.
+	NumNodesByKind map[string]uint64
+	// NumStale is the number of necessary nodes that are currently stale.
+	NumStale uint64
+	// RecomputeHeapLen is the number of nodes in the recompute heap.
+	RecomputeHeapLen int
+	// MaxHeight is the largest height of any node the graph is tracking.
+	MaxHeight int
+	// NumUnnecessaryVarSets is the number of times vars that aren't necessary
+	// have been set, if the graph was created with [OptGraphStrictObservation].
+	NumUnnecessaryVarSets uint64
+}
+
+// Stats returns a snapshot of statistics about the graph.
+//
+// The snapshot is taken while holding the recompute heap lock (and the locks
+// for the graph's node tracking) so that the values are consistent with each other.
+func (graph *Graph) Stats() (stats GraphStats) {
+	graph.recomputeHeap.mu.Lock()
+	defer graph.recomputeHeap.mu.Unlock()
+	graph.nodesMu.Lock()
+	defer graph.nodesMu.Unlock()
+	graph.observersMu.Lock()
+	defer graph.observersMu.Unlock()
+
+	stats.NumNodes = graph.numNodes
+	stats.NumObservers = uint64(len(graph.observers))
+	stats.RecomputeHeapLen = graph.recomputeHeap.numItems
+	stats.MaxHeight = HeightUnset
+	stats.NumUnnecessaryVarSets = atomic.LoadUint64(&graph.numUnnecessaryVarSets)
+	stats.NumNodesByKind = make(map[string]uint64)
+	for _, o := range graph.observers {
+		stats.NumNodesByKind[o.Node().kind]++
+	}
+	for _, n := range graph.nodes {
+		nn := n.Node()
+		stats.NumNodesByKind[nn.kind]++
+		if nn.isNecessary() {
+			stats.NumNecessary++
+		} else {
+			stats.NumUnnecessary++
+		}
+		if nn.isStale() {
+			stats.NumStale++
+		}
+		if nn.height > stats.MaxHeight {
+			stats.MaxHeight = nn.height
+		}
+	}
+	return
+}
@@ -0,0 +1,66 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_Stats(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	stats := g.Stats()
+	testutil.Equal(t, 0, stats.NumNodes)
+	testutil.Equal(t, 0, stats.NumObservers)
+	testutil.Equal(t, 0, stats.NumStale)
+	testutil.Equal(t, 0, stats.RecomputeHeapLen)
+	testutil.Equal(t, HeightUnset, stats.MaxHeight)
+
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	m1 := Map(g, m0, ident)
+	_ = MustObserve(g, m1)
+
+	stats = g.Stats()
+	testutil.Equal(t, 4, stats.NumNodes)
+	testutil.Equal(t, 1, stats.NumObservers)
+	testutil.Equal(t, 2, stats.NumStale)
+	testutil.Equal(t, 2, stats.RecomputeHeapLen)
+	testutil.Equal(t, 2, stats.MaxHeight)
+	testutil.Equal(t, 3, stats.NumNecessary)
+	testutil.Equal(t, 0, stats.NumUnnecessary)
+	testutil.Equal(t, 2, stats.NumNodesByKind["map"])
+	testutil.Equal(t, 1, stats.NumNodesByKind["var"])
+	testutil.Equal(t, 1, stats.NumNodesByKind["observer"])
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	stats = g.Stats()
+	testutil.Equal(t, 4, stats.NumNodes)
+	testutil.Equal(t, 0, stats.NumStale)
+	testutil.Equal(t, 0, stats.RecomputeHeapLen)
+
+	v.Set("world")
+	stats = g.Stats()
+	testutil.Equal(t, 1, stats.RecomputeHeapLen)
+}
+
+func Test_Graph_Height(t *testing.T) {
+	g := New()
+
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	m1 := Map(g, m0, ident)
+	testutil.Equal(t, HeightUnset, g.Height(m1))
+
+	_ = MustObserve(g, m1)
+	testutil.Equal(t, 0, g.Height(v))
+	testutil.Equal(t, 1, g.Height(m0))
+	testutil.Equal(t, 2, g.Height(m1))
+
+	// nodes that belong to a different graph don't have heights in the graph.
+	other := New()
+	testutil.Equal(t, HeightUnset, other.Height(m1))
+}
@@ -2,8 +2,12 @@ package incr
 
 import (
 	"context"
+	"fmt"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/wcharczuk/go-incr/testutil"
 )
@@ -23,7 +27,7 @@ func Test_New(t *testing.T) {
 func Test_New_options_MaxHeight(t *testing.T) {
 	g := New(OptGraphMaxHeight(1024))
 	testutil.NotEqual(t, 1024, DefaultMaxHeight)
-	testutil.Equal(t, 1024, len(g.recomputeHeap.heights))
+	testutil.Equal(t, 1024, len(g.recomputeHeap.lists.(*recomputeHeapDense).heights))
 	testutil.Equal(t, 1024, len(g.adjustHeightsHeap.nodesByHeight))
 }
 
@@ -32,6 +36,139 @@ func Test_New_options_Parallelism(t *testing.T) {
 	testutil.Equal(t, runtime.NumCPU()*2, g.parallelism)
 }
 
+func Test_New_options_PreallocateHeightListsSize(t *testing.T) {
+	g := New(OptGraphMaxHeight(32), OptGraphPreallocateHeightListsSize(16))
+	for x := 0; x < 16; x++ {
+		testutil.NotNil(t, g.recomputeHeap.lists.(*recomputeHeapDense).heights[x])
+	}
+	for x := 16; x < 32; x++ {
+		testutil.Nil(t, g.recomputeHeap.lists.(*recomputeHeapDense).heights[x])
+	}
+}
+
+func Test_New_options_PreallocateHeightListsSize_cappedAtMaxHeight(t *testing.T) {
+	g := New(OptGraphMaxHeight(8), OptGraphPreallocateHeightListsSize(16))
+	testutil.Equal(t, 8, len(g.recomputeHeap.lists.(*recomputeHeapDense).heights))
+	testutil.Equal(t, 8, ExpertGraph(g).RecomputeHeapHeightListsAllocated())
+}
+
+func Test_New_options_PreallocateHeightListsSize_stabilize(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphPreallocateHeightListsSize(DefaultMaxHeight))
+	v0 := Var(g, "hello")
+	v1 := Var(g, "world")
+	m := Map2(g, v0, v1, concat)
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "helloworld", o.Value())
+
+	v1.Set("there")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hellothere", o.Value())
+}
+
+func Test_New_options_preallocate_reducesAllocations(t *testing.T) {
+	const size = 4096
+	withoutPreallocation := testing.AllocsPerRun(2, func() {
+		_, _ = makeBenchmarkGraph(size, false /*preallocate*/)
+	})
+	withPreallocation := testing.AllocsPerRun(2, func() {
+		_, _ = makeBenchmarkGraph(size, true /*preallocate*/)
+	})
+	testutil.Equal(t, true, withPreallocation < withoutPreallocation, fmt.Sprintf("with=%v without=%v", withPreallocation, withoutPreallocation))
+}
+
+func Test_New_options_PreallocateHeightListsSize_reducesAllocations(t *testing.T) {
+	const depth = 64
+	allocsObservingChain := func(opts ...GraphOption) float64 {
+		// create the graphs up front such that we only
+		// measure the allocations from observing the chain.
+		graphs := make([]*Graph, 3)
+		chains := make([]Incr[int], len(graphs))
+		for index := range graphs {
+			graphs[index] = New(opts...)
+			var cursor Incr[int] = Var(graphs[index], 0)
+			for x := 0; x < depth; x++ {
+				cursor = Map(graphs[index], cursor, ident)
+			}
+			chains[index] = cursor
+		}
+		var index int
+		return testing.AllocsPerRun(len(graphs)-1, func() {
+			_ = MustObserve(graphs[index], chains[index])
+			index++
+		})
+	}
+	withoutPreallocation := allocsObservingChain()
+	withPreallocation := allocsObservingChain(OptGraphPreallocateHeightListsSize(depth + 1))
+	testutil.Equal(t, true, withPreallocation < withoutPreallocation, fmt.Sprintf("with=%v without=%v", withPreallocation, withoutPreallocation))
+}
+
+func Test_New_options_PreallocateHeightListsSize_keptOnClear(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphPreallocateHeightListsSize(16), OptGraphClearRecomputeHeapOnError(true))
+	v := Var(g, "a")
+	m := MapContext(g, v, func(_ context.Context, _ string) (string, error) {
+		return "", fmt.Errorf("this is just a test")
+	})
+	_ = MustObserve(g, m)
+	_ = MustObserve(g, Map(g, m, ident))
+
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+	testutil.Equal(t, 16, ExpertGraph(g).RecomputeHeapHeightListsAllocated())
+	testutil.NoError(t, g.recomputeHeap.sanityCheck())
+}
+
+func Test_New_options_RecomputeHeapInitialHeights(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphRecomputeHeapInitialHeights(4))
+	testutil.Equal(t, 4, ExpertGraph(g).RecomputeHeapHeights())
+
+	// the lookup grows as nodes are added at taller heights.
+	var cursor Incr[int] = Var(g, 0)
+	for x := 0; x < 8; x++ {
+		cursor = Map(g, cursor, func(v int) int { return v + 1 })
+	}
+	o := MustObserve(g, cursor)
+	testutil.Equal(t, 9, ExpertGraph(g).RecomputeHeapHeights())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 8, o.Value())
+
+	g = New(OptGraphMaxHeight(8), OptGraphRecomputeHeapInitialHeights(16))
+	testutil.Equal(t, 8, ExpertGraph(g).RecomputeHeapHeights())
+
+	g = New(OptGraphRecomputeHeapSparse(true), OptGraphRecomputeHeapInitialHeights(16))
+	testutil.Equal(t, 0, ExpertGraph(g).RecomputeHeapHeights())
+}
+
+func Test_New_options_IdentifierProvider(t *testing.T) {
+	g := New(OptGraphIdentifierProvider(NewCounterIdentifierProvider()))
+	testutil.Equal(t, "00000000000000000000000000000001", g.ID().String())
+
+	v := Var(g, "hello")
+	testutil.Equal(t, "00000000000000000000000000000002", v.Node().ID().String())
+
+	b := Bind(g, v, func(bs Scope, vv string) Incr[string] {
+		return Return(bs, vv)
+	})
+	testutil.Equal(t, "00000000000000000000000000000003", b.(*bindMainIncr[string, string]).bind.lhsChange.Node().ID().String())
+	testutil.Equal(t, "00000000000000000000000000000004", b.Node().ID().String())
+
+	o := MustObserve(g, b)
+	testutil.Equal(t, "00000000000000000000000000000005", o.Node().ID().String())
+
+	err := g.Stabilize(testContext())
+	testutil.NoError(t, err)
+	testutil.Equal(t, "00000000000000000000000000000006", b.(*bindMainIncr[string, string]).bind.rhs.Node().ID().String())
+}
+
 func Test_Graph_Metadata(t *testing.T) {
 	g := New()
 	testutil.Nil(t, g.Metadata())
@@ -151,7 +288,7 @@ func Test_Graph_zeroNode(t *testing.T) {
 	_ = MustObserve(g, r)
 
 	testutil.Equal(t, 0, r.Node().height)
-	testutil.Equal(t, 0, r.Node().heightInRecomputeHeap)
+	testutil.Equal(t, HeightUnset, r.Node().heightInRecomputeHeap)
 	testutil.Equal(t, HeightUnset, r.Node().heightInAdjustHeightsHeap)
 	testutil.Equal(t, true, r.Node().valid)
 	testutil.NotNil(t, r.Node().createdIn)
@@ -171,13 +308,36 @@ func Test_Graph_zeroNode(t *testing.T) {
 	testutil.Equal(t, true, r.Node().valid)
 	testutil.NotNil(t, r.Node().createdIn)
 	testutil.Equal(t, 0, r.Node().setAt)
-	testutil.Equal(t, 0, r.Node().changedAt)
-	testutil.Equal(t, 0, r.Node().recomputedAt)
+	// return nodes are constants, and keep their recompute state.
+	testutil.Equal(t, 4, r.Node().changedAt)
+	testutil.Equal(t, 5, r.Node().recomputedAt)
 	testutil.Empty(t, r.Node().observers)
 
 	testutil.Equal(t, 1, g.numNodes)
 }
 
+func Test_Graph_zeroNode_notConstant(t *testing.T) {
+	g := New()
+
+	m := Map(g, Return(g, "hello"), ident)
+	_ = MustObserve(g, m)
+
+	testutil.Equal(t, 1, m.Node().height)
+	testutil.Equal(t, 1, m.Node().heightInRecomputeHeap)
+
+	m.Node().setAt = 3
+	m.Node().changedAt = 4
+	m.Node().recomputedAt = 5
+
+	g.zeroNode(m)
+
+	testutil.Equal(t, HeightUnset, m.Node().height)
+	testutil.Equal(t, HeightUnset, m.Node().heightInRecomputeHeap)
+	testutil.Equal(t, 0, m.Node().setAt)
+	testutil.Equal(t, 0, m.Node().changedAt)
+	testutil.Equal(t, 0, m.Node().recomputedAt)
+}
+
 func Test_Graph_addChild(t *testing.T) {
 	g := New()
 
@@ -194,3 +354,796 @@ func Test_Graph_addChild(t *testing.T) {
 	err = g.addChild(n0, n1)
 	testutil.NoError(t, err)
 }
+
+func Test_Graph_GetNode(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	ar := Map(g, Return(g, "a-value"), ident)
+	b := Bind(g, v, func(bs Scope, which string) Incr[string] {
+		if which == "a" {
+			return ar
+		}
+		return Map(bs, Return(bs, "b-value"), ident)
+	})
+	o := MustObserve(g, b)
+	s := Sentinel(g, func() bool { return false }, v)
+
+	n, ok := g.GetNode(v.Node().ID())
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, v.Node().ID(), n.Node().ID())
+	testutil.Equal(t, true, g.HasID(v.Node().ID()))
+	testutil.Equal(t, true, g.IsObservingID(v.Node().ID()))
+
+	n, ok = g.GetNode(o.Node().ID())
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, o.Node().ID(), n.Node().ID())
+	testutil.Equal(t, true, g.HasID(o.Node().ID()))
+	testutil.Equal(t, false, g.IsObservingID(o.Node().ID()))
+
+	n, ok = g.GetNode(s.Node().ID())
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, s.Node().ID(), n.Node().ID())
+	testutil.Equal(t, true, g.HasID(s.Node().ID()))
+
+	n, ok = g.GetNode(NewIdentifier())
+	testutil.Equal(t, false, ok)
+	testutil.Nil(t, n)
+	testutil.Equal(t, false, g.HasID(NewIdentifier()))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, g.IsObservingID(ar.Node().ID()))
+
+	// the node returned is the live node.
+	n, ok = g.GetNode(ar.Node().ID())
+	testutil.Equal(t, true, ok)
+	var updates int
+	n.Node().OnUpdate(func(_ context.Context) { updates++ })
+	g.SetStale(n)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, updates)
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b-value", o.Value())
+	testutil.Equal(t, false, g.IsObservingID(ar.Node().ID()))
+	testutil.Equal(t, false, g.HasID(ar.Node().ID()))
+
+	rhsID := b.(*bindMainIncr[string, string]).bind.rhs.Node().ID()
+	testutil.Equal(t, true, g.IsObservingID(rhsID))
+
+	v.Set("a")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a-value", o.Value())
+	testutil.Equal(t, true, g.IsObservingID(ar.Node().ID()))
+	testutil.Equal(t, false, g.IsObservingID(rhsID))
+	_, ok = g.GetNode(rhsID)
+	testutil.Equal(t, false, ok)
+}
+
+func Test_Graph_GetNode_roundTrip(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, 1)
+	m := Map(g, v, func(vv int) int { return vv * 2 })
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	id := o.Node().ID()
+	n, ok := g.GetNode(id)
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, true, SameNode(o, n))
+
+	typed, ok := n.(ObserveIncr[int])
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, 2, typed.Value())
+
+	n, ok = g.GetNode(m.Node().ID())
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, true, SameNode(m, n))
+	testutil.Equal(t, false, SameNode(v, n))
+
+	v.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 4, n.(Incr[int]).Value())
+}
+
+func Test_Graph_GetNode_concurrentStabilize(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	b := Bind(g, v, func(bs Scope, which string) Incr[string] {
+		return Map(bs, Return(bs, which), ident)
+	})
+	o := MustObserve(g, b)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for x := 0; x < 64; x++ {
+			_, _ = g.GetNode(o.Node().ID())
+			_ = g.HasID(v.Node().ID())
+			_ = g.IsObservingID(b.Node().ID())
+		}
+	}()
+	for x := 0; x < 16; x++ {
+		v.Set(fmt.Sprint(x))
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+	}
+	<-done
+	testutil.Equal(t, "15", o.Value())
+}
+
+func Test_Graph_Read_Stabilize_concurrent(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	type pair struct {
+		A, B int
+	}
+	a := Var(g, 0)
+	b := Var(g, 0)
+	m := Map2(g, a, b, func(av, bv int) pair {
+		return pair{av, bv}
+	})
+	o := MustObserve(g, m)
+
+	const iterations = 256
+	done := make(chan struct{})
+	stabilizeErrors := make(chan error, 1)
+	go func() {
+		defer close(done)
+		for x := 1; x <= iterations; x++ {
+			a.Set(x)
+			b.Set(x)
+			if err := g.Stabilize(ctx); err != nil {
+				stabilizeErrors <- err
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	var torn int32
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				g.Read(func() {
+					if p := o.Value(); p.A != p.B {
+						atomic.AddInt32(&torn, 1)
+					}
+				})
+				if p, _ := o.ValueStable(); p.A != p.B {
+					atomic.AddInt32(&torn, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	<-done
+
+	select {
+	case err := <-stabilizeErrors:
+		t.Fatal(err)
+	default:
+	}
+	testutil.Equal(t, int32(0), atomic.LoadInt32(&torn))
+
+	value, stabilizationNum := o.ValueStable()
+	testutil.Equal(t, pair{iterations, iterations}, value)
+	testutil.Equal(t, uint64(iterations), stabilizationNum)
+}
+
+func Test_Graph_Read_blocksStabilize(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "foo")
+	m := Map(g, v, ident)
+	o := MustObserve(g, m)
+
+	readStarted := make(chan struct{})
+	releaseRead := make(chan struct{})
+	go g.Read(func() {
+		close(readStarted)
+		<-releaseRead
+	})
+	<-readStarted
+
+	stabilized := make(chan error)
+	go func() {
+		stabilized <- g.Stabilize(ctx)
+	}()
+
+	select {
+	case <-stabilized:
+		t.Fatal("stabilize should block while a reader holds the read lock")
+	case <-time.After(10 * time.Millisecond):
+	}
+	testutil.Equal(t, "", o.Value())
+
+	close(releaseRead)
+	err := <-stabilized
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", o.Value())
+}
+
+func Test_Graph_Read_nested(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "foo")
+	m := Map(g, v, ident)
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	v.Set("bar")
+	readDone := make(chan string)
+	go g.Read(func() {
+		stabilized := make(chan error)
+		go func() {
+			stabilized <- g.Stabilize(ctx)
+		}()
+		// give the stabilization a chance to wait on the read lock.
+		<-time.After(10 * time.Millisecond)
+		value, _ := o.ValueStable()
+		readDone <- value
+		go func() {
+			testutil.NoError(t, <-stabilized)
+		}()
+	})
+
+	select {
+	case value := <-readDone:
+		testutil.Equal(t, "foo", value)
+	case <-time.After(time.Second):
+		t.Fatal("a nested read should not block on a waiting stabilization")
+	}
+}
+
+func Test_Graph_Read_betweenSteps(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "foo")
+	m0 := Map(g, v, ident)
+	m1 := Map(g, m0, ident)
+	o0 := MustObserve(g, m0)
+	o1 := MustObserve(g, m1)
+
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+
+	_, err = s.Step()
+	testutil.NoError(t, err)
+
+	// reading between steps should not block, and sees the
+	// values of the nodes as of the last step.
+	var value0, value1 string
+	g.Read(func() {
+		value0, value1 = o0.Value(), o1.Value()
+	})
+	testutil.Equal(t, "foo", value0)
+	testutil.Equal(t, "", value1)
+
+	value, _ := o0.ValueStable()
+	testutil.Equal(t, "foo", value)
+
+	err = s.Finish()
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", o1.Value())
+}
+
+func Test_Graph_SetStaleMany(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	var recomputes int
+	m0 := Map(g, v, func(vv string) string { recomputes++; return vv })
+	m1 := Map(g, v, func(vv string) string { recomputes++; return vv })
+	m2 := Map(g, v, func(vv string) string { recomputes++; return vv })
+	_ = g.MustObserveMany(ctx, m0, m1, m2)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, recomputes)
+
+	g.SetStaleMany(m0, m2, m2)
+	testutil.Equal(t, 2, g.recomputeHeap.len())
+	testutil.Equal(t, RecomputeReasonSetStale, g.StaleReason(m0).Reason)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5, recomputes)
+}
+
+func Test_Graph_SetStaleWithPriority(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	var order []string
+	newMap := func(label string) Incr[string] {
+		m := Map(g, v, func(vv string) string {
+			order = append(order, label)
+			return vv
+		})
+		m.Node().SetLabel(label)
+		return m
+	}
+	m0 := newMap("m0")
+	m1 := newMap("m1")
+	m2 := newMap("m2")
+	_ = g.MustObserveMany(ctx, m0, m1, m2)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	order = nil
+	g.SetStaleMany(m0, m1, m2)
+	g.SetStaleWithPriority(m2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"m2", "m0", "m1"}, order)
+
+	order = nil
+	g.SetStaleWithPriority(m1)
+	g.SetStale(m0)
+	g.SetStaleWithPriority(m2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"m2", "m1", "m0"}, order)
+}
+
+func Test_Graph_SetStaleWithPriority_deterministicOrdering(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphDeterministicOrdering())
+	v := Var(g, "a")
+	var order []string
+	newMap := func(label string) Incr[string] {
+		m := Map(g, v, func(vv string) string {
+			order = append(order, label)
+			return vv
+		})
+		m.Node().SetLabel(label)
+		return m
+	}
+	m0 := newMap("m0")
+	m1 := newMap("m1")
+	m2 := newMap("m2")
+	m3 := newMap("m3")
+	_ = g.MustObserveMany(ctx, m3, m2, m1, m0)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"m0", "m1", "m2", "m3"}, order)
+
+	order = nil
+	g.SetStaleMany(m3, m2, m1, m0)
+	g.SetStaleWithPriority(m2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"m2", "m0", "m1", "m3"}, order)
+
+	order = nil
+	g.SetStaleWithPriority(m1)
+	g.SetStale(m0)
+	g.SetStaleWithPriority(m3)
+	g.SetStale(m2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"m3", "m1", "m0", "m2"}, order)
+
+	// priority doesn't carry over once the node has been recomputed.
+	order = nil
+	g.SetStaleMany(m3, m2, m1, m0)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"m0", "m1", "m2", "m3"}, order)
+}
+
+func Test_Graph_deterministicOrdering(t *testing.T) {
+	ctx := testContext()
+
+	run := func() []string {
+		g := New(OptGraphDeterministicOrdering())
+		var order []string
+		record := func(label string, a, b string) string {
+			order = append(order, label)
+			return a + b
+		}
+		newMap2 := func(label string, a, b Incr[string]) Incr[string] {
+			return Map2(g, a, b, func(av, bv string) string {
+				return record(label, av, bv)
+			})
+		}
+		var vars []VarIncr[string]
+		for x := 0; x < 8; x++ {
+			vars = append(vars, Var(g, fmt.Sprint(x)))
+		}
+		l0 := newMap2("l0", vars[0], vars[1])
+		l1 := newMap2("l1", vars[2], vars[3])
+		l2 := newMap2("l2", vars[4], vars[5])
+		l3 := newMap2("l3", vars[6], vars[7])
+		r0 := newMap2("r0", l0, l1)
+		r1 := newMap2("r1", l2, l3)
+		root := newMap2("root", r0, r1)
+		o := MustObserve(g, root)
+
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, "01234567", o.Value())
+
+		for x := len(vars) - 1; x >= 0; x-- {
+			vars[x].Set(fmt.Sprint(x + 1))
+		}
+		err = g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, "12345678", o.Value())
+		return order
+	}
+
+	expected := []string{
+		"l0", "l1", "l2", "l3", "r0", "r1", "root",
+		"l0", "l1", "l2", "l3", "r0", "r1", "root",
+	}
+	for x := 0; x < 3; x++ {
+		testutil.Equal(t, expected, run())
+	}
+}
+
+func Test_Graph_deterministicOrdering_SetStaleMany(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphDeterministicOrdering())
+	v := Var(g, "a")
+	var order []string
+	var nodes []INode
+	for x := 0; x < 4; x++ {
+		label := fmt.Sprintf("m%d", x)
+		nodes = append(nodes, Map(g, v, func(vv string) string {
+			order = append(order, label)
+			return vv
+		}))
+	}
+	_ = g.MustObserveMany(ctx, nodes...)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	order = nil
+	g.SetStaleMany(nodes[3], nodes[2], nodes[1], nodes[0])
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"m0", "m1", "m2", "m3"}, order)
+
+	order = nil
+	g.SetStaleMany(nodes[3], nodes[1])
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+	for {
+		done, stepErr := s.Step()
+		testutil.NoError(t, stepErr)
+		if done {
+			break
+		}
+	}
+	testutil.NoError(t, s.Finish())
+	testutil.Equal(t, []string{"m1", "m3"}, order)
+}
+
+func Test_Graph_deterministicOrdering_updateHandlers(t *testing.T) {
+	ctx := testContext()
+
+	run := func() []string {
+		g := New(OptGraphDeterministicOrdering())
+		v := Var(g, "a")
+		var order []string
+		var nodes []INode
+		for x := 0; x < 16; x++ {
+			label := fmt.Sprintf("m%02d", x)
+			m := Map(g, v, ident)
+			m.Node().OnUpdate(func(_ context.Context) {
+				order = append(order, label)
+			})
+			nodes = append(nodes, m)
+		}
+		_ = g.MustObserveMany(ctx, nodes...)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		return order
+	}
+
+	var expected []string
+	for x := 0; x < 16; x++ {
+		expected = append(expected, fmt.Sprintf("m%02d", x))
+	}
+	for x := 0; x < 3; x++ {
+		testutil.Equal(t, expected, run())
+	}
+}
+
+func Test_Graph_deterministicOrdering_error(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphDeterministicOrdering())
+	v := Var(g, "a")
+	var recomputes int
+	m0 := MapContext(g, v, func(_ context.Context, vv string) (string, error) {
+		recomputes++
+		return "", fmt.Errorf("this is just a test")
+	})
+	m1 := Map(g, v, func(vv string) string {
+		recomputes++
+		return vv
+	})
+	_ = g.MustObserveMany(ctx, m0, m1)
+
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, 1, recomputes)
+	testutil.Equal(t, true, g.recomputeHeap.has(m1))
+}
+
+func Test_Graph_OnNodeAdded_OnNodeRemoved(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	var added, removed []string
+	g.OnNodeAdded(func(n INode) {
+		added = append(added, n.Node().Label())
+	})
+	g.OnNodeRemoved(func(n INode) {
+		removed = append(removed, n.Node().Label())
+	})
+
+	v := Var(g, "a")
+	v.Node().SetLabel("v")
+	m := Map(g, v, ident)
+	m.Node().SetLabel("m")
+	o := MustObserve(g, m)
+	o.Node().SetLabel("o")
+
+	testutil.Equal(t, []string{"", "m", "v"}, added)
+	testutil.Empty(t, removed)
+
+	o.Unobserve(ctx)
+	testutil.Equal(t, []string{"o", "v", "m"}, removed)
+}
+
+func Test_Graph_OnNodeAdded_OnNodeRemoved_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	bind := Bind(g, v, func(bs Scope, which string) Incr[string] {
+		m := Map(bs, Return(bs, which), ident)
+		m.Node().SetLabel("m-" + which)
+		return m
+	})
+	_ = MustObserve(g, bind)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	var events []string
+	g.OnNodeAdded(func(n INode) {
+		if label := n.Node().Label(); label != "" {
+			events = append(events, "added:"+label)
+		}
+	})
+	g.OnNodeRemoved(func(n INode) {
+		if label := n.Node().Label(); label != "" {
+			events = append(events, "removed:"+label)
+		}
+	})
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"added:m-b", "removed:m-a"}, events)
+}
+
+func Test_Graph_StabilizationNum_LastStabilizedAt(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	var chain Incr[string] = v
+	for x := 0; x < 8; x++ {
+		chain = Map(g, chain, ident)
+	}
+	var stabilizationNums []uint64
+	chain.Node().OnUpdate(func(_ context.Context) {
+		stabilizationNums = append(stabilizationNums, g.StabilizationNum())
+	})
+	o := MustObserve(g, chain)
+
+	testutil.Equal(t, 0, g.StabilizationNum())
+	testutil.Equal(t, true, g.LastStabilizedAt().IsZero())
+
+	var previous time.Time
+	for x := 0; x < 5; x++ {
+		v.Set(fmt.Sprint(x))
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, fmt.Sprint(x), o.Value())
+		testutil.Equal(t, x+1, g.StabilizationNum())
+		testutil.Equal(t, false, g.LastStabilizedAt().IsZero())
+		testutil.Equal(t, false, g.LastStabilizedAt().Before(previous))
+		previous = g.LastStabilizedAt()
+	}
+	testutil.Equal(t, []uint64{1, 2, 3, 4, 5}, stabilizationNums)
+}
+
+func Test_Graph_LastStabilizeChanged(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	om := MustObserve(g, Map(g, v, ident))
+
+	testutil.Equal(t, false, g.LastStabilizeChanged())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", om.Value())
+	testutil.Equal(t, true, g.LastStabilizeChanged())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.LastStabilizeChanged())
+
+	v.Set("b")
+	var changedDuring bool
+	g.OnStabilizationStart(func(_ context.Context) {
+		changedDuring = g.LastStabilizeChanged()
+	})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b", om.Value())
+	testutil.Equal(t, true, g.LastStabilizeChanged())
+
+	v.Set("c")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, g.LastStabilizeChanged())
+	testutil.Equal(t, false, changedDuring)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.LastStabilizeChanged())
+}
+
+func Test_Graph_LastStabilizeChanged_skipped(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphSkipEmptyStabilizations(true))
+
+	v := Var(g, "a")
+	om := MustObserve(g, Map(g, v, ident))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", om.Value())
+	testutil.Equal(t, true, g.LastStabilizeChanged())
+
+	// the stabilization is skipped as there is no work to do.
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.LastStabilizeChanged())
+
+	v.Set("b")
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b", om.Value())
+	testutil.Equal(t, true, g.LastStabilizeChanged())
+
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.LastStabilizeChanged())
+
+	v.Set("c")
+	stabilized, err := g.StabilizeIfNeeded(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, stabilized)
+	testutil.Equal(t, true, g.LastStabilizeChanged())
+
+	stabilized, err = g.StabilizeIfNeeded(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, stabilized)
+	testutil.Equal(t, false, g.LastStabilizeChanged())
+}
+
+func Test_Graph_WithDefaultContext(t *testing.T) {
+	testGraphWithDefaultContext(t, func(ctx context.Context, g *Graph) error {
+		return g.Stabilize(ctx)
+	})
+}
+
+func Test_Graph_WithDefaultContext_parallel(t *testing.T) {
+	testGraphWithDefaultContext(t, func(ctx context.Context, g *Graph) error {
+		return g.ParallelStabilize(ctx)
+	})
+}
+
+func testGraphWithDefaultContext(t *testing.T, stabilize func(context.Context, *Graph) error) {
+	t.Helper()
+
+	ctx := context.Background()
+	g := New()
+	g.WithDefaultContext(testutil.WithBlueDye)
+
+	var seenMu sync.Mutex
+	seen := make(map[string]bool)
+	see := func(ctx context.Context, kind string) {
+		testutil.BlueDye(ctx, t)
+		seenMu.Lock()
+		seen[kind] = true
+		seenMu.Unlock()
+	}
+
+	g.OnStabilizationStart(func(ictx context.Context) {
+		see(ictx, "start")
+	})
+	g.OnStabilizationEnd(func(ictx context.Context, _ time.Time, _ error) {
+		see(ictx, "end")
+	})
+
+	v := Var(g, "hello")
+	m := MapContext(g, v, func(ictx context.Context, v string) (string, error) {
+		see(ictx, "stabilize")
+		if v == "error" {
+			return "", fmt.Errorf("this is only a test")
+		}
+		return v, nil
+	})
+	m.Node().OnUpdate(func(ictx context.Context) {
+		see(ictx, "update")
+	})
+	m.Node().OnError(func(ictx context.Context, _ error) {
+		see(ictx, "error")
+	})
+	c := CutoffContext(g, m, func(ictx context.Context, _, _ string) (bool, error) {
+		see(ictx, "cutoff")
+		return false, nil
+	})
+	b := BindContext(g, c, func(ictx context.Context, bs Scope, v string) (Incr[string], error) {
+		see(ictx, "bind")
+		return Return(bs, v), nil
+	})
+	_ = MustObserve(g, b)
+
+	err := stabilize(ctx, g)
+	testutil.NoError(t, err)
+
+	v.Set("world")
+	err = stabilize(ctx, g)
+	testutil.NoError(t, err)
+
+	v.Set("error")
+	err = stabilize(ctx, g)
+	testutil.Error(t, err)
+
+	testutil.Equal(t, map[string]bool{
+		"start":     true,
+		"end":       true,
+		"stabilize": true,
+		"update":    true,
+		"error":     true,
+		"cutoff":    true,
+		"bind":      true,
+	}, seen)
+}
@@ -2,11 +2,13 @@ package incr
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // Identifier is a unique id.
@@ -70,6 +72,25 @@ func SetIdentifierProvider(ip func() Identifier) {
 	identifierProvider = ip
 }
 
+// NewCounterIdentifierProvider returns a new identifier provider that yields
+// identifiers from a monotonically increasing counter starting at 1.
+//
+// Each provider returned holds its own counter, and as a result two graphs
+// created with their own counter providers (see [OptGraphIdentifierProvider])
+// and built identically will assign identical identifiers to their nodes, which is
+// useful for producing reproducible [Dot] output and traces.
+//
+// The identifiers this provider yields are not random, and should not be relied
+// upon for uniqueness across providers.
+func NewCounterIdentifierProvider() func() Identifier {
+	var counter uint64
+	return func() (output Identifier) {
+		newCounter := atomic.AddUint64(&counter, 1)
+		binary.BigEndian.PutUint64(output[8:], newCounter)
+		return
+	}
+}
+
 func cryptoRandIdentifierProvider() (output Identifier) {
 	identifierRandPoolMu.Lock()
 	if identifierRandPoolPos == randPoolSize {
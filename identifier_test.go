@@ -48,6 +48,15 @@ func Test_SetIdentifierProvider(t *testing.T) {
 	testutil.Equal(t, "00000000000000000000000000000003", NewIdentifier().String())
 }
 
+func Test_NewCounterIdentifierProvider(t *testing.T) {
+	p0 := NewCounterIdentifierProvider()
+	p1 := NewCounterIdentifierProvider()
+	testutil.Equal(t, "00000000000000000000000000000001", p0().String())
+	testutil.Equal(t, "00000000000000000000000000000002", p0().String())
+	testutil.Equal(t, "00000000000000000000000000000001", p1().String())
+	testutil.Equal(t, "00000000000000000000000000000003", p0().String())
+}
+
 func Test_Identifier_IsZero(t *testing.T) {
 	id := NewIdentifier()
 	testutil.Equal(t, false, id.IsZero())
@@ -61,6 +61,29 @@ type IAlways interface {
 	Always()
 }
 
+// IAlwaysWhen is an [IAlways] type that can opt out of being recomputed
+// for a stabilization pass if none of its inputs have changed.
+type IAlwaysWhen interface {
+	IAlways
+	ShouldRecompute(context.Context) (bool, error)
+}
+
+// iConstant is a type whose value never changes after construction.
+//
+// Nodes that implement this interface are recomputed at most once,
+// and are not re-added to the recompute heap if they become unnecessary
+// and then necessary again.
+type iConstant interface {
+	isConstant()
+}
+
+// iSkipIfUnchanged is a type that can skip being recomputed if none
+// of its inputs have changed since it was last recomputed, e.g. if it
+// was scheduled because its height was adjusted.
+type iSkipIfUnchanged interface {
+	skipIfUnchanged()
+}
+
 // ISentinel is a node that manages the staleness of a target node
 // based on a predicate and can mark that target node for recomputation.
 type ISentinel interface {
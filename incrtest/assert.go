@@ -0,0 +1,30 @@
+package incrtest
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr"
+)
+
+// AssertRecomputedExactly is a test helper to verify that a node
+// has been recomputed an exact number of times.
+//
+// The count is the total number of times the node was recomputed, including
+// recomputations that were cut off, across all stabilizations.
+func AssertRecomputedExactly(t testing.TB, node incr.INode, expected uint64) {
+	t.Helper()
+	if actual := incr.ExpertNode(node).NumRecomputes(); actual != expected {
+		t.Fatalf("recompute count assertion failure; node=%v actual=%d expected=%d", node, actual, expected)
+	}
+}
+
+// AssertChangedExactly is a test helper to verify that a node
+// has changed an exact number of times.
+//
+// A node changes when it is recomputed and not cut off.
+func AssertChangedExactly(t testing.TB, node incr.INode, expected uint64) {
+	t.Helper()
+	if actual := incr.ExpertNode(node).NumChanges(); actual != expected {
+		t.Fatalf("change count assertion failure; node=%v actual=%d expected=%d", node, actual, expected)
+	}
+}
@@ -0,0 +1,67 @@
+package incrtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr"
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_AssertRecomputedExactly(t *testing.T) {
+	ctx := context.Background()
+	g := incr.New()
+
+	v := incr.Var(g, 1)
+	c := incr.Cutoff(g, v, func(previous, current int) bool {
+		return previous == current
+	})
+	m := incr.Map(g, c, func(vv int) int { return vv * 2 })
+	_ = incr.MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	AssertRecomputedExactly(t, c, 1)
+	AssertChangedExactly(t, c, 1)
+	AssertRecomputedExactly(t, m, 1)
+
+	incr.ExpertGraph(g).RecomputeHeapAdd(c)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	AssertRecomputedExactly(t, c, 2)
+	AssertChangedExactly(t, c, 1)
+	AssertRecomputedExactly(t, m, 1)
+	AssertChangedExactly(t, m, 1)
+}
+
+func Test_AssertRecomputedExactly_fails(t *testing.T) {
+	g := incr.New()
+	m := incr.Map(g, incr.Var(g, 1), func(vv int) int { return vv })
+
+	ft := new(fatalRecorder)
+	AssertRecomputedExactly(ft, m, 1)
+	testutil.Matches(t, "recompute count assertion failure", ft.message)
+	testutil.Matches(t, "actual=0 expected=1", ft.message)
+
+	ft = new(fatalRecorder)
+	AssertChangedExactly(ft, m, 2)
+	testutil.Matches(t, "change count assertion failure", ft.message)
+
+	ft = new(fatalRecorder)
+	AssertRecomputedExactly(ft, m, 0)
+	testutil.Equal(t, "", ft.message)
+}
+
+// fatalRecorder is a [testing.TB] that records
+// the message passed to Fatalf rather than failing.
+type fatalRecorder struct {
+	testing.TB
+	message string
+}
+
+func (fr *fatalRecorder) Helper() {}
+
+func (fr *fatalRecorder) Fatalf(format string, args ...any) {
+	fr.message = fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,8 @@
+/*
+Package incrtest provides test helpers for code that uses incr.
+
+The helpers let you assert how many times nodes are recomputed or changed,
+which is useful for regression tests for over-recomputation, and to verify
+that custom nodes and cutoffs behave as expected.
+*/
+package incrtest
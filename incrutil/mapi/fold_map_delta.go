@@ -0,0 +1,72 @@
+package mapi
+
+import (
+	"context"
+	"maps"
+
+	"github.com/wcharczuk/go-incr"
+)
+
+// FoldMapDelta returns an incremental node that folds the values of an input map into
+// an accumulator, applying only the changes to the map between stabilizations.
+//
+// When a key is added the add function is applied, when a key is removed the remove function
+// is applied, and when the value for a key changes, the remove function is applied for the
+// previous value and then the add function is applied for the new value.
+//
+// For folds like a sum this means the fold functions are called once per changed key
+// rather than once per key, though finding the changed keys still compares every key.
+func FoldMapDelta[M ~map[K]V, K, V comparable, B any](scope incr.Scope, i incr.Incr[M], initial B, add, remove func(B, K, V) B) incr.Incr[B] {
+	return incr.WithinScope(scope, &foldMapDeltaIncr[M, K, V, B]{
+		n:       incr.NewNode("mapi_fold_map_delta"),
+		i:       i,
+		add:     add,
+		remove:  remove,
+		parents: []incr.INode{i},
+		val:     initial,
+	})
+}
+
+type foldMapDeltaIncr[M ~map[K]V, K, V comparable, B any] struct {
+	n       *incr.Node
+	i       incr.Incr[M]
+	add     func(B, K, V) B
+	remove  func(B, K, V) B
+	parents []incr.INode
+	last    M
+	val     B
+}
+
+func (mfn *foldMapDeltaIncr[M, K, V, B]) Parents() []incr.INode {
+	return mfn.parents
+}
+
+func (mfn *foldMapDeltaIncr[M, K, V, B]) String() string {
+	return mfn.n.String()
+}
+
+func (mfn *foldMapDeltaIncr[M, K, V, B]) Node() *incr.Node { return mfn.n }
+
+func (mfn *foldMapDeltaIncr[M, K, V, B]) Value() B { return mfn.val }
+
+func (mfn *foldMapDeltaIncr[M, K, V, B]) Stabilize(_ context.Context) error {
+	newVal := mfn.i.Value()
+	for k, v := range mfn.last {
+		if _, ok := newVal[k]; !ok {
+			mfn.val = mfn.remove(mfn.val, k, v)
+		}
+	}
+	for k, v := range newVal {
+		previous, ok := mfn.last[k]
+		if !ok {
+			mfn.val = mfn.add(mfn.val, k, v)
+			continue
+		}
+		if previous != v {
+			mfn.val = mfn.remove(mfn.val, k, previous)
+			mfn.val = mfn.add(mfn.val, k, v)
+		}
+	}
+	mfn.last = maps.Clone(newVal)
+	return nil
+}
@@ -0,0 +1,90 @@
+package mapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wcharczuk/go-incr"
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_FoldMapDelta(t *testing.T) {
+	ctx := context.Background()
+	g := incr.New()
+	v := incr.Var(g, map[string]int{"foo": 1, "bar": 2})
+
+	var adds, removes int
+	sum := FoldMapDelta(g, v, 0,
+		func(acc int, _ string, value int) int {
+			adds++
+			return acc + value
+		},
+		func(acc int, _ string, value int) int {
+			removes++
+			return acc - value
+		},
+	)
+	os := incr.MustObserve(g, sum)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, os.Value())
+	testutil.Equal(t, 2, adds)
+	testutil.Equal(t, 0, removes)
+
+	// add a key
+	v.Set(map[string]int{"foo": 1, "bar": 2, "buzz": 3})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, os.Value())
+	testutil.Equal(t, 3, adds)
+	testutil.Equal(t, 0, removes)
+
+	// change a key
+	v.Set(map[string]int{"foo": 10, "bar": 2, "buzz": 3})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 15, os.Value())
+	testutil.Equal(t, 4, adds)
+	testutil.Equal(t, 1, removes)
+
+	// remove a key
+	v.Set(map[string]int{"foo": 10, "buzz": 3})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 13, os.Value())
+	testutil.Equal(t, 4, adds)
+	testutil.Equal(t, 2, removes)
+
+	// no changes
+	v.Set(map[string]int{"foo": 10, "buzz": 3})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 13, os.Value())
+	testutil.Equal(t, 4, adds)
+	testutil.Equal(t, 2, removes)
+}
+
+func Test_FoldMapDelta_mutatedInPlace(t *testing.T) {
+	ctx := context.Background()
+	g := incr.New()
+	m := map[string]int{"foo": 1}
+	v := incr.Var(g, m)
+
+	sum := FoldMapDelta(g, v, 0,
+		func(acc int, _ string, value int) int { return acc + value },
+		func(acc int, _ string, value int) int { return acc - value },
+	)
+	os := incr.MustObserve(g, sum)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, os.Value())
+
+	m["foo"] = 5
+	m["bar"] = 2
+	v.Set(m)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 7, os.Value())
+}
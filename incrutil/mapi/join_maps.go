@@ -0,0 +1,49 @@
+package mapi
+
+import (
+	"github.com/wcharczuk/go-incr"
+)
+
+// JoinMaps returns an incremental that joins two input maps by key, applying
+// a function to the left and right values for each key in the output map.
+//
+// By default the join is an inner join, that is, only keys present in both maps are
+// included in the output (see [incr.Join]); use [JoinMapsWithKind] to do a left join
+// instead (see [incr.LeftJoin]).
+func JoinMaps[K comparable, A, B, C any](scope incr.Scope, left incr.Incr[map[K]A], right incr.Incr[map[K]B], fn func(K, A, B) C, opts ...JoinMapsOption) incr.Incr[map[K]C] {
+	var options JoinMapsOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.Kind == JoinLeft {
+		return incr.LeftJoin(scope, left, right, fn)
+	}
+	return incr.Join(scope, left, right, fn)
+}
+
+// JoinKind is the policy for keys that are missing from one of the inputs of [JoinMaps].
+type JoinKind int
+
+// JoinKind values.
+const (
+	// JoinInner only includes keys that are present in both inputs.
+	JoinInner JoinKind = iota
+	// JoinLeft includes every key in the left input, passing the zero
+	// value for the right value if the key is missing from the right input.
+	JoinLeft
+)
+
+// JoinMapsOption mutates [JoinMapsOptions].
+type JoinMapsOption func(*JoinMapsOptions)
+
+// JoinMapsWithKind sets the policy for keys that are missing from one of the inputs.
+func JoinMapsWithKind(kind JoinKind) func(*JoinMapsOptions) {
+	return func(jo *JoinMapsOptions) {
+		jo.Kind = kind
+	}
+}
+
+// JoinMapsOptions are options for [JoinMaps] nodes.
+type JoinMapsOptions struct {
+	Kind JoinKind
+}
@@ -0,0 +1,117 @@
+package mapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wcharczuk/go-incr"
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_JoinMaps(t *testing.T) {
+	ctx := context.Background()
+	g := incr.New()
+	left := incr.Var(g, map[string]int{"a": 1, "b": 2, "c": 3})
+	right := incr.Var(g, map[string]string{"a": "x", "b": "y", "d": "z"})
+
+	calls := make(map[string]int)
+	j := JoinMaps(g, left, right, func(k string, l int, r string) string {
+		calls[k]++
+		return r + ":" + k + ":" + string(rune('0'+l))
+	})
+	o := incr.MustObserve(g, j)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]string{"a": "x:a:1", "b": "y:b:2"}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 1, "b": 1}, calls)
+
+	// a single key changing on the left only applies the function for that key.
+	left.Set(map[string]int{"a": 1, "b": 5, "c": 3})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]string{"a": "x:a:1", "b": "y:b:5"}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 1, "b": 2}, calls)
+
+	// as does a single key changing on the right.
+	right.Set(map[string]string{"a": "w", "b": "y", "d": "z"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]string{"a": "w:a:1", "b": "y:b:5"}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 2, "b": 2}, calls)
+
+	// a key appearing on the right joins with the existing left key.
+	right.Set(map[string]string{"a": "w", "b": "y", "c": "v", "d": "z"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]string{"a": "w:a:1", "b": "y:b:5", "c": "v:c:3"}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 2, "b": 2, "c": 1}, calls)
+
+	// deleting a key from either side removes it from the output.
+	left.Set(map[string]int{"b": 5, "c": 3})
+	right.Set(map[string]string{"a": "w", "b": "y", "d": "z"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]string{"b": "y:b:5"}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 2, "b": 2, "c": 1}, calls)
+}
+
+func Test_JoinMaps_outputCopied(t *testing.T) {
+	ctx := context.Background()
+	g := incr.New()
+	left := incr.Var(g, map[string]int{"a": 1})
+	right := incr.Var(g, map[string]int{"a": 2})
+
+	j := JoinMaps(g, left, right, func(_ string, l, r int) int {
+		return l + r
+	})
+	o := incr.MustObserve(g, j)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	previous := o.Value()
+	testutil.Equal(t, map[string]int{"a": 3}, previous)
+
+	left.Set(map[string]int{"a": 10})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 12}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 3}, previous)
+}
+
+func Test_JoinMaps_left(t *testing.T) {
+	ctx := context.Background()
+	g := incr.New()
+	left := incr.Var(g, map[string]int{"a": 1, "b": 2})
+	right := incr.Var(g, map[string]int{"a": 10})
+
+	var calls int
+	j := JoinMaps(g, left, right, func(_ string, l, r int) int {
+		calls++
+		return l + r
+	}, JoinMapsWithKind(JoinLeft))
+	o := incr.MustObserve(g, j)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 11, "b": 2}, o.Value())
+	testutil.Equal(t, 2, calls)
+
+	right.Set(map[string]int{"a": 10, "b": 20, "c": 30})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 11, "b": 22}, o.Value())
+	testutil.Equal(t, 3, calls, "keys only in the right map should not be joined")
+
+	right.Set(map[string]int{"b": 20, "c": 30})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 1, "b": 22}, o.Value())
+	testutil.Equal(t, 4, calls)
+
+	left.Set(map[string]int{"a": 1})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 1}, o.Value())
+	testutil.Equal(t, 4, calls)
+}
@@ -1,16 +1,128 @@
 package slicei
 
-import "github.com/wcharczuk/go-incr"
+import (
+	"context"
+	"fmt"
+
+	"github.com/wcharczuk/go-incr"
+)
 
 // Filter takes an input incremental and applies a predicate to it.
-func Filter[A any](g incr.Scope, input incr.Incr[[]A], pred func(A) bool) incr.Incr[[]A] {
-	return incr.Map(g, input, func(value []A) []A {
-		var output []A
-		for _, v := range value {
-			if pred(v) {
-				output = append(output, v)
-			}
-		}
-		return output
+//
+// When the input slice is an append-only extension of the slice from the previous
+// stabilization, the predicate is only applied to the appended elements, and the matching
+// elements are appended to the previous output. Otherwise the predicate is applied to
+// every element of the input.
+//
+// The input is considered to be appended to if it is at least as long as the previous
+// input and shares its backing array, which takes constant time, e.g. if it was
+// appended to in place without growing. As a result, modifying the elements of the
+// input in place is not detected; use [FilterEqualFunc] to compare the elements instead.
+func Filter[A any](scope incr.Scope, input incr.Incr[[]A], pred func(A) bool) incr.Incr[[]A] {
+	return incr.WithinScope(scope, &filterIncr[A]{
+		n: incr.NewNode("slicei_filter"),
+		i: input,
+		pred: func(_ context.Context, v A) (bool, error) {
+			return pred(v), nil
+		},
+	})
+}
+
+// FilterContext is like [Filter] but the predicate is passed the stabilization context
+// and can return an error, which will abort the stabilization.
+func FilterContext[A any](scope incr.Scope, input incr.Incr[[]A], pred func(context.Context, A) (bool, error)) incr.Incr[[]A] {
+	return incr.WithinScope(scope, &filterIncr[A]{
+		n:    incr.NewNode("slicei_filter"),
+		i:    input,
+		pred: pred,
 	})
 }
+
+// FilterEqualFunc is like [Filter] but uses a given equality function to determine
+// if the input slice is an append-only extension of the previous input slice.
+//
+// Each of the elements of the previous input is compared with the input, and the
+// input is copied to compare against on the next stabilization, such that the cost
+// of the check grows with the length of the input, but the input can be copied or
+// modified in place between stabilizations.
+func FilterEqualFunc[A any](scope incr.Scope, input incr.Incr[[]A], eq func(A, A) bool, pred func(A) bool) incr.Incr[[]A] {
+	return incr.WithinScope(scope, &filterIncr[A]{
+		n:  incr.NewNode("slicei_filter"),
+		i:  input,
+		eq: eq,
+		pred: func(_ context.Context, v A) (bool, error) {
+			return pred(v), nil
+		},
+	})
+}
+
+var (
+	_ incr.Incr[[]any] = (*filterIncr[any])(nil)
+	_ incr.IParents    = (*filterIncr[any])(nil)
+	_ incr.IStabilize  = (*filterIncr[any])(nil)
+	_ fmt.Stringer     = (*filterIncr[any])(nil)
+)
+
+type filterIncr[A any] struct {
+	n *incr.Node
+	i incr.Incr[[]A]
+	// eq is used to compare the elements of the input with the
+	// previous input if set, otherwise the backing arrays are compared.
+	eq    func(A, A) bool
+	pred  func(context.Context, A) (bool, error)
+	last  []A
+	value []A
+}
+
+func (fi *filterIncr[A]) Parents() []incr.INode { return []incr.INode{fi.i} }
+
+func (fi *filterIncr[A]) Node() *incr.Node { return fi.n }
+
+func (fi *filterIncr[A]) Value() []A { return fi.value }
+
+func (fi *filterIncr[A]) Stabilize(ctx context.Context) error {
+	values := fi.i.Value()
+	var output []A
+	var start int
+	if fi.last != nil && fi.isAppendOnly(values) {
+		output = fi.value
+		start = len(fi.last)
+	}
+	for _, v := range values[start:] {
+		ok, err := fi.pred(ctx, v)
+		if err != nil {
+			return err
+		}
+		if ok {
+			output = append(output, v)
+		}
+	}
+	if fi.eq == nil {
+		fi.last = values
+	} else {
+		// we copy the input values so that changes to the input's backing
+		// array don't show up in the values we compare against next pass.
+		fi.last = make([]A, len(values))
+		copy(fi.last, values)
+	}
+	fi.value = output
+	return nil
+}
+
+// isAppendOnly returns if the given values start with the values from the previous pass.
+func (fi *filterIncr[A]) isAppendOnly(values []A) bool {
+	if len(values) < len(fi.last) {
+		return false
+	}
+	if fi.eq == nil {
+		return len(fi.last) == 0 || &values[0] == &fi.last[0]
+	}
+	for index := range fi.last {
+		if !fi.eq(fi.last[index], values[index]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (fi *filterIncr[A]) String() string { return fi.n.String() }
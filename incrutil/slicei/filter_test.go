@@ -1,6 +1,8 @@
 package slicei
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/wcharczuk/go-incr"
@@ -21,3 +23,176 @@ func Test_Filter(t *testing.T) {
 	testutil.NoError(t, err)
 	testutil.Equal(t, []int{0, 2, 4, 6, 8, 10}, of.Value())
 }
+
+func Test_Filter_appendOnly(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	var calls int
+	values := make([]int, 0, 16)
+	values = append(values, 0, 1, 2, 3)
+	v := incr.Var(g, values)
+	f := Filter(g, v, func(val int) bool {
+		calls++
+		return val%2 == 0
+	})
+	of := incr.MustObserve(g, f)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{0, 2}, of.Value())
+	testutil.Equal(t, 4, calls)
+
+	values = append(values, 4, 5, 6)
+	v.Set(values)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{0, 2, 4, 6}, of.Value())
+	testutil.Equal(t, 7, calls)
+
+	// setting the same values should only evaluate
+	// the (empty) suffix.
+	v.Set(values)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{0, 2, 4, 6}, of.Value())
+	testutil.Equal(t, 7, calls)
+
+	// a copy of the values doesn't share the backing
+	// array, and is evaluated from scratch.
+	v.Set([]int{0, 1, 2, 3, 4, 5, 6, 7, 8})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{0, 2, 4, 6, 8}, of.Value())
+	testutil.Equal(t, 16, calls)
+}
+
+func Test_Filter_replacement(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	var calls int
+	v := incr.Var(g, []int{0, 1, 2, 3})
+	f := Filter(g, v, func(val int) bool {
+		calls++
+		return val%2 == 0
+	})
+	of := incr.MustObserve(g, f)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 4, calls)
+
+	// changed prefix
+	v.Set([]int{10, 1, 2, 3, 4})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{10, 2, 4}, of.Value())
+	testutil.Equal(t, 9, calls)
+
+	// shorter
+	v.Set([]int{5, 6})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{6}, of.Value())
+	testutil.Equal(t, 11, calls)
+
+	// empty
+	v.Set(nil)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Empty(t, of.Value())
+	testutil.Equal(t, 11, calls)
+
+	v.Set([]int{8})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{8}, of.Value())
+	testutil.Equal(t, 12, calls)
+}
+
+func Test_FilterEqualFunc_inPlaceMutation(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	values := []int{0, 1, 2, 3}
+	v := incr.Var(g, values)
+	f := FilterEqualFunc(g, v, func(a, b int) bool {
+		return a == b
+	}, func(val int) bool {
+		return val%2 == 0
+	})
+	of := incr.MustObserve(g, f)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{0, 2}, of.Value())
+
+	values[1] = 4
+	v.Set(values)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{0, 4, 2}, of.Value())
+}
+
+func Test_FilterEqualFunc(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	type event struct {
+		ID    int
+		Valid bool
+		Meta  map[string]string
+	}
+	var calls int
+	v := incr.Var(g, []event{{ID: 1, Valid: true}, {ID: 2}})
+	f := FilterEqualFunc(g, v, func(a, b event) bool {
+		return a.ID == b.ID
+	}, func(e event) bool {
+		calls++
+		return e.Valid
+	})
+	of := incr.MustObserve(g, f)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, len(of.Value()))
+	testutil.Equal(t, 2, calls)
+
+	v.Set([]event{{ID: 1, Valid: true, Meta: map[string]string{"a": "b"}}, {ID: 2}, {ID: 3, Valid: true}})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, len(of.Value()))
+	testutil.Equal(t, 3, of.Value()[1].ID)
+	testutil.Equal(t, 3, calls)
+
+	v.Set([]event{{ID: 4, Valid: true}})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, len(of.Value()))
+	testutil.Equal(t, 4, of.Value()[0].ID)
+	testutil.Equal(t, 4, calls)
+}
+
+func Test_FilterContext(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	v := incr.Var(g, []int{0, 1, 2})
+	f := FilterContext(g, v, func(ctx context.Context, val int) (bool, error) {
+		testutil.BlueDye(ctx, t)
+		if val > 2 {
+			return false, fmt.Errorf("this is just a test")
+		}
+		return val > 0, nil
+	})
+	of := incr.MustObserve(g, f)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{1, 2}, of.Value())
+
+	v.Set([]int{0, 1, 2, 3})
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+}
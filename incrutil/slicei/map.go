@@ -0,0 +1,71 @@
+package slicei
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wcharczuk/go-incr"
+)
+
+// Map returns an incremental that applies a function to each element of an input slice incremental.
+//
+// When the input slice changes, the function is only re-applied to the indices whose element
+// differs from the element at that index in the previous stabilization's slice (as determined by `==`);
+// outputs for unchanged indices are carried over. Length changes are handled by applying the function
+// to any new trailing elements, or by truncating the output.
+func Map[A comparable, B any](scope incr.Scope, input incr.Incr[[]A], fn func(int, A) B) incr.Incr[[]B] {
+	return MapEqualFunc(scope, input, func(a, b A) bool { return a == b }, fn)
+}
+
+// MapEqualFunc is like [Map] but uses a given equality function to determine which elements
+// of the input slice have changed between stabilizations.
+func MapEqualFunc[A, B any](scope incr.Scope, input incr.Incr[[]A], eq func(A, A) bool, fn func(int, A) B) incr.Incr[[]B] {
+	return incr.WithinScope(scope, &mapIncr[A, B]{
+		n:  incr.NewNode("slicei_map"),
+		i:  input,
+		eq: eq,
+		fn: fn,
+	})
+}
+
+var (
+	_ incr.Incr[[]any] = (*mapIncr[any, any])(nil)
+	_ incr.IParents    = (*mapIncr[any, any])(nil)
+	_ incr.IStabilize  = (*mapIncr[any, any])(nil)
+	_ fmt.Stringer     = (*mapIncr[any, any])(nil)
+)
+
+type mapIncr[A, B any] struct {
+	n     *incr.Node
+	i     incr.Incr[[]A]
+	eq    func(A, A) bool
+	fn    func(int, A) B
+	last  []A
+	value []B
+}
+
+func (mi *mapIncr[A, B]) Parents() []incr.INode { return []incr.INode{mi.i} }
+
+func (mi *mapIncr[A, B]) Node() *incr.Node { return mi.n }
+
+func (mi *mapIncr[A, B]) Value() []B { return mi.value }
+
+func (mi *mapIncr[A, B]) Stabilize(_ context.Context) error {
+	values := mi.i.Value()
+	output := make([]B, len(values))
+	for index, v := range values {
+		if index < len(mi.last) && mi.eq(mi.last[index], v) {
+			output[index] = mi.value[index]
+			continue
+		}
+		output[index] = mi.fn(index, v)
+	}
+	// we copy the input values so that changes to the input's backing
+	// array don't show up in the values we compare against next pass.
+	mi.last = make([]A, len(values))
+	copy(mi.last, values)
+	mi.value = output
+	return nil
+}
+
+func (mi *mapIncr[A, B]) String() string { return mi.n.String() }
@@ -0,0 +1,102 @@
+package slicei
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr"
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Map(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	v := incr.Var(g, []int{1, 2, 3})
+	var calls []int
+	m := Map(g, v, func(index, value int) string {
+		calls = append(calls, index)
+		return fmt.Sprint(value * 10)
+	})
+	o := incr.MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"10", "20", "30"}, o.Value())
+	testutil.Equal(t, []int{0, 1, 2}, calls)
+
+	calls = nil
+	v.Set([]int{1, 5, 3})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"10", "50", "30"}, o.Value())
+	testutil.Equal(t, []int{1}, calls)
+
+	calls = nil
+	v.Set([]int{1, 5, 3, 4})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"10", "50", "30", "40"}, o.Value())
+	testutil.Equal(t, []int{3}, calls)
+
+	calls = nil
+	v.Set([]int{1, 5})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"10", "50"}, o.Value())
+	testutil.Empty(t, calls)
+
+	calls = nil
+	v.Set([]int{1, 5, 6})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"10", "50", "60"}, o.Value())
+	testutil.Equal(t, []int{2}, calls)
+}
+
+func Test_Map_inPlaceMutation(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	values := []int{1, 2, 3}
+	v := incr.Var(g, values)
+	m := Map(g, v, func(_, value int) int {
+		return value * 10
+	})
+	o := incr.MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{10, 20, 30}, o.Value())
+
+	values[0] = 4
+	v.Set(values)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{40, 20, 30}, o.Value())
+}
+
+func Test_MapEqualFunc(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	v := incr.Var(g, []string{"a", "b"})
+	var calls int
+	m := MapEqualFunc(g, v, strings.EqualFold, func(_ int, value string) string {
+		calls++
+		return value + value
+	})
+	o := incr.MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"aa", "bb"}, o.Value())
+	testutil.Equal(t, 2, calls)
+
+	v.Set([]string{"A", "c"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"aa", "cc"}, o.Value())
+	testutil.Equal(t, 3, calls)
+}
@@ -0,0 +1,100 @@
+package slicei
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/wcharczuk/go-incr"
+)
+
+// Partition splits an input slice incremental into two incrementals, one with the elements
+// that match a given predicate and one with the elements that do not.
+//
+// Both outputs share a single pass over the input, that is, the predicate is called
+// once per element each time the input changes regardless of which (or how many)
+// of the outputs are observed. Both outputs are placed at the input's height + 1.
+func Partition[A any](scope incr.Scope, input incr.Incr[[]A], pred func(A) bool) (matched incr.Incr[[]A], rest incr.Incr[[]A]) {
+	p := &partition[A]{
+		i:    input,
+		pred: pred,
+	}
+	matched = incr.WithinScope(scope, &partitionIncr[A]{
+		n:       incr.NewNode("slicei_partition"),
+		p:       p,
+		matched: true,
+	})
+	rest = incr.WithinScope(scope, &partitionIncr[A]{
+		n: incr.NewNode("slicei_partition"),
+		p: p,
+	})
+	return
+}
+
+// partition is the compute shared between the partition outputs.
+type partition[A any] struct {
+	mu            sync.Mutex
+	i             incr.Incr[[]A]
+	pred          func(A) bool
+	computed      bool
+	lastChangedAt uint64
+	matched       []A
+	rest          []A
+}
+
+// maybeCompute runs the predicate over the input if the input
+// has changed since the last time we computed the outputs.
+func (p *partition[A]) maybeCompute() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	changedAt := incr.ExpertNode(p.i).ChangedAt()
+	if p.computed && p.lastChangedAt == changedAt {
+		return
+	}
+	var matched, rest []A
+	for _, v := range p.i.Value() {
+		if p.pred(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	p.matched = matched
+	p.rest = rest
+	p.lastChangedAt = changedAt
+	p.computed = true
+}
+
+var (
+	_ incr.Incr[[]any] = (*partitionIncr[any])(nil)
+	_ incr.IParents    = (*partitionIncr[any])(nil)
+	_ incr.IStabilize  = (*partitionIncr[any])(nil)
+	_ fmt.Stringer     = (*partitionIncr[any])(nil)
+)
+
+type partitionIncr[A any] struct {
+	n       *incr.Node
+	p       *partition[A]
+	matched bool
+	value   []A
+}
+
+func (pi *partitionIncr[A]) Parents() []incr.INode { return []incr.INode{pi.p.i} }
+
+func (pi *partitionIncr[A]) Node() *incr.Node { return pi.n }
+
+func (pi *partitionIncr[A]) Value() []A { return pi.value }
+
+func (pi *partitionIncr[A]) Stabilize(_ context.Context) error {
+	pi.p.maybeCompute()
+	pi.p.mu.Lock()
+	defer pi.p.mu.Unlock()
+	if pi.matched {
+		pi.value = pi.p.matched
+	} else {
+		pi.value = pi.p.rest
+	}
+	return nil
+}
+
+func (pi *partitionIncr[A]) String() string { return pi.n.String() }
@@ -0,0 +1,82 @@
+package slicei
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr"
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Partition(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	var calls int
+	v := incr.Var(g, []int{0, 1, 2, 3, 4, 5})
+	matched, rest := Partition(g, v, func(val int) bool {
+		calls++
+		return val%2 == 0
+	})
+	om := incr.MustObserve(g, matched)
+	or := incr.MustObserve(g, rest)
+
+	testutil.Equal(t, 1, matched.Node().Height())
+	testutil.Equal(t, 1, rest.Node().Height())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{0, 2, 4}, om.Value())
+	testutil.Equal(t, []int{1, 3, 5}, or.Value())
+	testutil.Equal(t, 6, calls)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, calls)
+
+	v.Set([]int{6, 7, 8})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{6, 8}, om.Value())
+	testutil.Equal(t, []int{7}, or.Value())
+	testutil.Equal(t, 9, calls)
+}
+
+func Test_Partition_parallel(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	var calls int
+	v := incr.Var(g, []int{0, 1, 2, 3, 4, 5})
+	matched, rest := Partition(g, v, func(val int) bool {
+		calls++
+		return val < 2
+	})
+	om := incr.MustObserve(g, matched)
+	or := incr.MustObserve(g, rest)
+
+	err := g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{0, 1}, om.Value())
+	testutil.Equal(t, []int{2, 3, 4, 5}, or.Value())
+	testutil.Equal(t, 6, calls)
+}
+
+func Test_Partition_singleObserved(t *testing.T) {
+	ctx := testContext()
+	g := incr.New()
+
+	v := incr.Var(g, []string{"a", "", "b"})
+	_, rest := Partition(g, v, func(val string) bool {
+		return val != ""
+	})
+	or := incr.MustObserve(g, rest)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{""}, or.Value())
+
+	v.Set([]string{"", "", "c"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"", ""}, or.Value())
+}
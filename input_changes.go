@@ -0,0 +1,36 @@
+package incr
+
+// inputChanges tracks the changes of an input of a stateful node, e.g. [Previous],
+// such that the node only advances its state when the input has changed since the
+// node last saw it, rather than each time the node is recomputed (which can also
+// happen because of [Always] nodes, [Graph.SetStale] or [Graph.RecomputeAll]).
+//
+// Nodes cut themselves off with [inputChanges.changed] if the input hasn't changed
+// since they last advanced, and check [inputChanges.advance] when they're stabilized.
+type inputChanges struct {
+	changedAt uint64
+	seen      bool
+}
+
+// changed returns if the input has changed since it was last seen.
+func (ic *inputChanges) changed(input INode) bool {
+	return !ic.seen || input.Node().changedAt != ic.changedAt
+}
+
+// advance marks the input of a given node as seen, returning if the node
+// should advance its state, that is, if the input has changed since it was
+// last seen.
+//
+// Recomputing the graph from scratch with [Graph.RecomputeAll] doesn't change
+// the inputs, so changes are only recorded such that the node keeps its state,
+// unless the node hasn't seen its input yet.
+func (ic *inputChanges) advance(n, input INode) bool {
+	// nodes are cut off if the input hasn't changed, so this only
+	// matters when [Graph.RecomputeAll] stabilizes nodes regardless.
+	if !ic.changed(input) {
+		return false
+	}
+	seen := ic.seen
+	ic.changedAt, ic.seen = input.Node().changedAt, true
+	return !seen || !GraphForNode(n).recomputingAll
+}
@@ -0,0 +1,134 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+// Join returns an incremental that joins two input maps by key, including only the keys
+// present in both maps, and applying a function to the left and right values for each key.
+//
+// Between stabilizations the function is only applied for keys whose left or right
+// value changed, or that were added to or removed from either input, though finding
+// the changed keys still compares every key. Values are compared with [reflect.DeepEqual].
+//
+// If none of the keys changed the node is cut off, that is, its children
+// are not recomputed.
+func Join[K comparable, A, B, C any](scope Scope, left Incr[map[K]A], right Incr[map[K]B], fn func(K, A, B) C) Incr[map[K]C] {
+	return WithinScope(scope, &joinIncr[K, A, B, C]{
+		n:     NewNode("join"),
+		left:  left,
+		right: right,
+		fn:    fn,
+	})
+}
+
+// LeftJoin is like [Join] but includes every key in the left map, passing the
+// zero value for the right value if the key is missing from the right map.
+func LeftJoin[K comparable, A, B, C any](scope Scope, left Incr[map[K]A], right Incr[map[K]B], fn func(K, A, B) C) Incr[map[K]C] {
+	return WithinScope(scope, &joinIncr[K, A, B, C]{
+		n:         NewNode("left_join"),
+		left:      left,
+		right:     right,
+		fn:        fn,
+		leftOuter: true,
+	})
+}
+
+var (
+	_ Incr[map[string]int] = (*joinIncr[string, int, int, int])(nil)
+	_ IParents             = (*joinIncr[string, int, int, int])(nil)
+	_ ICutoff              = (*joinIncr[string, int, int, int])(nil)
+	_ IStabilize           = (*joinIncr[string, int, int, int])(nil)
+	_ fmt.Stringer         = (*joinIncr[string, int, int, int])(nil)
+)
+
+type joinIncr[K comparable, A, B, C any] struct {
+	n         *Node
+	left      Incr[map[K]A]
+	right     Incr[map[K]B]
+	fn        func(K, A, B) C
+	leftOuter bool
+	lastLeft  map[K]A
+	lastRight map[K]B
+	// changed holds the keys found to have changed by the
+	// cutoff, to be recomputed by stabilize.
+	changed map[K]struct{}
+	val     map[K]C
+}
+
+func (j *joinIncr[K, A, B, C]) Parents() []INode {
+	return []INode{j.left, j.right}
+}
+
+func (j *joinIncr[K, A, B, C]) Node() *Node { return j.n }
+
+func (j *joinIncr[K, A, B, C]) Value() map[K]C { return j.val }
+
+func (j *joinIncr[K, A, B, C]) Cutoff(_ context.Context) (bool, error) {
+	if j.val == nil {
+		return false, nil
+	}
+	j.changed = j.changedKeys()
+	if len(j.changed) == 0 {
+		j.changed = nil
+		return true, nil
+	}
+	return false, nil
+}
+
+func (j *joinIncr[K, A, B, C]) Stabilize(_ context.Context) error {
+	changed := j.changed
+	if changed == nil {
+		changed = j.changedKeys()
+	}
+	j.changed = nil
+
+	newLeft, newRight := j.left.Value(), j.right.Value()
+	// the output is copied so that nodes holding on to the
+	// previous value don't see it change out from under them.
+	val := maps.Clone(j.val)
+	if val == nil {
+		val = make(map[K]C)
+	}
+	for k := range changed {
+		lv, hasLeft := newLeft[k]
+		rv, hasRight := newRight[k]
+		if hasLeft && (hasRight || j.leftOuter) {
+			val[k] = j.fn(k, lv, rv)
+		} else {
+			delete(val, k)
+		}
+	}
+	j.val = val
+	j.lastLeft = maps.Clone(newLeft)
+	j.lastRight = maps.Clone(newRight)
+	return nil
+}
+
+func (j *joinIncr[K, A, B, C]) String() string { return j.n.String() }
+
+// changedKeys returns the keys that were added, removed, or whose values
+// changed in either input since the node was last recomputed.
+func (j *joinIncr[K, A, B, C]) changedKeys() map[K]struct{} {
+	changed := make(map[K]struct{})
+	joinChangedKeys(changed, j.lastLeft, j.left.Value())
+	joinChangedKeys(changed, j.lastRight, j.right.Value())
+	return changed
+}
+
+func joinChangedKeys[K comparable, V any](changed map[K]struct{}, last, current map[K]V) {
+	for k := range last {
+		if _, ok := current[k]; !ok {
+			changed[k] = struct{}{}
+		}
+	}
+	for k, v := range current {
+		previous, ok := last[k]
+		if !ok || !reflect.DeepEqual(previous, v) {
+			changed[k] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// JoinStrings returns an incremental whose value is the values of a given list of
+// input incrementals joined with a given separator, like [strings.Join].
+//
+// The values of the inputs are cached such that when the node is recomputed only
+// the inputs that changed since it was last recomputed are read. Parts can be added and
+// removed after the node is created with [JoinStringsIncr.AddPart] and [JoinStringsIncr.RemovePart].
+func JoinStrings(scope Scope, sep string, parts ...Incr[string]) JoinStringsIncr {
+	return WithinScope(scope, &joinStringsIncr{
+		n:      NewNode("join_strings"),
+		sep:    sep,
+		inputs: newDynamicInputs[string, string](parts),
+	})
+}
+
+// JoinStringsIncr is the type of [JoinStrings] nodes.
+type JoinStringsIncr interface {
+	Incr[string]
+	// AddPart adds a part to the end of the list of parts.
+	AddPart(Incr[string]) error
+	// RemovePart removes the part with a given identifier.
+	//
+	// If none of the parts have the identifier [ErrInputNotFound] is returned.
+	RemovePart(Identifier) error
+}
+
+var (
+	_ JoinStringsIncr = (*joinStringsIncr)(nil)
+	_ IParents        = (*joinStringsIncr)(nil)
+	_ IStabilize      = (*joinStringsIncr)(nil)
+	_ fmt.Stringer    = (*joinStringsIncr)(nil)
+)
+
+type joinStringsIncr struct {
+	n   *Node
+	sep string
+	// inputs holds the parts, and for each the
+	// value of the part as of when it was last read.
+	inputs dynamicInputs[string, string]
+	// partsChanged is set when parts are added or removed.
+	partsChanged bool
+	value        string
+}
+
+func (js *joinStringsIncr) Parents() []INode {
+	return js.inputs.parents()
+}
+
+func (js *joinStringsIncr) AddPart(i Incr[string]) error {
+	js.partsChanged = true
+	return js.inputs.add(js, i)
+}
+
+func (js *joinStringsIncr) RemovePart(id Identifier) error {
+	count := len(js.inputs.inputs)
+	err := js.inputs.remove(js, id, nil)
+	js.partsChanged = js.partsChanged || len(js.inputs.inputs) != count
+	return err
+}
+
+func (js *joinStringsIncr) Node() *Node { return js.n }
+
+func (js *joinStringsIncr) Value() string { return js.value }
+
+func (js *joinStringsIncr) Stabilize(_ context.Context) error {
+	if js.inputs.stale(js) {
+		js.inputs.reset()
+		js.partsChanged = true
+	}
+	changed := js.partsChanged
+	js.partsChanged = false
+	js.inputs.eachChanged(func(value string, part *string, seen bool) {
+		changed = changed || !seen || value != *part
+		*part = value
+	})
+	if !changed {
+		return nil
+	}
+	length := len(js.sep) * max(len(js.inputs.inputs)-1, 0)
+	js.inputs.each(func(part string) {
+		length += len(part)
+	})
+	var sb strings.Builder
+	sb.Grow(length)
+	var index int
+	js.inputs.each(func(part string) {
+		if index > 0 {
+			sb.WriteString(js.sep)
+		}
+		sb.WriteString(part)
+		index++
+	})
+	js.value = sb.String()
+	return nil
+}
+
+func (js *joinStringsIncr) String() string { return js.n.String() }
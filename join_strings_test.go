@@ -0,0 +1,133 @@
+package incr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_JoinStrings(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	const count = 10
+	var reads int
+	vars := make([]VarIncr[string], count)
+	parts := make([]Incr[string], count)
+	expected := make([]string, count)
+	for x := 0; x < count; x++ {
+		expected[x] = fmt.Sprint(x)
+		vars[x] = Var(g, expected[x])
+		parts[x] = readCounter(g, vars[x], &reads)
+	}
+	js := JoinStrings(g, ", ", parts...)
+	o := MustObserve(g, js)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, strings.Join(expected, ", "), o.Value())
+	testutil.Equal(t, count, reads)
+
+	// a change to one part only reads that part.
+	vars[3].Set("three")
+	expected[3] = "three"
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, strings.Join(expected, ", "), o.Value())
+	testutil.Equal(t, count+1, reads)
+
+	vars[0].Set("zero")
+	vars[9].Set("nine")
+	expected[0], expected[9] = "zero", "nine"
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, strings.Join(expected, ", "), o.Value())
+	testutil.Equal(t, count+3, reads)
+}
+
+func Test_JoinStrings_addRemovePart(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	v2 := Var(g, "c")
+	js := JoinStrings(g, "-", v0)
+	o := MustObserve(g, js)
+	_ = MustObserve(g, v2)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+
+	err = js.AddPart(v1)
+	testutil.NoError(t, err)
+	err = js.AddPart(v2)
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a-b-c", o.Value())
+
+	err = js.RemovePart(v1.Node().ID())
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a-c", o.Value())
+
+	err = js.RemovePart(v0.Node().ID())
+	testutil.NoError(t, err)
+	err = js.RemovePart(v2.Node().ID())
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "", o.Value())
+	testutil.NoError(t, g.Validate())
+
+	err = js.RemovePart(v0.Node().ID())
+	testutil.Equal(t, ErrInputNotFound, err)
+}
+
+func Test_JoinStrings_RemovePart_beforeObserve(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	v2 := Var(g, "c")
+	js := JoinStrings(g, "-", v0, v1, v2)
+
+	err := js.RemovePart(v1.Node().ID())
+	testutil.NoError(t, err)
+
+	o := MustObserve(g, js)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a-c", o.Value())
+	testutil.Equal(t, false, g.Has(v1))
+}
+
+func Test_JoinStrings_reobserved(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	js := JoinStrings(g, ",", v0, v1)
+	o := MustObserve(g, js)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a,b", o.Value())
+
+	o.Unobserve(ctx)
+	v0.Set("c")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	o = MustObserve(g, js)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "c,b", o.Value())
+}
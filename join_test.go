@@ -0,0 +1,102 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Join(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	left := Var(g, map[string]int{"a": 1, "b": 2, "c": 3})
+	right := Var(g, map[string]int{"a": 10, "b": 20, "d": 40})
+
+	calls := make(map[string]int)
+	j := Join(g, left, right, func(k string, l, r int) int {
+		calls[k]++
+		return l + r
+	})
+	var downstream int
+	o := MustObserve(g, Map(g, j, func(vv map[string]int) map[string]int {
+		downstream++
+		return vv
+	}))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 11, "b": 22}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 1, "b": 1}, calls)
+	testutil.Equal(t, 1, downstream)
+
+	// updating a single key only applies the function for that key.
+	left.Set(map[string]int{"a": 1, "b": 5, "c": 3})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 11, "b": 25}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 1, "b": 2}, calls)
+	testutil.Equal(t, 2, downstream)
+
+	// keys appearing on one side join with the other side.
+	right.Set(map[string]int{"a": 10, "b": 20, "c": 30, "d": 40})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 11, "b": 25, "c": 33}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 1, "b": 2, "c": 1}, calls)
+
+	// setting the same values again doesn't mark the join changed.
+	left.Set(map[string]int{"a": 1, "b": 5, "c": 3})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 1, "b": 2, "c": 1}, calls)
+	testutil.Equal(t, 3, downstream)
+
+	// keys disappearing from either side are removed.
+	left.Set(map[string]int{"b": 5, "c": 3})
+	right.Set(map[string]int{"a": 10, "b": 20, "d": 40})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"b": 25}, o.Value())
+	testutil.Equal(t, map[string]int{"a": 1, "b": 2, "c": 1}, calls)
+	testutil.Equal(t, 4, downstream)
+}
+
+func Test_Join_empty(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	left := Var(g, map[string]int{"a": 1})
+	right := Var(g, map[string]int{})
+	o := MustObserve(g, Join(g, left, right, func(_ string, l, r int) int { return l + r }))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.NotNil(t, o.Value())
+	testutil.Equal(t, 0, len(o.Value()))
+
+	right.Set(map[string]int{"a": 2})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"a": 3}, o.Value())
+}
+
+func Test_LeftJoin(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	left := Var(g, map[string]int{"a": 1, "b": 2})
+	right := Var(g, map[string]string{"a": "x"})
+	o := MustObserve(g, LeftJoin(g, left, right, func(k string, l int, r string) string {
+		return k + r
+	}))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]string{"a": "ax", "b": "b"}, o.Value())
+
+	right.Set(map[string]string{"b": "y", "c": "z"})
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]string{"a": "a", "b": "by"}, o.Value())
+}
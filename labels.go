@@ -0,0 +1,98 @@
+package incr
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// FindByLabel returns the nodes the graph observes, that is the nodes that are
+// necessary (not including observers or sentinels), with a given label (see [Node.SetLabel]).
+//
+// Nodes are returned in the order they were created in.
+func (graph *Graph) FindByLabel(label string) (output []INode) {
+	graph.nodesMu.Lock()
+	defer graph.nodesMu.Unlock()
+	output = copySlice(graph.labels[label])
+	slices.SortFunc(output, func(a, b INode) int {
+		return cmp.Compare(a.Node().order, b.Node().order)
+	})
+	return
+}
+
+// relabel sets the label of a node, updating the label
+// index if the node is observed by the graph.
+func (graph *Graph) relabel(n *Node, label string) {
+	graph.nodesMu.Lock()
+	defer graph.nodesMu.Unlock()
+	tracked, ok := graph.nodes[n.id]
+	if ok {
+		graph.unindexLabelUnsafe(tracked, n.label)
+	}
+	n.label = label
+	if ok {
+		graph.indexLabelUnsafe(tracked, label)
+	}
+}
+
+// checkUniqueLabels returns an error if the given node, or any of the nodes that
+// would become necessary by observing it, has the same label as a node the graph
+// already observes, or as another one of those nodes.
+func (graph *Graph) checkUniqueLabels(input INode) error {
+	graph.nodesMu.Lock()
+	defer graph.nodesMu.Unlock()
+
+	seen := make(map[Identifier]struct{})
+	labels := make(map[string]INode)
+	pending := new(queue[INode])
+	pending.push(input)
+	for pending.len() > 0 {
+		n, _ := pending.pop()
+		nn := n.Node()
+		if _, ok := seen[nn.id]; ok {
+			continue
+		}
+		seen[nn.id] = struct{}{}
+		if _, ok := graph.nodes[nn.id]; ok {
+			continue
+		}
+		if nn.label != "" {
+			if existing := graph.labels[nn.label]; len(existing) > 0 {
+				return fmt.Errorf("observe; node %v has the same label as observed node %v", n, existing[0])
+			}
+			if other, ok := labels[nn.label]; ok {
+				return fmt.Errorf("observe; node %v has the same label as node %v", n, other)
+			}
+			labels[nn.label] = n
+		}
+		// the node may not have been initialized yet, so
+		// we can't rely on its node metadata for its parents.
+		if typed, ok := n.(IParents); ok {
+			for _, p := range typed.Parents() {
+				pending.push(p)
+			}
+		}
+	}
+	return nil
+}
+
+func (graph *Graph) indexLabelUnsafe(n INode, label string) {
+	if label == "" {
+		return
+	}
+	graph.labels[label] = append(graph.labels[label], n)
+}
+
+func (graph *Graph) unindexLabelUnsafe(n INode, label string) {
+	if label == "" {
+		return
+	}
+	remaining := slices.DeleteFunc(graph.labels[label], func(other INode) bool {
+		return SameNode(n, other)
+	})
+	if len(remaining) == 0 {
+		delete(graph.labels, label)
+		return
+	}
+	graph.labels[label] = remaining
+}
@@ -0,0 +1,155 @@
+package incr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_FindByLabel(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	v.Node().SetLabel("v")
+	m0 := Map(g, v, ident)
+	m0.Node().SetLabel("m")
+	m1 := Map(g, v, ident)
+	m1.Node().SetLabel("m")
+	testutil.Empty(t, g.FindByLabel("v"))
+
+	o0 := MustObserve(g, m0)
+	_ = MustObserve(g, m1)
+
+	found := g.FindByLabel("v")
+	testutil.Equal(t, 1, len(found))
+	testutil.Equal(t, true, SameNode(v, found[0]))
+
+	found = g.FindByLabel("m")
+	testutil.Equal(t, 2, len(found))
+	testutil.Equal(t, true, SameNode(m0, found[0]))
+	testutil.Equal(t, true, SameNode(m1, found[1]))
+	testutil.Empty(t, g.FindByLabel("not-a-label"))
+	testutil.Empty(t, g.FindByLabel(""))
+
+	o0.Unobserve(ctx)
+	found = g.FindByLabel("m")
+	testutil.Equal(t, 1, len(found))
+	testutil.Equal(t, true, SameNode(m1, found[0]))
+}
+
+func Test_Graph_FindByLabel_relabel(t *testing.T) {
+	g := New()
+
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	_ = MustObserve(g, m0)
+	testutil.Empty(t, g.FindByLabel("m0"))
+
+	m0.Node().SetLabel("m0")
+	testutil.Equal(t, 1, len(g.FindByLabel("m0")))
+
+	m0.Node().SetLabel("renamed")
+	testutil.Empty(t, g.FindByLabel("m0"))
+	testutil.Equal(t, 1, len(g.FindByLabel("renamed")))
+	testutil.Equal(t, "renamed", m0.Node().Label())
+
+	m0.Node().SetLabel("")
+	testutil.Empty(t, g.FindByLabel("renamed"))
+	testutil.Equal(t, "", m0.Node().Label())
+}
+
+func Test_Graph_FindByLabel_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	bv := Var(g, "a")
+	b := Bind(g, bv, func(bs Scope, which string) Incr[string] {
+		i0 := Return(bs, which+"-value")
+		i0.Node().SetLabel("i0")
+		m0 := Map(bs, i0, ident)
+		m0.Node().SetLabel("m0")
+		return m0
+	})
+	o := MustObserve(g, b)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a-value", o.Value())
+
+	found := g.FindByLabel("i0")
+	testutil.Equal(t, 1, len(found))
+	testutil.Equal(t, "a-value", found[0].(Incr[string]).Value())
+	found = g.FindByLabel("m0")
+	testutil.Equal(t, 1, len(found))
+	mA := found[0]
+
+	bv.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b-value", o.Value())
+
+	// the nodes from the previous right-hand side are no longer observed.
+	found = g.FindByLabel("m0")
+	testutil.Equal(t, 1, len(found))
+	testutil.Equal(t, false, SameNode(mA, found[0]))
+	testutil.Equal(t, "b-value", found[0].(Incr[string]).Value())
+	testutil.Equal(t, 1, len(g.FindByLabel("i0")))
+}
+
+func Test_Graph_uniqueLabels(t *testing.T) {
+	g := New(OptGraphUniqueLabels(true))
+
+	v := Var(g, "hello")
+	v.Node().SetLabel("v")
+	m0 := Map(g, v, ident)
+	m0.Node().SetLabel("m")
+	_ = MustObserve(g, m0)
+
+	// observing the same nodes again is fine.
+	_, err := Observe(g, m0)
+	testutil.NoError(t, err)
+
+	m1 := Map(g, v, ident)
+	m1.Node().SetLabel("m")
+	_, err = Observe(g, m1)
+	testutil.Error(t, err)
+	testutil.Matches(t, fmt.Sprintf(".*%s.*%s.*", m1.Node().ID().Short(), m0.Node().ID().Short()), err.Error())
+	testutil.Equal(t, false, g.Has(m1))
+
+	m1.Node().SetLabel("m1")
+	_, err = Observe(g, m1)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, len(g.FindByLabel("m")))
+	testutil.Equal(t, 1, len(g.FindByLabel("m1")))
+}
+
+func Test_Graph_uniqueLabels_withinObserved(t *testing.T) {
+	g := New(OptGraphUniqueLabels(true))
+
+	v0 := Var(g, "hello")
+	v0.Node().SetLabel("v")
+	v1 := Var(g, "world")
+	v1.Node().SetLabel("v")
+	m := Map2(g, v0, v1, concat)
+
+	_, err := Observe(g, m)
+	testutil.Error(t, err)
+	testutil.Matches(t, "has the same label as node", err.Error())
+	testutil.Empty(t, g.FindByLabel("v"))
+}
+
+func Test_Graph_uniqueLabels_disabled(t *testing.T) {
+	g := New()
+
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	m0.Node().SetLabel("m")
+	m1 := Map(g, v, ident)
+	m1.Node().SetLabel("m")
+	_ = MustObserve(g, m0)
+	_, err := Observe(g, m1)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, len(g.FindByLabel("m")))
+}
@@ -0,0 +1,69 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// Lag returns an incremental whose value is the value the input incremental
+// had a given number of changes ago, or the initial value if the input hasn't
+// changed that many times yet; [Previous] is the same as a lag of one.
+//
+// The [Lag] node holds on to the last n values of the input, such that a lag less
+// than one is treated as one. Like [Previous], the node sits directly above the
+// input and only advances when the input changes.
+func Lag[A any](scope Scope, input Incr[A], n int, initial A) Incr[A] {
+	return WithinScope(scope, &lagIncr[A]{
+		n:       NewNode("lag"),
+		input:   input,
+		lag:     max(n, 1),
+		value:   initial,
+		history: new(queue[A]),
+	})
+}
+
+var (
+	_ Incr[string] = (*lagIncr[string])(nil)
+	_ IParents     = (*lagIncr[string])(nil)
+	_ ICutoff      = (*lagIncr[string])(nil)
+	_ IStabilize   = (*lagIncr[string])(nil)
+	_ fmt.Stringer = (*lagIncr[string])(nil)
+)
+
+type lagIncr[A any] struct {
+	n     *Node
+	input Incr[A]
+	lag   int
+	value A
+	// history holds the most recent values of the
+	// input, up to the lag, oldest first.
+	history *queue[A]
+	changes inputChanges
+}
+
+func (l *lagIncr[A]) Parents() []INode {
+	return []INode{l.input}
+}
+
+func (l *lagIncr[A]) Node() *Node { return l.n }
+
+func (l *lagIncr[A]) Value() A { return l.value }
+
+func (l *lagIncr[A]) Cutoff(_ context.Context) (bool, error) {
+	return !l.changes.changed(l.input), nil
+}
+
+func (l *lagIncr[A]) Stabilize(_ context.Context) error {
+	if !l.changes.advance(l, l.input) {
+		return nil
+	}
+	l.history.push(l.input.Value())
+	if l.history.len() > l.lag {
+		l.value, _ = l.history.pop()
+	}
+	return nil
+}
+
+func (l *lagIncr[A]) String() string {
+	return l.n.String()
+}
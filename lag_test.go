@@ -0,0 +1,94 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Lag(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 1)
+	l := Lag(g, v, 2, 0)
+	ol := MustObserve(g, l)
+
+	testutil.Matches(t, `lag\[(.*)\]`, l.(*lagIncr[int]).String())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, ol.Value())
+	testutil.Equal(t, v.Node().Height()+1, l.Node().Height())
+
+	var lagged []int
+	for _, value := range []int{2, 3, 4, 5} {
+		v.Set(value)
+		err = g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		lagged = append(lagged, ol.Value())
+	}
+	testutil.Equal(t, []int{0, 1, 2, 3}, lagged)
+
+	// the lag only advances when the input changes.
+	g.SetStale(l)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, ol.Value())
+}
+
+func Test_Lag_one(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	m := Map(g, v, ident)
+	l := Lag(g, m, 0, "")
+	p := Previous(g, m, "")
+	ol := MustObserve(g, l)
+	op := MustObserve(g, p)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, m.Node().Height()+1, l.Node().Height())
+
+	for _, value := range []string{"b", "c", "d"} {
+		v.Set(value)
+		err = g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, op.Value(), ol.Value(), "a lag less than one should be the same as previous")
+	}
+	testutil.Equal(t, "c", ol.Value())
+}
+
+func Test_Lag_clone(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 1)
+	l := Lag(g, v, 2, 0)
+	ol := MustObserve(g, l)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	for _, value := range []int{2, 3} {
+		v.Set(value)
+		err = g.Stabilize(ctx)
+		testutil.NoError(t, err)
+	}
+	testutil.Equal(t, 1, ol.Value())
+
+	clone, clones, err := g.Clone()
+	testutil.NoError(t, err)
+	cv := clones[v.Node().ID()].(VarIncr[int])
+	cl := clones[l.Node().ID()].(Incr[int])
+
+	cv.Set(4)
+	err = clone.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, cl.Value())
+	testutil.Equal(t, 1, ol.Value(), "the copy should not share its history with the original")
+
+	v.Set(5)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, ol.Value())
+	testutil.Equal(t, 2, cl.Value())
+}
@@ -0,0 +1,105 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// Lazy returns an incremental that defers calling a given function to construct
+// its input subgraph until the node becomes necessary, that is, until it is observed
+// directly or through one of its children.
+//
+// The constructor is passed the scope the [Lazy] node was created in, and the
+// incremental it returns is linked as the input of the [Lazy] node, whose value
+// passes through the value of that input.
+//
+// By default the constructed subgraph is cached and reused if the node is unobserved
+// and observed again; use [LazyWithRebuild] to call the constructor again each time
+// the node becomes necessary.
+//
+// This is useful for graphs with many optional branches, where constructing
+// each branch up front would be wasteful.
+func Lazy[A any](scope Scope, construct func(Scope) Incr[A], opts ...LazyOption) Incr[A] {
+	var options LazyOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	l := WithinScope(scope, &lazyIncr[A]{
+		n:         NewNode("lazy"),
+		scope:     scope,
+		construct: construct,
+		rebuild:   options.Rebuild,
+	})
+	l.n.onObservedHandlers = append(l.n.onObservedHandlers, l.onObserved)
+	l.n.onUnobservedHandlers = append(l.n.onUnobservedHandlers, l.onUnobserved)
+	return l
+}
+
+// LazyOption mutates [LazyOptions].
+type LazyOption func(*LazyOptions)
+
+// LazyWithRebuild sets if a [Lazy] node should discard its constructed
+// subgraph when it is no longer necessary, calling the constructor again
+// the next time it becomes necessary.
+func LazyWithRebuild(rebuild bool) func(*LazyOptions) {
+	return func(lo *LazyOptions) {
+		lo.Rebuild = rebuild
+	}
+}
+
+// LazyOptions are options for [Lazy] nodes.
+type LazyOptions struct {
+	Rebuild bool
+}
+
+var (
+	_ Incr[string] = (*lazyIncr[string])(nil)
+	_ IParents     = (*lazyIncr[string])(nil)
+	_ IStabilize   = (*lazyIncr[string])(nil)
+	_ fmt.Stringer = (*lazyIncr[string])(nil)
+)
+
+type lazyIncr[A any] struct {
+	n         *Node
+	scope     Scope
+	construct func(Scope) Incr[A]
+	rebuild   bool
+	input     Incr[A]
+	value     A
+}
+
+func (l *lazyIncr[A]) Parents() []INode {
+	if l.input == nil {
+		return nil
+	}
+	return []INode{l.input}
+}
+
+func (l *lazyIncr[A]) Node() *Node { return l.n }
+
+func (l *lazyIncr[A]) Value() A { return l.value }
+
+func (l *lazyIncr[A]) Stabilize(_ context.Context) error {
+	if l.input != nil {
+		l.value = l.input.Value()
+	}
+	return nil
+}
+
+func (l *lazyIncr[A]) String() string { return l.n.String() }
+
+// onObserved constructs the input subgraph if it hasn't been constructed
+// yet; it is called before the graph links the node's parents.
+func (l *lazyIncr[A]) onObserved(_ context.Context) {
+	if l.input == nil {
+		l.input = l.construct(l.scope)
+	}
+}
+
+func (l *lazyIncr[A]) onUnobserved(_ context.Context) {
+	if l.rebuild {
+		l.input = nil
+		var zero A
+		l.value = zero
+	}
+}
@@ -0,0 +1,114 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// newLazySelect returns a node that passes through the value of the incremental
+// returned by a select function, linking only the selected incremental into
+// the graph, and relinking when the selector incremental changes.
+//
+// It is the implementation of [MapIfLazy] and [Switch].
+func newLazySelect[A any](scope Scope, kind string, selector INode, selectFn func() (Incr[A], error)) Incr[A] {
+	main := &lazySelectIncr[A]{
+		n: NewNode(kind),
+	}
+	main.change = WithinScope(scope, &lazySelectChangeIncr[A]{
+		n:        NewNode(kind + "-change"),
+		main:     main,
+		selector: selector,
+		selectFn: selectFn,
+	})
+	main.parents = []INode{main.change}
+	return WithinScope(scope, main)
+}
+
+var (
+	_ Incr[string] = (*lazySelectIncr[string])(nil)
+	_ IParents     = (*lazySelectIncr[string])(nil)
+	_ IStale       = (*lazySelectIncr[string])(nil)
+	_ IStabilize   = (*lazySelectIncr[string])(nil)
+	_ fmt.Stringer = (*lazySelectIncr[string])(nil)
+)
+
+type lazySelectIncr[A any] struct {
+	n        *Node
+	change   *lazySelectChangeIncr[A]
+	selected Incr[A]
+	parents  []INode
+	err      error
+	value    A
+}
+
+func (ls *lazySelectIncr[A]) Parents() []INode {
+	return ls.parents
+}
+
+func (ls *lazySelectIncr[A]) Stale() bool {
+	return ls.n.recomputedAt == 0 || ls.n.isStaleInRespectToParent()
+}
+
+func (ls *lazySelectIncr[A]) Node() *Node { return ls.n }
+
+func (ls *lazySelectIncr[A]) Value() A { return ls.value }
+
+func (ls *lazySelectIncr[A]) Stabilize(_ context.Context) error {
+	if ls.err != nil {
+		return ls.err
+	}
+	if ls.selected != nil {
+		ls.value = ls.selected.Value()
+	}
+	return nil
+}
+
+func (ls *lazySelectIncr[A]) String() string { return ls.n.String() }
+
+var (
+	_ INode        = (*lazySelectChangeIncr[string])(nil)
+	_ IParents     = (*lazySelectChangeIncr[string])(nil)
+	_ IStabilize   = (*lazySelectChangeIncr[string])(nil)
+	_ fmt.Stringer = (*lazySelectChangeIncr[string])(nil)
+)
+
+// lazySelectChangeIncr is the node that relinks the main node
+// to the selected incremental when the selector changes.
+//
+// If the select function returns an error, the main node is unlinked from
+// the previously selected incremental and returns the error when it stabilizes,
+// such that the error handlers of the main node are called.
+type lazySelectChangeIncr[A any] struct {
+	n        *Node
+	main     *lazySelectIncr[A]
+	selector INode
+	selectFn func() (Incr[A], error)
+}
+
+func (lc *lazySelectChangeIncr[A]) Parents() []INode {
+	return []INode{lc.selector}
+}
+
+func (lc *lazySelectChangeIncr[A]) Node() *Node { return lc.n }
+
+func (lc *lazySelectChangeIncr[A]) Stabilize(_ context.Context) error {
+	oldSelected := lc.main.selected
+	newSelected, err := lc.selectFn()
+	lc.main.err = err
+	lc.main.selected = newSelected
+	if newSelected != nil {
+		lc.main.parents = []INode{lc, newSelected}
+	} else {
+		lc.main.parents = []INode{lc}
+	}
+	var oldParent, newParent INode
+	if oldSelected != nil {
+		oldParent = oldSelected
+	}
+	if newSelected != nil {
+		newParent = newSelected
+	}
+	return GraphForNode(lc).changeParent(lc.main, oldParent, newParent)
+}
+
+func (lc *lazySelectChangeIncr[A]) String() string { return lc.n.String() }
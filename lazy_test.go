@@ -0,0 +1,129 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Lazy(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "foo")
+	var constructs int
+	l := Lazy(g, func(bs Scope) Incr[string] {
+		constructs++
+		return Map(bs, v, func(vv string) string { return vv + "-bar" })
+	})
+	testutil.Equal(t, "lazy", l.Node().Kind())
+	testutil.Equal(t, 0, constructs)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, constructs)
+
+	o := MustObserve(g, l)
+	testutil.Equal(t, 1, constructs)
+	testutil.Equal(t, true, g.Has(v))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo-bar", o.Value())
+	testutil.Equal(t, 1, constructs)
+
+	v.Set("moo")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "moo-bar", o.Value())
+	testutil.Equal(t, 1, constructs)
+
+	o.Unobserve(ctx)
+	testutil.Equal(t, false, g.Has(l))
+	testutil.Equal(t, false, g.Has(v))
+
+	v.Set("loo")
+	o = MustObserve(g, l)
+	testutil.Equal(t, 1, constructs, "the constructed subgraph should be cached")
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "loo-bar", o.Value())
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_Lazy_rebuild(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "foo")
+	var constructs int
+	l := Lazy(g, func(bs Scope) Incr[string] {
+		constructs++
+		return Map(bs, v, func(vv string) string { return vv + "-bar" })
+	}, LazyWithRebuild(true))
+
+	o := MustObserve(g, l)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo-bar", o.Value())
+	testutil.Equal(t, 1, constructs)
+
+	o.Unobserve(ctx)
+	testutil.Equal(t, "", l.Value())
+
+	o = MustObserve(g, l)
+	testutil.Equal(t, 2, constructs)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo-bar", o.Value())
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_Lazy_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "foo")
+	var constructs []string
+	newLazy := func(label string) Incr[string] {
+		return Lazy(g, func(bs Scope) Incr[string] {
+			constructs = append(constructs, label)
+			return Map(bs, v, func(vv string) string { return vv + "-" + label })
+		})
+	}
+	la := newLazy("a")
+	lb := newLazy("b")
+	lc := newLazy("c")
+
+	sw := Var(g, "a")
+	b := Bind(g, sw, func(_ Scope, swv string) Incr[string] {
+		switch swv {
+		case "a":
+			return la
+		case "b":
+			return lb
+		default:
+			return lc
+		}
+	})
+	o := MustObserve(g, b)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo-a", o.Value())
+	testutil.Equal(t, []string{"a"}, constructs)
+
+	sw.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo-b", o.Value())
+	testutil.Equal(t, []string{"a", "b"}, constructs)
+
+	sw.Set("a")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo-a", o.Value())
+	testutil.Equal(t, []string{"a", "b"}, constructs)
+	testutil.Equal(t, false, g.Has(lc))
+}
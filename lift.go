@@ -0,0 +1,106 @@
+package incr
+
+import "context"
+
+// Lift returns a constructor for [Map] nodes that apply a given function,
+// letting you define a reusable incremental operator once, e.g.
+//
+//	double := incr.Lift(func(v int) int { return v * 2 })
+//	doubled := double(g, input)
+//
+// Each call to the constructor returns a new node as if you called [Map] directly.
+func Lift[A, B any](fn func(A) B, opts ...LiftOption) func(Scope, Incr[A]) Incr[B] {
+	options := liftOptions(opts)
+	return func(scope Scope, a Incr[A]) Incr[B] {
+		return liftApply(options, Map(scope, a, fn))
+	}
+}
+
+// LiftContext returns a constructor for [MapContext] nodes that apply a given function.
+func LiftContext[A, B any](fn func(context.Context, A) (B, error), opts ...LiftOption) func(Scope, Incr[A]) Incr[B] {
+	options := liftOptions(opts)
+	return func(scope Scope, a Incr[A]) Incr[B] {
+		return liftApply(options, MapContext(scope, a, fn))
+	}
+}
+
+// Lift2 returns a constructor for [Map2] nodes that apply a given function,
+// letting you define a reusable incremental operator once, e.g.
+//
+//	add := incr.Lift2(func(a, b int) int { return a + b })
+//	sum := add(g, x, y)
+//
+// Each call to the constructor returns a new node as if you called [Map2] directly.
+func Lift2[A, B, C any](fn func(A, B) C, opts ...LiftOption) func(Scope, Incr[A], Incr[B]) Incr[C] {
+	options := liftOptions(opts)
+	return func(scope Scope, a Incr[A], b Incr[B]) Incr[C] {
+		return liftApply(options, Map2(scope, a, b, fn))
+	}
+}
+
+// Lift2Context returns a constructor for [Map2Context] nodes that apply a given function.
+func Lift2Context[A, B, C any](fn func(context.Context, A, B) (C, error), opts ...LiftOption) func(Scope, Incr[A], Incr[B]) Incr[C] {
+	options := liftOptions(opts)
+	return func(scope Scope, a Incr[A], b Incr[B]) Incr[C] {
+		return liftApply(options, Map2Context(scope, a, b, fn))
+	}
+}
+
+// Lift3 returns a constructor for [Map3] nodes that apply a given function.
+//
+// Each call to the constructor returns a new node as if you called [Map3] directly.
+func Lift3[A, B, C, D any](fn func(A, B, C) D, opts ...LiftOption) func(Scope, Incr[A], Incr[B], Incr[C]) Incr[D] {
+	options := liftOptions(opts)
+	return func(scope Scope, a Incr[A], b Incr[B], c Incr[C]) Incr[D] {
+		return liftApply(options, Map3(scope, a, b, c, fn))
+	}
+}
+
+// Lift3Context returns a constructor for [Map3Context] nodes that apply a given function.
+func Lift3Context[A, B, C, D any](fn func(context.Context, A, B, C) (D, error), opts ...LiftOption) func(Scope, Incr[A], Incr[B], Incr[C]) Incr[D] {
+	options := liftOptions(opts)
+	return func(scope Scope, a Incr[A], b Incr[B], c Incr[C]) Incr[D] {
+		return liftApply(options, Map3Context(scope, a, b, c, fn))
+	}
+}
+
+// LiftOption mutates [LiftOptions].
+type LiftOption func(*LiftOptions)
+
+// LiftWithKind sets the kind of the nodes returned by a lifted constructor,
+// which is otherwise the kind of the underlying map node, e.g. "map2".
+func LiftWithKind(kind string) func(*LiftOptions) {
+	return func(lo *LiftOptions) {
+		lo.Kind = kind
+	}
+}
+
+// LiftWithLabel sets the label of the nodes returned by a lifted constructor.
+func LiftWithLabel(label string) func(*LiftOptions) {
+	return func(lo *LiftOptions) {
+		lo.Label = label
+	}
+}
+
+// LiftOptions are options for lifted constructors, e.g. [Lift2].
+type LiftOptions struct {
+	Kind  string
+	Label string
+}
+
+func liftOptions(opts []LiftOption) (options LiftOptions) {
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return
+}
+
+func liftApply[A any](options LiftOptions, i Incr[A]) Incr[A] {
+	if options.Kind != "" {
+		i.Node().SetKind(options.Kind)
+	}
+	if options.Label != "" {
+		i.Node().SetLabel(options.Label)
+	}
+	return i
+}
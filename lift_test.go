@@ -0,0 +1,159 @@
+package incr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Lift(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	double := Lift(func(v int) int { return v * 2 })
+	v := Var(g, 2)
+	d := double(g, v)
+	testutil.Equal(t, "map", d.Node().Kind())
+	o := MustObserve(g, d)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 4, o.Value())
+
+	v.Set(3)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, o.Value())
+}
+
+func Test_Lift2(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	add := Lift2(func(a, b int) int { return a + b })
+	x := Var(g, 1)
+	y := Var(g, 2)
+	lifted := add(g, x, y)
+	direct := Map2(g, x, y, func(a, b int) int { return a + b })
+	testutil.Equal(t, direct.Node().Kind(), lifted.Node().Kind())
+	testutil.Equal(t, "", lifted.Node().Label())
+
+	// each call returns a new node.
+	other := add(g, x, y)
+	testutil.NotEqual(t, lifted.Node().ID(), other.Node().ID())
+
+	lo := MustObserve(g, lifted)
+	do := MustObserve(g, direct)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, lo.Value())
+	testutil.Equal(t, do.Value(), lo.Value())
+	testutil.Equal(t, direct.Node().height, lifted.Node().height)
+	testutil.Equal(t, 2, len(lifted.Node().Parents()))
+
+	y.Set(5)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, lo.Value())
+	testutil.Equal(t, do.Value(), lo.Value())
+}
+
+func Test_Lift3(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	join := Lift3(func(a, b, c string) string { return a + b + c }, LiftWithKind("join3"), LiftWithLabel("join"))
+	j := join(g, Return(g, "a"), Return(g, "b"), Return(g, "c"))
+	testutil.Equal(t, "join3", j.Node().Kind())
+	testutil.Equal(t, "join", j.Node().Label())
+	o := MustObserve(g, j)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "abc", o.Value())
+}
+
+func Test_LiftContext(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	parse := LiftContext(func(ictx context.Context, v string) (int, error) {
+		testutil.BlueDye(ictx, t)
+		var out int
+		_, err := fmt.Sscanf(v, "%d", &out)
+		return out, err
+	}, LiftWithLabel("parse"))
+	v := Var(g, "12")
+	p := parse(g, v)
+	testutil.Equal(t, "map", p.Node().Kind())
+	testutil.Equal(t, "parse", p.Node().Label())
+	var handledErr error
+	p.Node().OnError(func(_ context.Context, err error) {
+		handledErr = err
+	})
+	o := MustObserve(g, p)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 12, o.Value())
+
+	v.Set("not-a-number")
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.NotNil(t, handledErr)
+	var nodeErr *NodeError
+	testutil.Equal(t, true, errors.As(err, &nodeErr))
+	testutil.Equal(t, "parse", nodeErr.Label)
+}
+
+func Test_Lift2Context_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	div := Lift2Context(func(_ context.Context, a, b int) (int, error) {
+		if b == 0 {
+			return 0, fmt.Errorf("divide by zero")
+		}
+		return a / b, nil
+	})
+	x := Var(g, 6)
+	y := Var(g, 2)
+	lifted := div(g, x, y)
+	direct := Map2Context(g, x, y, func(_ context.Context, a, b int) (int, error) {
+		if b == 0 {
+			return 0, fmt.Errorf("divide by zero")
+		}
+		return a / b, nil
+	})
+	lo := MustObserve(g, lifted)
+	do := MustObserve(g, direct)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, lo.Value())
+	testutil.Equal(t, do.Value(), lo.Value())
+
+	y.Set(0)
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, "divide by zero", errors.Unwrap(err).Error())
+	testutil.Equal(t, 3, lo.Value())
+}
+
+func Test_Lift3Context_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	fail := Lift3Context(func(_ context.Context, a, b, c int) (int, error) {
+		return 0, fmt.Errorf("this is just a test")
+	})
+	_ = MustObserve(g, fail(g, Return(g, 1), Return(g, 2), Return(g, 3)))
+
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, "this is just a test", errors.Unwrap(err).Error())
+}
@@ -0,0 +1,54 @@
+package incr
+
+// Link associates a child node with one or more parent nodes after the child
+// has been constructed, updating the heights of the child (and its descendants)
+// and scheduling the child for recomputation.
+//
+// This is useful for custom nodes that change their inputs over time without
+// having to reimplement the internals of [Bind]. The child node's [IParents]
+// implementation (if any) must also return the new parents, so that the graph
+// can re-establish the links if the child becomes unnecessary and later necessary again.
+//
+// The graph maintains the invariant that a child's height is always greater than
+// the heights of each of its parents; linking a parent with a height greater than
+// or equal to the child's will increase the height of the child and its descendants,
+// and will return an error if the link would create a cycle.
+//
+// If the child is not necessary, i.e. it is not observed, Link does nothing, and the
+// parents will be linked from the child's [IParents] when it becomes necessary.
+func Link(child INode, parents ...INode) error {
+	if child == nil {
+		return errChildNil
+	}
+	if !child.Node().isNecessary() {
+		return nil
+	}
+	graph := GraphForNode(child)
+	for _, parent := range parents {
+		if err := graph.addChild(child, parent); err != nil {
+			return err
+		}
+	}
+	// the child has new inputs, so it should be recomputed
+	// even if the new parents haven't changed recently.
+	graph.recomputeHeap.addIfNotPresent(child, RecomputeReasonLinked)
+	return nil
+}
+
+// Unlink removes the association between a child node and a parent node,
+// scheduling the child for recomputation.
+//
+// If the parent is no longer necessary as a result, it (and any of its parents
+// that are no longer necessary) will be removed from the graph.
+//
+// Note that the heights of the child and its descendants are not reduced.
+func Unlink(child, parent INode) {
+	if child == nil || parent == nil {
+		return
+	}
+	graph := GraphForNode(child)
+	graph.removeParent(child, parent)
+	if child.Node().isNecessary() {
+		graph.recomputeHeap.addIfNotPresent(child, RecomputeReasonLinked)
+	}
+}
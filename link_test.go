@@ -0,0 +1,127 @@
+package incr
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func newLinkTestIncr(scope Scope, inputs ...Incr[string]) *linkTestIncr {
+	return WithinScope(scope, &linkTestIncr{
+		n:      NewNode("link_test"),
+		inputs: inputs,
+	})
+}
+
+type linkTestIncr struct {
+	n      *Node
+	inputs []Incr[string]
+	value  string
+}
+
+func (lt *linkTestIncr) Parents() (out []INode) {
+	for _, i := range lt.inputs {
+		out = append(out, i)
+	}
+	return
+}
+func (lt *linkTestIncr) Node() *Node   { return lt.n }
+func (lt *linkTestIncr) Value() string { return lt.value }
+func (lt *linkTestIncr) Stabilize(_ context.Context) error {
+	values := make([]string, 0, len(lt.inputs))
+	for _, i := range lt.inputs {
+		values = append(values, i.Value())
+	}
+	lt.value = strings.Join(values, ",")
+	return nil
+}
+
+func (lt *linkTestIncr) removeInput(id Identifier) {
+	lt.inputs, _ = remove(lt.inputs, id)
+}
+
+func Test_Link(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	lt := newLinkTestIncr(g, v0)
+	o := MustObserve(g, lt)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+	testutil.Equal(t, 1, lt.Node().Height())
+
+	v1 := Var(g, "b")
+	m0 := Map(g, v1, ident)
+	m1 := Map(g, m0, ident)
+	lt.inputs = append(lt.inputs, m1)
+	err = Link(lt, m1)
+	testutil.NoError(t, err)
+
+	testutil.Equal(t, true, g.Has(m1))
+	testutil.Equal(t, true, g.Has(m0))
+	testutil.Equal(t, 2, m1.Node().Height())
+	testutil.Equal(t, 3, lt.Node().Height())
+	testutil.Equal(t, true, hasKey(m1.Node().Children(), lt.Node().ID()))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a,b", o.Value())
+
+	v1.Set("c")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a,c", o.Value())
+
+	lt.removeInput(m1.Node().ID())
+	Unlink(lt, m1)
+	testutil.Equal(t, false, g.Has(m1))
+	testutil.Equal(t, false, g.Has(m0))
+	testutil.Equal(t, false, g.Has(v1))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+}
+
+func Test_Link_notNecessary(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	lt := newLinkTestIncr(g, v0)
+
+	v1 := Var(g, "b")
+	lt.inputs = append(lt.inputs, v1)
+	err := Link(lt, v1)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.Has(v1))
+
+	o := MustObserve(g, lt)
+	testutil.Equal(t, true, g.Has(v1))
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a,b", o.Value())
+}
+
+func Test_Link_cycle(t *testing.T) {
+	g := New()
+
+	v0 := Var(g, "a")
+	lt := newLinkTestIncr(g, v0)
+	m := Map(g, lt, ident)
+	_ = MustObserve(g, m)
+
+	lt.inputs = append(lt.inputs, m)
+	err := Link(lt, m)
+	testutil.Error(t, err)
+}
+
+func Test_Link_nil(t *testing.T) {
+	testutil.Error(t, Link(nil))
+	Unlink(nil, nil)
+}
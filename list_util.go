@@ -11,3 +11,9 @@ func remove[A INode](nodes []A, id Identifier) (output []A, removed A) {
 	}
 	return
 }
+
+func copySlice[A any](values []A) (output []A) {
+	output = make([]A, len(values))
+	copy(output, values)
+	return
+}
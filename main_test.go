@@ -278,3 +278,40 @@ func (n *ccutoffAlwaysIncr[A, B]) Stabilize(ctx context.Context) (err error) {
 func (n *ccutoffAlwaysIncr[A, B]) String() string {
 	return n.n.String()
 }
+
+// readCounter returns an incremental whose value is the value of a given
+// input, counting the times its value is read in a given counter.
+func readCounter[A any](scope Scope, input Incr[A], reads *int) Incr[A] {
+	return WithinScope(scope, &readCounterIncr[A]{
+		n:     NewNode("read-counter"),
+		input: input,
+		reads: reads,
+	})
+}
+
+type readCounterIncr[A any] struct {
+	n     *Node
+	input Incr[A]
+	reads *int
+	value A
+}
+
+func (n *readCounterIncr[A]) Parents() []INode {
+	return []INode{n.input}
+}
+
+func (n *readCounterIncr[A]) Node() *Node { return n.n }
+
+func (n *readCounterIncr[A]) Value() A {
+	*n.reads++
+	return n.value
+}
+
+func (n *readCounterIncr[A]) Stabilize(_ context.Context) error {
+	n.value = n.input.Value()
+	return nil
+}
+
+func (n *readCounterIncr[A]) String() string {
+	return n.n.String()
+}
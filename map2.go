@@ -7,6 +7,9 @@ import (
 
 // Map2 applies a function to a given input incremental and returns
 // a new incremental of the output type of that function.
+//
+// The function is not called if the node is scheduled for recomputation
+// but none of its inputs have changed since it was last recomputed.
 func Map2[A, B, C any](scope Scope, a Incr[A], b Incr[B], fn func(A, B) C) Incr[C] {
 	return Map2Context(scope, a, b, func(_ context.Context, a A, b B) (C, error) {
 		return fn(a, b), nil
@@ -27,10 +30,11 @@ func Map2Context[A, B, C any](scope Scope, a Incr[A], b Incr[B], fn func(context
 }
 
 var (
-	_ Incr[string] = (*map2Incr[int, int, string])(nil)
-	_ INode        = (*map2Incr[int, int, string])(nil)
-	_ IStabilize   = (*map2Incr[int, int, string])(nil)
-	_ fmt.Stringer = (*map2Incr[int, int, string])(nil)
+	_ Incr[string]     = (*map2Incr[int, int, string])(nil)
+	_ INode            = (*map2Incr[int, int, string])(nil)
+	_ IStabilize       = (*map2Incr[int, int, string])(nil)
+	_ iSkipIfUnchanged = (*map2Incr[int, int, string])(nil)
+	_ fmt.Stringer     = (*map2Incr[int, int, string])(nil)
 )
 
 type map2Incr[A, B, C any] struct {
@@ -51,6 +55,8 @@ func (m2n *map2Incr[A, B, C]) Node() *Node { return m2n.n }
 
 func (m2n *map2Incr[A, B, C]) Value() C { return m2n.val }
 
+func (m2n *map2Incr[A, B, C]) skipIfUnchanged() {}
+
 func (m2n *map2Incr[A, B, C]) Stabilize(ctx context.Context) (err error) {
 	var val C
 	val, err = m2n.fn(ctx, m2n.a.Value(), m2n.b.Value())
@@ -7,6 +7,9 @@ import (
 
 // Map3 applies a function to given input incrementals and returns
 // a new incremental of the output type of that function.
+//
+// The function is not called if the node is scheduled for recomputation
+// but none of its inputs have changed since it was last recomputed.
 func Map3[A, B, C, D any](scope Scope, a Incr[A], b Incr[B], c Incr[C], fn func(A, B, C) D) Incr[D] {
 	return Map3Context(scope, a, b, c, func(_ context.Context, av A, bv B, cv C) (D, error) {
 		return fn(av, bv, cv), nil
@@ -28,10 +31,11 @@ func Map3Context[A, B, C, D any](scope Scope, a Incr[A], b Incr[B], c Incr[C], f
 }
 
 var (
-	_ Incr[string] = (*map3Incr[int, int, int, string])(nil)
-	_ INode        = (*map3Incr[int, int, int, string])(nil)
-	_ IStabilize   = (*map3Incr[int, int, int, string])(nil)
-	_ fmt.Stringer = (*map3Incr[int, int, int, string])(nil)
+	_ Incr[string]     = (*map3Incr[int, int, int, string])(nil)
+	_ INode            = (*map3Incr[int, int, int, string])(nil)
+	_ IStabilize       = (*map3Incr[int, int, int, string])(nil)
+	_ iSkipIfUnchanged = (*map3Incr[int, int, int, string])(nil)
+	_ fmt.Stringer     = (*map3Incr[int, int, int, string])(nil)
 )
 
 type map3Incr[A, B, C, D any] struct {
@@ -52,6 +56,8 @@ func (mn *map3Incr[A, B, C, D]) Node() *Node { return mn.n }
 
 func (mn *map3Incr[A, B, C, D]) Value() D { return mn.val }
 
+func (mn *map3Incr[A, B, C, D]) skipIfUnchanged() {}
+
 func (mn *map3Incr[A, B, C, D]) Stabilize(ctx context.Context) (err error) {
 	var val D
 	val, err = mn.fn(ctx, mn.a.Value(), mn.b.Value(), mn.c.Value())
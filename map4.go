@@ -7,6 +7,9 @@ import (
 
 // Map4 applies a function to given input incrementals and returns
 // a new incremental of the output type of that function.
+//
+// The function is not called if the node is scheduled for recomputation
+// but none of its inputs have changed since it was last recomputed.
 func Map4[A, B, C, D, E any](scope Scope, a Incr[A], b Incr[B], c Incr[C], d Incr[D], fn func(A, B, C, D) E) Incr[E] {
 	return Map4Context(scope, a, b, c, d, func(_ context.Context, av A, bv B, cv C, dv D) (E, error) {
 		return fn(av, bv, cv, dv), nil
@@ -29,10 +32,11 @@ func Map4Context[A, B, C, D, E any](scope Scope, a Incr[A], b Incr[B], c Incr[C]
 }
 
 var (
-	_ Incr[string] = (*map4Incr[int, int, int, int, string])(nil)
-	_ INode        = (*map4Incr[int, int, int, int, string])(nil)
-	_ IStabilize   = (*map4Incr[int, int, int, int, string])(nil)
-	_ fmt.Stringer = (*map4Incr[int, int, int, int, string])(nil)
+	_ Incr[string]     = (*map4Incr[int, int, int, int, string])(nil)
+	_ INode            = (*map4Incr[int, int, int, int, string])(nil)
+	_ IStabilize       = (*map4Incr[int, int, int, int, string])(nil)
+	_ iSkipIfUnchanged = (*map4Incr[int, int, int, int, string])(nil)
+	_ fmt.Stringer     = (*map4Incr[int, int, int, int, string])(nil)
 )
 
 type map4Incr[A, B, C, D, E any] struct {
@@ -54,6 +58,8 @@ func (mn *map4Incr[A, B, C, D, E]) Node() *Node { return mn.n }
 
 func (mn *map4Incr[A, B, C, D, E]) Value() E { return mn.val }
 
+func (mn *map4Incr[A, B, C, D, E]) skipIfUnchanged() {}
+
 func (mn *map4Incr[A, B, C, D, E]) Stabilize(ctx context.Context) (err error) {
 	var val E
 	val, err = mn.fn(ctx, mn.a.Value(), mn.b.Value(), mn.c.Value(), mn.d.Value())
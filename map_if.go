@@ -10,42 +10,64 @@ import (
 //
 // Specifically, we term this [MapIf] because the nodes are all
 // linked in the graph, but the value changes during stabilization.
+//
+// Because both inputs are linked, both are necessary and are recomputed
+// when they change even though only one is used; use [MapIfLazy] if the
+// inputs are expensive to compute.
 func MapIf[A any](scope Scope, a, b Incr[A], p Incr[bool]) Incr[A] {
-	return WithinScope(scope, &mapIfIncr[A]{
-		n: NewNode("map_if"),
-		a: a,
-		b: b,
-		p: p,
+	return MapIfContext(scope, a, b, p, func(_ context.Context, pv bool) (bool, error) {
+		return pv, nil
+	})
+}
+
+// MapIfContext returns an incremental that yields one of two values
+// based on the boolean condition returned by a function applied
+// to the value of a third incremental.
+//
+// If the function returns an error, the stabilization is
+// stopped and the error is returned.
+func MapIfContext[A, B any](scope Scope, a, b Incr[A], p Incr[B], fn func(context.Context, B) (bool, error)) Incr[A] {
+	return WithinScope(scope, &mapIfIncr[A, B]{
+		n:  NewNode("map_if"),
+		a:  a,
+		b:  b,
+		p:  p,
+		fn: fn,
 	})
 }
 
 var (
-	_ Incr[string] = (*mapIfIncr[string])(nil)
-	_ INode        = (*mapIfIncr[string])(nil)
-	_ IStabilize   = (*mapIfIncr[string])(nil)
-	_ fmt.Stringer = (*mapIfIncr[string])(nil)
+	_ Incr[string] = (*mapIfIncr[string, bool])(nil)
+	_ INode        = (*mapIfIncr[string, bool])(nil)
+	_ IStabilize   = (*mapIfIncr[string, bool])(nil)
+	_ fmt.Stringer = (*mapIfIncr[string, bool])(nil)
 )
 
-type mapIfIncr[A any] struct {
+type mapIfIncr[A, B any] struct {
 	n     *Node
 	a     Incr[A]
 	b     Incr[A]
-	p     Incr[bool]
+	p     Incr[B]
+	fn    func(context.Context, B) (bool, error)
 	value A
 }
 
-func (mi *mapIfIncr[T]) Parents() []INode {
+func (mi *mapIfIncr[A, B]) Parents() []INode {
 	return []INode{mi.a, mi.b, mi.p}
 }
 
-func (mi *mapIfIncr[A]) Node() *Node { return mi.n }
+func (mi *mapIfIncr[A, B]) Node() *Node { return mi.n }
 
-func (mi *mapIfIncr[A]) Value() A {
+func (mi *mapIfIncr[A, B]) Value() A {
 	return mi.value
 }
 
-func (mi *mapIfIncr[A]) Stabilize(ctx context.Context) error {
-	if mi.p.Value() {
+func (mi *mapIfIncr[A, B]) Stabilize(ctx context.Context) error {
+	pv, err := mi.fn(ctx, mi.p.Value())
+	if err != nil {
+		return err
+	}
+	if pv {
 		mi.value = mi.a.Value()
 	} else {
 		mi.value = mi.b.Value()
@@ -53,4 +75,4 @@ func (mi *mapIfIncr[A]) Stabilize(ctx context.Context) error {
 	return nil
 }
 
-func (mi *mapIfIncr[A]) String() string { return mi.n.String() }
+func (mi *mapIfIncr[A, B]) String() string { return mi.n.String() }
@@ -0,0 +1,21 @@
+package incr
+
+// MapIfLazy returns an incremental that yields the value of one of two
+// incrementals based on the value of a boolean condition incremental.
+//
+// Unlike [MapIf], only the selected incremental is linked into the graph,
+// and as a result only the selected incremental is necessary and recomputed.
+// When the condition changes the node is relinked to the other incremental,
+// which becomes necessary, and the previously selected incremental becomes
+// unnecessary (unless it is necessary for other reasons).
+//
+// This is similar to a [Bind] that returns one of two incrementals created
+// outside the bind, but without creating a bind scope.
+func MapIfLazy[A any](scope Scope, whenTrue, whenFalse Incr[A], cond Incr[bool]) Incr[A] {
+	return newLazySelect(scope, "map_if_lazy", cond, func() (Incr[A], error) {
+		if cond.Value() {
+			return whenTrue, nil
+		}
+		return whenFalse, nil
+	})
+}
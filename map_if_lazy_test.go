@@ -0,0 +1,145 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_MapIfLazy(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	cond := Var(g, false)
+	vt := Var(g, "true")
+	vf := Var(g, "false")
+	var trueRecomputes, falseRecomputes int
+	whenTrue := Map(g, vt, func(v string) string {
+		trueRecomputes++
+		return v
+	})
+	whenFalse := Map(g, vf, func(v string) string {
+		falseRecomputes++
+		return v
+	})
+	mi := MapIfLazy(g, whenTrue, whenFalse, cond)
+	o := MustObserve(g, mi)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "false", o.Value())
+	testutil.Equal(t, 0, trueRecomputes)
+	testutil.Equal(t, 1, falseRecomputes)
+	testutil.Equal(t, false, g.Has(whenTrue))
+	testutil.Equal(t, true, g.Has(whenFalse))
+
+	vt.Set("true-2")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "false", o.Value())
+	testutil.Equal(t, 0, trueRecomputes)
+
+	cond.Set(true)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "true-2", o.Value())
+	testutil.Equal(t, 1, trueRecomputes)
+	testutil.Equal(t, 1, falseRecomputes)
+	testutil.Equal(t, true, g.Has(whenTrue))
+	testutil.Equal(t, false, g.Has(whenFalse))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, trueRecomputes)
+
+	vf.Set("false-2")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "true-2", o.Value())
+	testutil.Equal(t, 1, falseRecomputes)
+
+	cond.Set(false)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "false-2", o.Value())
+	testutil.Equal(t, 2, falseRecomputes)
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_MapIfLazy_heights(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	cond := Var(g, false)
+	deep := Map(g, Map(g, Map(g, Var(g, "deep"), ident), ident), ident)
+	shallow := Return(g, "shallow")
+	o := MustObserve(g, MapIfLazy(g, deep, shallow, cond))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "shallow", o.Value())
+
+	cond.Set(true)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "deep", o.Value())
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_MapIfLazy_unobserve(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	cond := Var(g, true)
+	whenTrue := Map(g, Var(g, "a"), ident)
+	whenFalse := Map(g, Var(g, "b"), ident)
+	mi := MapIfLazy(g, whenTrue, whenFalse, cond)
+	o := MustObserve(g, mi)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+
+	o.Unobserve(ctx)
+	testutil.Equal(t, false, g.Has(whenTrue))
+	testutil.Equal(t, false, g.Has(cond))
+
+	err = o.Reobserve(ctx)
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+	testutil.Equal(t, true, g.Has(whenTrue))
+	testutil.Equal(t, false, g.Has(whenFalse))
+}
+
+func Test_MapIfContext(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	p := Var(g, 1)
+	mi := MapIfContext(g, Return(g, "even"), Return(g, "odd"), p, func(_ context.Context, pv int) (bool, error) {
+		if pv < 0 {
+			return false, fmt.Errorf("negative")
+		}
+		return pv%2 == 0, nil
+	})
+	testutil.Equal(t, "map_if", mi.Node().Kind())
+	o := MustObserve(g, mi)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "odd", o.Value())
+
+	p.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "even", o.Value())
+
+	p.Set(-1)
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, "even", o.Value())
+}
@@ -3,6 +3,7 @@ package incr
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 )
 
 // MapN applies a function to given list of input incrementals and returns
@@ -33,6 +34,8 @@ type MapNContextFunc[A, B any] func(context.Context, ...A) (B, error)
 type MapNIncr[A, B any] interface {
 	Incr[B]
 	AddInput(Incr[A]) error
+	// RemoveInput removes an input by the input's identifier, returning
+	// [ErrInputNotFound] if none of the inputs have the identifier.
 	RemoveInput(Identifier) error
 }
 
@@ -72,13 +75,33 @@ func (mn *mapNIncr[A, B]) AddInput(i Incr[A]) error {
 func (mn *mapNIncr[A, B]) RemoveInput(id Identifier) error {
 	var removed Incr[A]
 	mn.inputs, removed = remove(mn.inputs, id)
-	if removed != nil {
-		mn.Node().removeParent(id)
-		removed.Node().removeChild(mn.n.id)
-		GraphForNode(mn).SetStale(mn)
-		GraphForNode(mn).checkIfUnnecessary(removed)
+	if removed == nil {
+		return ErrInputNotFound
+	}
+	return removeInput(mn, removed)
+}
+
+// removeInput unlinks a removed input from a node that takes a variable
+// number of inputs, reducing the node's height if the removed input was
+// the node's tallest input.
+//
+// Nodes that aren't part of the graph yet aren't linked to their inputs,
+// so there is nothing to unlink.
+func removeInput(child, removed INode) error {
+	if child.Node().height == HeightUnset {
 		return nil
 	}
+	graph := GraphForNode(child)
+	wasTallest := removed.Node().height+1 == child.Node().height
+	child.Node().removeParent(removed.Node().id)
+	removed.Node().removeChild(child.Node().id)
+	graph.setStale(child, RecomputeReasonLinked)
+	graph.checkIfUnnecessary(removed)
+	// heights can only be reduced between stabilizations, during
+	// stabilization the node keeps its (still valid) height.
+	if wasTallest && atomic.LoadInt32(&graph.status) == StatusNotStabilizing {
+		return graph.RecomputeHeights(child)
+	}
 	return nil
 }
 
@@ -103,6 +103,24 @@ func Test_MapN_RemoveInput(t *testing.T) {
 	testutil.Equal(t, false, hasR1)
 }
 
+func Test_MapN_RemoveInput_beforeObserve(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 1)
+	v1 := Var(g, 2)
+	mn := MapN(g, sum, v0, v1)
+
+	err := mn.RemoveInput(v0.Node().ID())
+	testutil.NoError(t, err)
+
+	o := MustObserve(g, mn)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, o.Value())
+	testutil.Equal(t, false, g.Has(v0))
+}
+
 func Test_MapN_RemoveInput_onlyInput(t *testing.T) {
 	ctx := testContext()
 	g := New()
@@ -157,5 +175,47 @@ func Test_MapN_RemoveInput_heightUpdates(t *testing.T) {
 	err = g.Stabilize(ctx)
 	testutil.NoError(t, err)
 	testutil.Equal(t, 1, om.Value())
-	testutil.Equal(t, 2, mn.Node().height, "the height should stay the same as strictly it shouldn't get smaller, but staying higher is fine")
+	testutil.Equal(t, 1, mn.Node().height, "the height should be reduced as the tallest input was removed")
+	testutil.NoError(t, g.SanityCheck())
+}
+
+func Test_MapN_RemoveInput_threeInputs(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 1)
+	v1 := Var(g, 2)
+	m2 := Map(g, Map(g, Var(g, 3), ident), ident)
+	mn := MapN(g, sum, v0, v1, m2)
+	om := MustObserve(g, mn)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, om.Value())
+	testutil.Equal(t, 3, mn.Node().height)
+
+	err = mn.RemoveInput(v1.Node().ID())
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, mn.Node().height)
+	testutil.Equal(t, true, g.recomputeHeap.has(mn))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 4, om.Value())
+
+	err = mn.RemoveInput(m2.Node().ID())
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, mn.Node().height)
+	testutil.Equal(t, 1, mn.Node().heightInRecomputeHeap)
+	testutil.Equal(t, false, g.Has(m2))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, om.Value())
+
+	// removing an input that isn't an input returns an error.
+	err = mn.RemoveInput(v1.Node().ID())
+	testutil.Equal(t, ErrInputNotFound, err)
+	testutil.Equal(t, 1, len(mn.Node().parents))
+	testutil.NoError(t, g.Validate())
 }
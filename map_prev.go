@@ -0,0 +1,72 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// MapPrev applies a function to a given input incremental and the
+// previous output of the node, returning a new incremental of the
+// output type of that function.
+//
+// The previous output is the initial value for the first recomputation,
+// and otherwise is the value the function returned for the last recomputation.
+func MapPrev[A, B any](scope Scope, a Incr[A], initial B, fn func(B, A) B) Incr[B] {
+	return MapPrevContext(scope, a, initial, func(_ context.Context, prev B, v A) (B, error) {
+		return fn(prev, v), nil
+	})
+}
+
+// MapPrevContext applies a function to a given input incremental and the
+// previous output of the node, returning a new incremental of the output type
+// of that function but is context aware and can also return an error, aborting stabilization.
+//
+// If the function returns an error the previous output is left as is.
+func MapPrevContext[A, B any](scope Scope, a Incr[A], initial B, fn func(context.Context, B, A) (B, error)) Incr[B] {
+	return WithinScope(scope, &mapPrevIncr[A, B]{
+		n:       NewNode("map_prev"),
+		a:       a,
+		fn:      fn,
+		val:     initial,
+		parents: []INode{a},
+	})
+}
+
+var (
+	_ Incr[string] = (*mapPrevIncr[int, string])(nil)
+	_ INode        = (*mapPrevIncr[int, string])(nil)
+	_ IStabilize   = (*mapPrevIncr[int, string])(nil)
+	_ fmt.Stringer = (*mapPrevIncr[int, string])(nil)
+)
+
+type mapPrevIncr[A, B any] struct {
+	n       *Node
+	a       Incr[A]
+	fn      func(context.Context, B, A) (B, error)
+	val     B
+	parents []INode
+}
+
+func (mn *mapPrevIncr[A, B]) Parents() []INode {
+	return mn.parents
+}
+
+func (mn *mapPrevIncr[A, B]) Node() *Node {
+	return mn.n
+}
+
+func (mn *mapPrevIncr[A, B]) Value() B { return mn.val }
+
+func (mn *mapPrevIncr[A, B]) Stabilize(ctx context.Context) (err error) {
+	var val B
+	val, err = mn.fn(ctx, mn.val, mn.a.Value())
+	if err != nil {
+		return
+	}
+	mn.val = val
+	return nil
+}
+
+func (mn *mapPrevIncr[A, B]) String() string {
+	return mn.n.String()
+}
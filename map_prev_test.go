@@ -0,0 +1,69 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_MapPrev(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	m := MapPrev(g, v, ">", func(prev, cur string) string {
+		return prev + cur
+	})
+	o := MustObserve(g, m)
+
+	testutil.Matches(t, `map_prev\[(.*)\]`, m.(*mapPrevIncr[string, string]).String())
+	testutil.Equal(t, ">", o.Value())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, ">a", o.Value())
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, ">ab", o.Value())
+
+	// the previous value persists across stabilizations
+	// where the input doesn't change.
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, ">ab", o.Value())
+
+	v.Set("c")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, ">abc", o.Value())
+}
+
+func Test_MapPrevContext_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 1)
+	m := MapPrevContext(g, v, 0, func(_ context.Context, prev, cur int) (int, error) {
+		if cur < 0 {
+			return 0, fmt.Errorf("this is only a test")
+		}
+		return prev + cur, nil
+	})
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, o.Value())
+
+	v.Set(-1)
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, 1, o.Value())
+
+	v.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, o.Value())
+}
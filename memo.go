@@ -0,0 +1,110 @@
+package incr
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+)
+
+// Memo applies a function to a given input incremental like [Map], but
+// caches the results of the function keyed by the input value so that
+// if the input returns to a value it has seen before the function is
+// not called again.
+//
+// The cache holds up to size results, evicting the least recently used
+// results as new results are added; a size of zero or less means results
+// are never evicted. The function should be pure, that is, it should
+// return the same output for the same input.
+func Memo[A comparable, B any](scope Scope, i Incr[A], fn func(A) B, size int) MemoIncr[B] {
+	return WithinScope(scope, &memoIncr[A, B]{
+		n:       NewNode("memo"),
+		i:       i,
+		fn:      fn,
+		size:    size,
+		lookup:  make(map[A]*list.Element),
+		lru:     list.New(),
+		parents: []INode{i},
+	})
+}
+
+// MemoIncr is an incremental that caches results of a function.
+type MemoIncr[B any] interface {
+	Incr[B]
+	// Stats returns the cache statistics for the node.
+	Stats() MemoStats
+}
+
+// MemoStats are cache statistics for a [Memo] node.
+type MemoStats struct {
+	// Hits is the number of times a result was found in the cache.
+	Hits uint64
+	// Misses is the number of times the function was called because
+	// a result was not in the cache.
+	Misses uint64
+	// Evictions is the number of results evicted from the cache.
+	Evictions uint64
+	// Len is the number of results currently in the cache.
+	Len int
+}
+
+var (
+	_ MemoIncr[string] = (*memoIncr[int, string])(nil)
+	_ IParents         = (*memoIncr[int, string])(nil)
+	_ IStabilize       = (*memoIncr[int, string])(nil)
+	_ fmt.Stringer     = (*memoIncr[int, string])(nil)
+)
+
+type memoIncr[A comparable, B any] struct {
+	n       *Node
+	i       Incr[A]
+	fn      func(A) B
+	size    int
+	lookup  map[A]*list.Element
+	lru     *list.List
+	stats   MemoStats
+	val     B
+	parents []INode
+}
+
+type memoEntry[A comparable, B any] struct {
+	key   A
+	value B
+}
+
+func (m *memoIncr[A, B]) Parents() []INode {
+	return m.parents
+}
+
+func (m *memoIncr[A, B]) Node() *Node { return m.n }
+
+func (m *memoIncr[A, B]) Value() B { return m.val }
+
+func (m *memoIncr[A, B]) Stats() MemoStats {
+	stats := m.stats
+	stats.Len = m.lru.Len()
+	return stats
+}
+
+func (m *memoIncr[A, B]) Stabilize(_ context.Context) error {
+	key := m.i.Value()
+	if element, ok := m.lookup[key]; ok {
+		m.stats.Hits++
+		m.lru.MoveToFront(element)
+		m.val = element.Value.(*memoEntry[A, B]).value
+		return nil
+	}
+	m.stats.Misses++
+	m.val = m.fn(key)
+	m.lookup[key] = m.lru.PushFront(&memoEntry[A, B]{key: key, value: m.val})
+	if m.size > 0 && m.lru.Len() > m.size {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.lookup, oldest.Value.(*memoEntry[A, B]).key)
+		m.stats.Evictions++
+	}
+	return nil
+}
+
+func (m *memoIncr[A, B]) String() string {
+	return m.n.String()
+}
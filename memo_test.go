@@ -0,0 +1,115 @@
+package incr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Memo(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	var calls []string
+	m := Memo(g, v, func(vv string) string {
+		calls = append(calls, vv)
+		return vv + "!"
+	}, 2)
+	var childRecomputes int
+	c := Map(g, m, func(vv string) string {
+		childRecomputes++
+		return vv
+	})
+	o := MustObserve(g, c)
+
+	testutil.Matches(t, `memo\[(.*)\]`, fmt.Sprint(m))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a!", o.Value())
+	testutil.Equal(t, MemoStats{Misses: 1, Len: 1}, m.Stats())
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b!", o.Value())
+	testutil.Equal(t, MemoStats{Misses: 2, Len: 2}, m.Stats())
+
+	// flapping back to a cached value doesn't call the function
+	// but should still propagate the changed value.
+	v.Set("a")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a!", o.Value())
+	testutil.Equal(t, 3, childRecomputes)
+	testutil.Equal(t, MemoStats{Hits: 1, Misses: 2, Len: 2}, m.Stats())
+	testutil.Equal(t, []string{"a", "b"}, calls)
+}
+
+func Test_Memo_eviction(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 1)
+	var calls []int
+	m := Memo(g, v, func(vv int) int {
+		calls = append(calls, vv)
+		return vv * 10
+	}, 2)
+	o := MustObserve(g, m)
+
+	for _, value := range []int{1, 2, 1, 3} {
+		v.Set(value)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, value*10, o.Value())
+	}
+	// 2 was the least recently used when 3 was added because 1
+	// was used after it, so 2 should have been evicted.
+	testutil.Equal(t, []int{1, 2, 3}, calls)
+	testutil.Equal(t, MemoStats{Hits: 1, Misses: 3, Evictions: 1, Len: 2}, m.Stats())
+
+	v.Set(1)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10, o.Value())
+	testutil.Equal(t, []int{1, 2, 3}, calls)
+
+	// 2 recurs after it was evicted, so we should call the function again
+	// and evict 3 which is now the least recently used.
+	v.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 20, o.Value())
+	testutil.Equal(t, []int{1, 2, 3, 2}, calls)
+	testutil.Equal(t, MemoStats{Hits: 2, Misses: 4, Evictions: 2, Len: 2}, m.Stats())
+
+	v.Set(3)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 30, o.Value())
+	testutil.Equal(t, []int{1, 2, 3, 2, 3}, calls)
+}
+
+func Test_Memo_unbounded(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 0)
+	var calls int
+	m := Memo(g, v, func(vv int) int {
+		calls++
+		return vv
+	}, 0)
+	_ = MustObserve(g, m)
+
+	for x := 0; x < 2; x++ {
+		for value := 0; value < 10; value++ {
+			v.Set(value)
+			err := g.Stabilize(ctx)
+			testutil.NoError(t, err)
+		}
+	}
+	testutil.Equal(t, 10, calls)
+	testutil.Equal(t, uint64(0), m.Stats().Evictions)
+	testutil.Equal(t, 10, m.Stats().Len)
+}
@@ -0,0 +1,78 @@
+package incr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Mermaid formats the graph reachable from the given root nodes in the
+// mermaid flowchart format so that you can embed the graph in markdown documents.
+//
+// The graph is traversed from the roots through each node's children (and observers),
+// and each node is labeled with its kind, short identifier and label (if set).
+//
+// The output is written to the writer as the graph is traversed.
+func Mermaid(wr io.Writer, roots ...INode) (err error) {
+	// NOTE(wc): similar to [Dot], we panic in the `writef`
+	// helper if there is a write error and recover here.
+	defer func() {
+		err, _ = recover().(error)
+	}()
+
+	writef := func(format string, args ...any) {
+		if _, writeErr := fmt.Fprintf(wr, format+"\n", args...); writeErr != nil {
+			panic(writeErr)
+		}
+	}
+
+	writef("flowchart TD")
+
+	nodeLabels := make(map[Identifier]string)
+	q := new(queue[INode])
+	nodeLabel := func(n INode) (label string, isNew bool) {
+		if label, ok := nodeLabels[n.Node().id]; ok {
+			return label, false
+		}
+		label = fmt.Sprintf("n%d", len(nodeLabels)+1)
+		nodeLabels[n.Node().id] = label
+		writef("\t%s[\"%s\"]", label, escapeForMermaid(mermaidNodeLabel(n)))
+		q.push(n)
+		return label, true
+	}
+	for _, r := range roots {
+		if r == nil {
+			continue
+		}
+		_, _ = nodeLabel(r)
+	}
+	for q.len() > 0 {
+		n, _ := q.pop()
+		label := nodeLabels[n.Node().id]
+		for _, c := range n.Node().children {
+			childLabel, _ := nodeLabel(c)
+			writef("\t%s --> %s", label, childLabel)
+		}
+		for _, o := range n.Node().observers {
+			observerLabel, _ := nodeLabel(o)
+			writef("\t%s --> %s", label, observerLabel)
+		}
+	}
+	return
+}
+
+func mermaidNodeLabel(n INode) string {
+	if n.Node().label != "" {
+		return fmt.Sprintf("%s:%s %s", n.Node().kind, n.Node().id.Short(), n.Node().label)
+	}
+	return fmt.Sprintf("%s:%s", n.Node().kind, n.Node().id.Short())
+}
+
+// escapeForMermaid replaces characters that would otherwise
+// terminate a mermaid node label with their entity codes.
+func escapeForMermaid(str string) string {
+	return strings.NewReplacer(
+		`"`, "#quot;",
+		"\n", " ",
+	).Replace(str)
+}
@@ -0,0 +1,70 @@
+package incr
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Mermaid(t *testing.T) {
+	g := New()
+
+	v0 := Var(g, "foo")
+	v0.Node().SetLabel("v0")
+	v1 := Var(g, "bar")
+	v1.Node().SetLabel(`v1 "quoted"`)
+	m2 := Map2(g, v0, v1, concat)
+	m3 := Map2(g, m2, Return(g, "const"), concat)
+	m3.Node().SetLabel("m3")
+	o := MustObserve(g, m3)
+
+	buffer := new(bytes.Buffer)
+	err := Mermaid(buffer, v0, v1)
+	testutil.NoError(t, err)
+
+	output := buffer.String()
+	testutil.Equal(t, true, strings.HasPrefix(output, "flowchart TD\n"))
+	testutil.Equal(t, true, strings.Contains(output, fmt.Sprintf(`n1["var:%s v0"]`, v0.Node().id.Short())))
+	testutil.Equal(t, true, strings.Contains(output, fmt.Sprintf(`n2["var:%s v1 #quot;quoted#quot;"]`, v1.Node().id.Short())))
+	testutil.Equal(t, true, strings.Contains(output, fmt.Sprintf(`n3["map2:%s"]`, m2.Node().id.Short())))
+	testutil.Equal(t, true, strings.Contains(output, fmt.Sprintf(`["map2:%s m3"]`, m3.Node().id.Short())))
+	testutil.Equal(t, true, strings.Contains(output, fmt.Sprintf(`["observer:%s"]`, o.Node().id.Short())))
+	testutil.Equal(t, true, strings.Contains(output, "n1 --> n3"))
+	testutil.Equal(t, true, strings.Contains(output, "n2 --> n3"))
+	testutil.Equal(t, 1, strings.Count(output, m2.Node().id.Short()), "nodes should only be declared once")
+}
+
+func Test_Mermaid_deterministicIdentifiers(t *testing.T) {
+	render := func() string {
+		g := New(OptGraphIdentifierProvider(NewCounterIdentifierProvider()))
+		v0 := Var(g, "foo")
+		v1 := Var(g, "bar")
+		m2 := Map2(g, v0, v1, concat)
+		_ = MustObserve(g, m2)
+		buffer := new(bytes.Buffer)
+		_ = Mermaid(buffer, v0, v1)
+		return buffer.String()
+	}
+	testutil.Equal(t, render(), render())
+}
+
+type errorWriter struct{}
+
+func (errorWriter) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("this is just a test")
+}
+
+func Test_Mermaid_writeError(t *testing.T) {
+	g := New()
+	v0 := Var(g, "foo")
+	_ = MustObserve(g, v0)
+
+	err := Mermaid(errorWriter{}, v0)
+	testutil.Error(t, err)
+
+	err = Dot(errorWriter{}, g)
+	testutil.Error(t, err)
+}
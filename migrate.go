@@ -0,0 +1,80 @@
+package incr
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// MigrateNode moves a given node, and the nodes it takes as inputs transitively that were
+// created in the same graph, from one graph to another.
+//
+// The nodes to be migrated must not be necessary in the graph they're migrated from, that is, any
+// observers of the nodes must be unobserved first, and the nodes must have been created within the
+// graph's top scope (i.e. not within a [Bind] function).
+//
+// The migrated nodes have their recompute state reset so that they are recomputed when they
+// become necessary in the graph they're migrated to, e.g. when they're observed with [Observe].
+func MigrateNode(from, to *Graph, n INode) error {
+	if n == nil {
+		return errChildNil
+	}
+	if atomic.LoadInt32(&from.status) != StatusNotStabilizing || atomic.LoadInt32(&to.status) != StatusNotStabilizing {
+		return ErrAlreadyStabilizing
+	}
+	if GraphForNode(n) != from {
+		return fmt.Errorf("migrate node; node %v does not belong to graph %s", n, from.id.Short())
+	}
+
+	nodes := migrationNodes(from, n)
+	for _, mn := range nodes {
+		if mn.Node().createdIn != Scope(from) {
+			return fmt.Errorf("migrate node; node %v was created within a bind scope, cannot continue", mn)
+		}
+		if mn.Node().isNecessary() || from.Has(mn) {
+			return fmt.Errorf("migrate node; node %v is necessary in graph %s, unobserve it first", mn, from.id.Short())
+		}
+	}
+	migrateNodes(nodes, to)
+	return nil
+}
+
+// migrationNodes returns a given node and the nodes it takes as
+// inputs transitively that belong to a given graph.
+func migrationNodes(from *Graph, n INode) []INode {
+	nodes := []INode{n}
+	seen := map[Identifier]struct{}{
+		n.Node().id: {},
+	}
+	q := new(queue[INode])
+	q.push(n)
+	for q.len() > 0 {
+		next, _ := q.pop()
+		for _, p := range next.Node().nodeParents() {
+			if _, ok := seen[p.Node().id]; ok {
+				continue
+			}
+			seen[p.Node().id] = struct{}{}
+			if GraphForNode(p) != from {
+				continue
+			}
+			nodes = append(nodes, p)
+			q.push(p)
+		}
+	}
+	return nodes
+}
+
+// migrateNodes moves nodes that are not necessary to a given graph, resetting
+// their recompute state so that they are recomputed when they become necessary.
+func migrateNodes(nodes []INode, to *Graph) {
+	for _, mn := range nodes {
+		mnn := mn.Node()
+		mnn.createdIn = to
+		mnn.setAt = 0
+		mnn.changedAt = 0
+		mnn.recomputedAt = 0
+		mnn.height = HeightUnset
+		mnn.heightInRecomputeHeap = HeightUnset
+		mnn.heightInAdjustHeightsHeap = HeightUnset
+	}
+}
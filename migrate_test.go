@@ -0,0 +1,126 @@
+package incr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Observe_differentGraph(t *testing.T) {
+	g0 := New()
+	g1 := New()
+
+	v := Var(g0, "hello")
+	m := Map(g0, v, ident)
+
+	_, err := Observe(g1, m)
+	testutil.Error(t, err)
+	testutil.Equal(t, true, errors.Is(err, ErrNodeAlreadyInGraph))
+	testutil.Matches(t, g0.ID().Short(), err.Error())
+	testutil.Matches(t, g1.ID().Short(), err.Error())
+	testutil.Equal(t, false, g1.Has(m))
+	testutil.Equal(t, false, g0.Has(m))
+}
+
+func Test_Observe_differentGraph_parent(t *testing.T) {
+	g0 := New()
+	g1 := New()
+
+	v := Var(g0, "hello")
+	m := Map(g1, v, ident)
+
+	_, err := Observe(g1, m)
+	testutil.Error(t, err)
+	testutil.Equal(t, true, errors.Is(err, ErrNodeAlreadyInGraph))
+}
+
+func Test_Link_differentGraph(t *testing.T) {
+	g0 := New()
+	g1 := New()
+
+	v0 := Var(g0, "a")
+	lt := newLinkTestIncr(g0, v0)
+	_ = MustObserve(g0, lt)
+
+	v1 := Var(g1, "b")
+	err := Link(lt, v1)
+	testutil.Equal(t, true, errors.Is(err, ErrNodeAlreadyInGraph))
+}
+
+func Test_MigrateNode(t *testing.T) {
+	ctx := testContext()
+	g0 := New()
+	g1 := New()
+
+	v := Var(g0, "hello")
+	m0 := Map(g0, v, ident)
+	m1 := Map(g0, m0, func(vv string) string { return vv + "!" })
+	o0 := MustObserve(g0, m1)
+
+	other := Map(g0, Var(g0, "other"), ident)
+	oo := MustObserve(g0, other)
+
+	err := g0.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello!", o0.Value())
+
+	err = MigrateNode(g0, g1, m1)
+	testutil.Error(t, err, "should not migrate necessary nodes")
+
+	o0.Unobserve(ctx)
+	testutil.Equal(t, false, g0.Has(m1))
+
+	err = MigrateNode(g0, g1, m1)
+	testutil.NoError(t, err)
+	testutil.Equal(t, g1, GraphForNode(m1))
+	testutil.Equal(t, g1, GraphForNode(m0))
+	testutil.Equal(t, g1, GraphForNode(v))
+	testutil.Equal(t, g0, GraphForNode(other))
+
+	o1 := MustObserve(g1, m1)
+	testutil.Equal(t, 2, m1.Node().Height())
+
+	err = g1.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello!", o1.Value())
+
+	v.Set("migrated")
+	testutil.Equal(t, 1, g1.recomputeHeap.len())
+	testutil.Equal(t, 0, g0.recomputeHeap.len())
+
+	err = g1.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "migrated!", o1.Value())
+
+	err = g0.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "other", oo.Value())
+
+	_, err = Observe(g0, m1)
+	testutil.Equal(t, true, errors.Is(err, ErrNodeAlreadyInGraph))
+}
+
+func Test_MigrateNode_errors(t *testing.T) {
+	g0 := New()
+	g1 := New()
+
+	testutil.Error(t, MigrateNode(g0, g1, nil))
+
+	v := Var(g1, "hello")
+	testutil.Error(t, MigrateNode(g0, g1, v), "node should belong to the from graph")
+
+	var inner Incr[string]
+	b := Bind(g0, Var(g0, "a"), func(bs Scope, _ string) Incr[string] {
+		inner = Map(bs, Return(bs, "inner"), ident)
+		return inner
+	})
+	o := MustObserve(g0, b)
+	err := g0.Stabilize(testContext())
+	testutil.NoError(t, err)
+	o.Unobserve(testContext())
+	testutil.Error(t, MigrateNode(g0, g1, inner), "should not migrate bind scoped nodes")
+
+	g0.status = StatusStabilizing
+	testutil.Equal(t, ErrAlreadyStabilizing, MigrateNode(g0, g1, Var(g0, "a")))
+}
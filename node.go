@@ -3,6 +3,7 @@ package incr
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // NewNode returns a new node.
@@ -17,6 +18,17 @@ func NewNode(kind string) *Node {
 	}
 }
 
+// SameNode returns if two nodes are the same node, that is, if they have
+// the same identifier, regardless of the types they're held as.
+//
+// Two nil nodes are considered the same node.
+func SameNode(a, b INode) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Node().id == b.Node().id
+}
+
 // HeightUnset is a constant that denotes that a height isn't
 // strictly set (because heights can be 0, we have to use something
 // other than the integer zero value).
@@ -28,6 +40,8 @@ type Node struct {
 	createdIn Scope
 	// id is a unique identifier for the node
 	id Identifier
+	// order is the sequence number of the node's creation within its graph
+	order uint64
 	// kind is the meta-type of the node
 	kind string
 	// metadata is any additional metadata a user wants to attach to a node.
@@ -44,6 +58,9 @@ type Node struct {
 	// observers are observer nodes that are attached to this
 	// node or its children.
 	observers []IObserver
+	// weakObservers are observer nodes created with [ObserveWeak] that
+	// are attached to this node but do not make it necessary.
+	weakObservers []IObserver
 	// observers are observer nodes that are attached to this
 	// node or its children.
 	sentinels []ISentinel
@@ -61,6 +78,10 @@ type Node struct {
 	height int
 	// heightInRecomputeHeap is the height of a node in the recompute heap
 	heightInRecomputeHeap int
+	// priorityInRecomputeHeap is set for nodes added to the recompute heap with
+	// priority (see [Graph.SetStaleWithPriority]), where nodes added later have
+	// higher values, and is zero otherwise.
+	priorityInRecomputeHeap uint64
 	// heightInAdjustHeightsHeap is the height of a node in the adjust heights heap
 	heightInAdjustHeightsHeap int
 	// changedAt connotes when the node was changed last,
@@ -72,6 +93,9 @@ type Node struct {
 	setAt uint64
 	// recomputedAt connotes when the node was last stabilized
 	recomputedAt uint64
+	// recomputedAtTime is the time the node was last stabilized, and
+	// is only set if the graph tracks recompute times.
+	recomputedAtTime time.Time
 	// onUpdateHandlers are functions that are called when the node updates.
 	// they are added with `OnUpdate(...)`.
 	onUpdateHandlers []func(context.Context)
@@ -82,6 +106,21 @@ type Node struct {
 	// pre-empted for update by another node erroring.
 	// they are added with `OnError(...)`.
 	onAbortedHandlers []func(context.Context, error)
+	// onRecomputeStartHandlers are functions that are called before the node's stabilize
+	// function is called. they are added with `OnRecomputeStart(...)`.
+	onRecomputeStartHandlers []func(context.Context)
+	// onRecomputeEndHandlers are functions that are called after the node's stabilize
+	// function is called, including if it errors. they are added with `OnRecomputeEnd(...)`.
+	onRecomputeEndHandlers []func(context.Context, time.Duration)
+	// onObservedHandlers are functions that are called when the node becomes
+	// necessary. they are added with `OnObserved(...)`.
+	onObservedHandlers []func(context.Context)
+	// onUnobservedHandlers are functions that are called when the node is no longer
+	// necessary. they are added with `OnUnobserved(...)`.
+	onUnobservedHandlers []func(context.Context)
+	// lifecycleObserved is if the observed handlers have been called
+	// for the node without a matching call to the unobserved handlers.
+	lifecycleObserved bool
 	// stabilizeFn is set during initialization and is a shortcut
 	// to the interface sniff for the node for the IStabilize interface.
 	stabilizeFn func(context.Context) error
@@ -103,6 +142,25 @@ type Node struct {
 	observer bool
 	// always determines if we always recompute this node.
 	always bool
+	// shouldRecomputeFn is set during initialization and is a shortcut
+	// to the interface sniff for the node for the IAlwaysWhen interface.
+	shouldRecomputeFn func(context.Context) (bool, error)
+	// constant determines if the node's value never changes, and as a
+	// result the node only needs to be recomputed at most once.
+	constant bool
+	// skipIfUnchanged determines if the node can skip being recomputed
+	// if none of its inputs have changed since it was last recomputed.
+	skipIfUnchanged bool
+	// recomputeReason is the reason the node was added to the recompute heap.
+	recomputeReason RecomputeReason
+	// lastRecomputeReason is the reason the node was last recomputed.
+	lastRecomputeReason RecomputeReason
+	// cutoffErrorPolicy determines how errors returned by the
+	// node's cutoff function are handled.
+	cutoffErrorPolicy CutoffErrorPolicy
+	// verifyEqualFn is an optional function used to compare the node's values
+	// by [Graph.VerifyAgainstFullRecompute], and is set with `SetVerifyEqual(...)`.
+	verifyEqualFn func(any, any) bool
 	// numRecomputes is the number of times we recomputed the node
 	numRecomputes uint64
 	// numChanges is the number of times we changed the node
@@ -121,6 +179,61 @@ func (n *Node) ID() Identifier {
 	return n.id
 }
 
+// Order returns the sequence number of the node's creation within its graph,
+// that is, nodes created later have larger order values.
+//
+// It is zero for nodes that were not created within a graph scope.
+func (n *Node) Order() uint64 {
+	return n.order
+}
+
+// LastRecomputedAt returns the time the node was last recomputed, or the zero
+// time if the node hasn't been recomputed or if the graph was not created
+// with [OptGraphTrackRecomputeTimes].
+func (n *Node) LastRecomputedAt() time.Time {
+	return n.recomputedAtTime
+}
+
+// LastChangedStabilization returns the number of the stabilization in which
+// the node's value last changed (see [Graph.StabilizationNum]), or zero if
+// it hasn't changed since it became necessary.
+func (n *Node) LastChangedStabilization() uint64 {
+	return n.changedAt
+}
+
+// Parents returns a copy of the nodes that this node depends on, that is,
+// the nodes that this node takes as inputs.
+//
+// Parents are only linked when the node is necessary; if the node
+// is not observed this will return an empty slice.
+func (n *Node) Parents() []INode {
+	return copySlice(n.parents)
+}
+
+// Children returns a copy of the nodes that depend on this node, that is,
+// the nodes that take this node as an input.
+func (n *Node) Children() []INode {
+	return copySlice(n.children)
+}
+
+// Observers returns a copy of the observers that are directly
+// observing this node.
+func (n *Node) Observers() []IObserver {
+	return copySlice(n.observers)
+}
+
+// IsNecessary returns if the node is necessary, that is, if it is
+// observed either directly or through one of its children.
+func (n *Node) IsNecessary() bool {
+	return n.isNecessary()
+}
+
+// Height returns the topological sort pseudo-height of the node, or
+// [HeightUnset] if the node is not necessary.
+func (n *Node) Height() int {
+	return n.height
+}
+
 // String returns a string form of the node metadata.
 func (n *Node) String() string {
 	if n.label != "" {
@@ -154,6 +267,49 @@ func (n *Node) OnAborted(fn func(context.Context, error)) {
 	n.onAbortedHandlers = append(n.onAbortedHandlers, fn)
 }
 
+// OnObserved registers an observed handler.
+//
+// An observed handler is called when the node becomes necessary, that is,
+// when it is observed directly or through one of its children, including
+// when a [Bind] links it into the graph. Observed handlers are called before
+// the node is recomputed as a result of becoming necessary.
+func (n *Node) OnObserved(fn func(context.Context)) {
+	n.onObservedHandlers = append(n.onObservedHandlers, fn)
+}
+
+// OnUnobserved registers an unobserved handler.
+//
+// An unobserved handler is called when the node is no longer necessary, e.g. after
+// it is unobserved or a [Bind] unlinks it.
+//
+// If the node stops being necessary during stabilization, the unobserved handlers
+// are called at the end of the stabilization, and only if the node is still not
+// necessary, such that a node that is unlinked and linked again within the same
+// stabilization does not see the intermediate transitions.
+func (n *Node) OnUnobserved(fn func(context.Context)) {
+	n.onUnobservedHandlers = append(n.onUnobservedHandlers, fn)
+}
+
+// OnRecomputeStart registers a recompute start handler.
+//
+// A recompute start handler is called immediately before the
+// stabilize function for this node is called during stabilization.
+func (n *Node) OnRecomputeStart(fn func(context.Context)) {
+	n.onRecomputeStartHandlers = append(n.onRecomputeStartHandlers, fn)
+}
+
+// OnRecomputeEnd registers a recompute end handler.
+//
+// A recompute end handler is called immediately after the stabilize
+// function for this node returns during stabilization, and is passed
+// the elapsed time of the stabilize function.
+//
+// Recompute end handlers are called even if the stabilize function
+// returns an error, before the error is propagated.
+func (n *Node) OnRecomputeEnd(fn func(context.Context, time.Duration)) {
+	n.onRecomputeEndHandlers = append(n.onRecomputeEndHandlers, fn)
+}
+
 // Label returns a descriptive label for the node or
 // an empty string if one hasn't been provided.
 func (n *Node) Label() string {
@@ -161,7 +317,16 @@ func (n *Node) Label() string {
 }
 
 // SetLabel sets the descriptive label on the node.
+//
+// If the node is observed by a graph the graph's label
+// index is updated (see [Graph.FindByLabel]).
 func (n *Node) SetLabel(label string) {
+	if n.createdIn != nil {
+		if graph := n.createdIn.scopeGraph(); graph != nil {
+			graph.relabel(n, label)
+			return
+		}
+	}
 	n.label = label
 }
 
@@ -175,6 +340,19 @@ func (n *Node) SetMetadata(md any) {
 	n.metadata = md
 }
 
+// SetVerifyEqual sets the function used to compare the node's incremental
+// and recomputed values in [Graph.VerifyAgainstFullRecompute], in place
+// of [reflect.DeepEqual].
+func (n *Node) SetVerifyEqual(fn func(incremental, recomputed any) bool) {
+	n.verifyEqualFn = fn
+}
+
+// SetCutoffErrorPolicy sets how errors returned by the node's cutoff
+// function are handled during stabilization (see [CutoffErrorPolicy]).
+func (n *Node) SetCutoffErrorPolicy(policy CutoffErrorPolicy) {
+	n.cutoffErrorPolicy = policy
+}
+
 // Kind returns the meta type of the node.
 func (n *Node) Kind() string {
 	return n.kind
@@ -192,6 +370,8 @@ func (n *Node) SetKind(kind string) {
 // initializeFrom detects delegates on the node type.
 func (n *Node) initializeFrom(in INode) {
 	n.detectAlways(in)
+	n.detectConstant(in)
+	n.detectSkipIfUnchanged(in)
 	n.detectCutoff(in)
 	n.detectInvalidate(in)
 	n.detectObserver(in)
@@ -213,6 +393,10 @@ func (n *Node) addObservers(observers ...IObserver) {
 	n.observers = append(n.observers, observers...)
 }
 
+func (n *Node) addWeakObservers(observers ...IObserver) {
+	n.weakObservers = append(n.weakObservers, observers...)
+}
+
 func (n *Node) addSentinels(sentinels ...ISentinel) {
 	n.sentinels = append(n.sentinels, sentinels...)
 }
@@ -229,6 +413,10 @@ func (n *Node) removeObserver(id Identifier) {
 	n.observers, _ = remove(n.observers, id)
 }
 
+func (n *Node) removeWeakObserver(id Identifier) {
+	n.weakObservers, _ = remove(n.weakObservers, id)
+}
+
 func (n *Node) removeSentinel(id Identifier) {
 	n.sentinels, _ = remove(n.sentinels, id)
 }
@@ -242,6 +430,30 @@ func (n *Node) maybeCutoff(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+// maybeSkipUnchanged returns if a node that opts into skipping
+// recomputation can skip recomputing because it has been computed before,
+// it hasn't been explicitly marked stale, and none of its inputs have
+// changed since it was last recomputed.
+func (n *Node) maybeSkipUnchanged() bool {
+	return n.skipIfUnchanged &&
+		n.recomputedAt > 0 &&
+		n.setAt <= n.recomputedAt &&
+		!n.isStaleInRespectToParent()
+}
+
+// maybeSkipAlways returns if an [IAlwaysWhen] node should skip recomputing
+// because none of its inputs have changed and its predicate says so.
+func (n *Node) maybeSkipAlways(ctx context.Context) (bool, error) {
+	if n.shouldRecomputeFn == nil || n.recomputedAt == 0 || n.isStaleInRespectToParent() {
+		return false, nil
+	}
+	shouldRecompute, err := n.shouldRecomputeFn(ctx)
+	if err != nil {
+		return false, err
+	}
+	return !shouldRecompute, nil
+}
+
 func (n *Node) detectCutoff(gn INode) {
 	if typed, ok := gn.(ICutoff); ok {
 		n.cutoffFn = typed.Cutoff
@@ -256,6 +468,17 @@ func (n *Node) detectParents(gn INode) {
 
 func (n *Node) detectAlways(gn INode) {
 	_, n.always = gn.(IAlways)
+	if typed, ok := gn.(IAlwaysWhen); ok {
+		n.shouldRecomputeFn = typed.ShouldRecompute
+	}
+}
+
+func (n *Node) detectConstant(gn INode) {
+	_, n.constant = gn.(iConstant)
+}
+
+func (n *Node) detectSkipIfUnchanged(gn INode) {
+	_, n.skipIfUnchanged = gn.(iSkipIfUnchanged)
 }
 
 func (n *Node) detectInvalidate(gn INode) {
@@ -334,16 +557,40 @@ func (n *Node) isStaleInRespectToParent() (stale bool) {
 	return
 }
 
+// isConstant returns if the node's value never changes and
+// it should only be scheduled for recomputation once (unless it
+// is explicitly marked stale with [Graph.SetStale]).
+func (n *Node) isConstant() bool {
+	return n.constant
+}
+
 func (n *Node) isStale() bool {
 	if !n.valid {
 		return false
 	}
+	if n.constant {
+		return n.recomputedAt == 0
+	}
 	if n.staleFn != nil {
 		return n.staleFn()
 	}
 	return n.recomputedAt == 0 || n.isStaleInRespectToParent()
 }
 
+func (n *Node) nodeMetadata() NodeMetadata {
+	recomputeReason := n.lastRecomputeReason
+	if n.heightInRecomputeHeap != HeightUnset {
+		recomputeReason = n.recomputeReason
+	}
+	return NodeMetadata{
+		ID:              n.id,
+		Kind:            n.kind,
+		Label:           n.label,
+		Height:          n.height,
+		RecomputeReason: recomputeReason,
+	}
+}
+
 func (n *Node) isNecessary() bool {
 	if n.observer {
 		return true
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/wcharczuk/go-incr/testutil"
 )
@@ -30,6 +31,19 @@ func Test_NewNode(t *testing.T) {
 	testutil.Nil(t, n.metadata)
 }
 
+func Test_SameNode(t *testing.T) {
+	g := New()
+	v := Var(g, "foo")
+	m := Map(g, v, ident)
+
+	testutil.Equal(t, true, SameNode(v, v))
+	testutil.Equal(t, true, SameNode(Incr[string](v), INode(v)))
+	testutil.Equal(t, false, SameNode(v, m))
+	testutil.Equal(t, false, SameNode(v, nil))
+	testutil.Equal(t, false, SameNode(nil, m))
+	testutil.Equal(t, true, SameNode(nil, nil))
+}
+
 func Test_Node_ID(t *testing.T) {
 	n := NewNode("test_node")
 	testutil.Equal(t, false, n.ID().IsZero())
@@ -39,6 +53,22 @@ func Test_Node_ID(t *testing.T) {
 	testutil.Equal(t, other, n.ID())
 }
 
+func Test_Node_Order(t *testing.T) {
+	n := NewNode("test_node")
+	testutil.Equal(t, 0, n.Order())
+
+	g := New()
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	m := Map2(g, v0, v1, concat)
+	testutil.Equal(t, 1, v0.Node().Order())
+	testutil.Equal(t, 2, v1.Node().Order())
+	testutil.Equal(t, 3, m.Node().Order())
+
+	other := New()
+	testutil.Equal(t, 1, Var(other, "c").Node().Order())
+}
+
 func Test_Node_Label(t *testing.T) {
 	n := NewNode("test_node")
 	testutil.Equal(t, "", n.Label())
@@ -78,7 +108,7 @@ func Test_SetStale(t *testing.T) {
 	testutil.Equal(t, true, GraphForNode(n).recomputeHeap.has(n))
 
 	// find the node in the recompute heap layer
-	testutil.Equal(t, 1, GraphForNode(n).recomputeHeap.heights[0].len())
+	testutil.Equal(t, 1, GraphForNode(n).recomputeHeap.listUnsafe(0).len())
 
 	g.SetStale(n)
 
@@ -88,7 +118,7 @@ func Test_SetStale(t *testing.T) {
 
 	testutil.Equal(t, true, GraphForNode(n).recomputeHeap.has(n))
 
-	testutil.Equal(t, 1, GraphForNode(n).recomputeHeap.heights[0].len())
+	testutil.Equal(t, 1, GraphForNode(n).recomputeHeap.listUnsafe(0).len())
 }
 
 func Test_Node_OnUpdate(t *testing.T) {
@@ -107,6 +137,62 @@ func Test_Node_OnError(t *testing.T) {
 	testutil.Equal(t, 1, len(n.onErrorHandlers))
 }
 
+func Test_Node_OnRecomputeStart_OnRecomputeEnd(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	m := Map(g, v, func(vv string) string {
+		time.Sleep(time.Millisecond)
+		return vv + " world"
+	})
+
+	var events []string
+	var elapsed time.Duration
+	m.Node().OnRecomputeStart(func(ictx context.Context) {
+		testutil.BlueDye(ictx, t)
+		events = append(events, "start")
+	})
+	m.Node().OnRecomputeEnd(func(ictx context.Context, e time.Duration) {
+		testutil.BlueDye(ictx, t)
+		events = append(events, "end")
+		elapsed = e
+	})
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"start", "end"}, events)
+	testutil.Equal(t, true, elapsed >= time.Millisecond)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"start", "end"}, events)
+}
+
+func Test_Node_OnRecomputeEnd_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	m := MapContext(g, v, func(_ context.Context, _ string) (string, error) {
+		return "", fmt.Errorf("this is only a test")
+	})
+
+	var events []string
+	m.Node().OnRecomputeEnd(func(_ context.Context, _ time.Duration) {
+		events = append(events, "end")
+	})
+	m.Node().OnError(func(_ context.Context, _ error) {
+		events = append(events, "error")
+	})
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, []string{"end", "error"}, events)
+}
+
 func Test_Node_SetLabel(t *testing.T) {
 	n := NewNode("test_node")
 
@@ -115,6 +201,87 @@ func Test_Node_SetLabel(t *testing.T) {
 	testutil.Equal(t, "test-label", n.label)
 }
 
+func Test_Node_topology_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	bindVar := Var(g, "a")
+	av := Var(g, "a-value")
+	a0 := Map(g, av, ident)
+	bv := Var(g, "b-value")
+	b0 := Map(g, bv, ident)
+	b1 := Map(g, b0, ident)
+
+	bind := Bind(g, bindVar, func(_ Scope, which string) Incr[string] {
+		if which == "a" {
+			return a0
+		}
+		return b1
+	})
+	m := Map(g, bind, ident)
+
+	testutil.Equal(t, false, m.Node().IsNecessary())
+	testutil.Equal(t, HeightUnset, m.Node().Height())
+	testutil.Empty(t, m.Node().Parents())
+	testutil.Empty(t, m.Node().Observers())
+
+	o := MustObserve(g, m)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a-value", o.Value())
+
+	testutil.Equal(t, "bind", bind.Node().Kind())
+	testutil.Equal(t, true, bind.Node().IsNecessary())
+	testutil.Equal(t, true, a0.Node().IsNecessary())
+	testutil.Equal(t, false, b1.Node().IsNecessary())
+
+	testutil.Equal(t, 1, len(m.Node().Parents()))
+	testutil.Equal(t, true, hasKey(m.Node().Parents(), bind.Node().ID()))
+	testutil.Equal(t, true, hasKey(bind.Node().Parents(), a0.Node().ID()))
+	testutil.Equal(t, true, hasKey(a0.Node().Children(), bind.Node().ID()))
+	testutil.Empty(t, b1.Node().Children())
+	testutil.Equal(t, true, hasKey(m.Node().Observers(), o.Node().ID()))
+	testutil.Empty(t, a0.Node().Observers())
+	testutil.Equal(t, 1, a0.Node().Height())
+	testutil.Equal(t, HeightUnset, b1.Node().Height())
+
+	bindVar.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b-value", o.Value())
+
+	testutil.Equal(t, false, a0.Node().IsNecessary())
+	testutil.Equal(t, true, b1.Node().IsNecessary())
+	testutil.Equal(t, true, hasKey(bind.Node().Parents(), b1.Node().ID()))
+	testutil.Equal(t, false, hasKey(bind.Node().Parents(), a0.Node().ID()))
+	testutil.Empty(t, a0.Node().Children())
+	testutil.Equal(t, true, hasKey(b1.Node().Children(), bind.Node().ID()))
+	testutil.Equal(t, true, hasKey(m.Node().Observers(), o.Node().ID()))
+	testutil.Equal(t, HeightUnset, a0.Node().Height())
+	testutil.Equal(t, 2, b1.Node().Height())
+	testutil.Equal(t, true, bind.Node().Height() > b1.Node().Height())
+}
+
+func Test_Node_topology_returnsCopies(t *testing.T) {
+	g := New()
+
+	v := Var(g, "hello")
+	m := Map(g, v, ident)
+	o := MustObserve(g, m)
+
+	parents := m.Node().Parents()
+	parents[0] = nil
+	testutil.NotNil(t, m.Node().Parents()[0])
+
+	children := v.Node().Children()
+	children[0] = nil
+	testutil.NotNil(t, v.Node().Children()[0])
+
+	observers := m.Node().Observers()
+	observers[0] = nil
+	testutil.Equal(t, o.Node().ID(), m.Node().Observers()[0].Node().ID())
+}
+
 func Test_Node_addChildren(t *testing.T) {
 	g := New()
 
@@ -460,8 +627,26 @@ func Test_nodeFormatters(t *testing.T) {
 		{Bind[string, string](g, Return(g, ""), nil), "bind"},
 		{Cutoff(g, Return(g, ""), nil), "cutoff"},
 		{Cutoff2(g, Return(g, ""), Return(g, ""), nil), "cutoff2"},
+		{Cutoff3(g, Return(g, ""), Return(g, ""), Return(g, ""), nil), "cutoff3"},
+		{CutoffN(g, Return(g, ""), nil, Return(g, "")), "cutoff_n"},
+		{Lazy(g, func(bs Scope) Incr[string] { return Return(bs, "") }), "lazy"},
+		{CircuitBreaker(g, Return(g, ""), 1), "circuit_breaker"},
 		{Func[string](g, nil), "func"},
 		{MapN[string, bool](g, nil), "map_n"},
+		{Join[string, int, int, int](g, Return(g, map[string]int{}), Return(g, map[string]int{}), nil), "join"},
+		{LeftJoin[string, int, int, int](g, Return(g, map[string]int{}), Return(g, map[string]int{}), nil), "left_join"},
+		{SumN[int](g), "sum_n"},
+		{MeanN[int](g), "mean_n"},
+		{MinN[int](g), "min_n"},
+		{MaxN[int](g), "max_n"},
+		{Lag(g, Return(g, ""), 2, ""), "lag"},
+		{At[int](g, nil, nil, nil), "at"},
+		{AtOption[int](g, nil, nil, nil), "at_option"},
+		{TopK[int](g, nil, 1), "top_k"},
+		{JoinStrings(g, ","), "join_strings"},
+		{RisingEdge(g, Return(g, false)), "rising_edge"},
+		{FallingEdge(g, Return(g, false)), "falling_edge"},
+		{DistinctBy[string, int](g, Return(g, ""), nil), "distinct_by"},
 		{Map[string, bool](g, Return(g, ""), nil), "map"},
 		{Map2[string, int, bool](g, Return(g, ""), Return(g, 0), nil), "map2"},
 		{Map3[string, int, float64, bool](g, Return(g, ""), Return(g, 0), Return(g, 1.0), nil), "map3"},
@@ -469,6 +654,8 @@ func Test_nodeFormatters(t *testing.T) {
 		{Map5[string, int, float64, bool, string, string](g, Return(g, ""), Return(g, 0), Return(g, 1.0), Return(g, false), Return(g, ""), nil), "map5"},
 		{Map6[string, int, float64, bool, string, int, string](g, Return(g, ""), Return(g, 0), Return(g, 1.0), Return(g, false), Return(g, ""), Return(g, 2), nil), "map6"},
 		{MapIf(g, Return(g, ""), Return(g, ""), Return(g, false)), "map_if"},
+		{MapIfLazy(g, Return(g, ""), Return(g, ""), Return(g, false)), "map_if_lazy"},
+		{Switch(g, Return(g, 0), Return(g, "")), "switch"},
 		{Return(g, ""), "return"},
 		{Watch(g, Return(g, "")), "watch"},
 		{Freeze(g, Return(g, "")), "freeze"},
@@ -632,3 +819,166 @@ func Test_Node_shouldBeInvalidated_fallThrough(t *testing.T) {
 	}
 	testutil.Equal(t, false, n.shouldBeInvalidated())
 }
+
+func Test_Node_OnObserved_OnUnobserved(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	m := Map(g, v, ident)
+	var observed, unobserved int
+	m.Node().OnObserved(func(_ context.Context) { observed++ })
+	m.Node().OnUnobserved(func(_ context.Context) { unobserved++ })
+
+	o0 := MustObserve(g, m)
+	testutil.Equal(t, 1, observed)
+	testutil.Equal(t, 0, unobserved)
+
+	o1 := MustObserve(g, m)
+	testutil.Equal(t, 1, observed)
+
+	o0.Unobserve(ctx)
+	testutil.Equal(t, 0, unobserved)
+	o1.Unobserve(ctx)
+	testutil.Equal(t, 1, observed)
+	testutil.Equal(t, 1, unobserved)
+
+	_ = MustObserve(g, m)
+	testutil.Equal(t, 2, observed)
+	testutil.Equal(t, 1, unobserved)
+}
+
+func Test_Node_OnObserved_OnUnobserved_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	sw := Var(g, false)
+	i0 := Return(g, "foo")
+	m0 := Map(g, i0, func(v0 string) string { return v0 + "-moo" })
+	i1 := Return(g, "bar")
+	m1 := Map(g, i1, func(v0 string) string { return v0 + "-loo" })
+	b := Bind(g, sw, func(_ Scope, swv bool) Incr[string] {
+		if swv {
+			return m0
+		}
+		return m1
+	})
+	mb := Map(g, b, func(v string) string {
+		return v + "-baz"
+	})
+
+	observed := make(map[string]int)
+	unobserved := make(map[string]int)
+	for label, n := range map[string]INode{"i0": i0, "m0": m0, "i1": i1, "m1": m1} {
+		label := label
+		n.Node().OnObserved(func(_ context.Context) { observed[label]++ })
+		n.Node().OnUnobserved(func(ictx context.Context) {
+			testutil.BlueDye(ictx, t)
+			unobserved[label]++
+		})
+	}
+	_ = MustObserve(g, mb)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar-loo-baz", mb.Value())
+	testutil.Equal(t, map[string]int{"i1": 1, "m1": 1}, observed)
+	testutil.Equal(t, 0, len(unobserved))
+
+	sw.Set(true)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo-moo-baz", mb.Value())
+	testutil.Equal(t, map[string]int{"i0": 1, "m0": 1, "i1": 1, "m1": 1}, observed)
+	testutil.Equal(t, map[string]int{"i1": 1, "m1": 1}, unobserved)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, map[string]int{"i0": 1, "m0": 1, "i1": 1, "m1": 1}, observed)
+	testutil.Equal(t, map[string]int{"i1": 1, "m1": 1}, unobserved)
+}
+
+func Test_Node_OnUnobserved_relinkDuringStabilization(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	m := Map(g, Var(g, "a"), ident)
+	var observed, unobserved int
+	m.Node().OnObserved(func(_ context.Context) { observed++ })
+	m.Node().OnUnobserved(func(_ context.Context) { unobserved++ })
+	_ = MustObserve(g, m)
+	testutil.Equal(t, 1, observed)
+
+	// simulate the node being unlinked and relinked within a stabilization.
+	g.stabilizationContext = ctx
+	g.removeNode(m)
+	g.addNode(m)
+	g.stabilizationContext = nil
+	g.stabilizeEndRunUnobservedHandlers(ctx)
+
+	testutil.Equal(t, 1, observed)
+	testutil.Equal(t, 0, unobserved)
+}
+
+func Test_Node_LastRecomputedAt(t *testing.T) {
+	ctx := testContext()
+
+	g := New()
+	m := Map(g, Var(g, "a"), ident)
+	_ = MustObserve(g, m)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, m.Node().LastRecomputedAt().IsZero(), "recompute times should not be tracked by default")
+
+	g = New(OptGraphTrackRecomputeTimes(true))
+	v := Var(g, "a")
+	m = Map(g, v, ident)
+	_ = MustObserve(g, m)
+	testutil.Equal(t, true, m.Node().LastRecomputedAt().IsZero())
+
+	before := time.Now()
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	first := m.Node().LastRecomputedAt()
+	testutil.Equal(t, false, first.Before(before))
+	testutil.Equal(t, false, first.After(g.LastStabilizedAt()))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, first, m.Node().LastRecomputedAt(), "the node wasn't recomputed")
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, m.Node().LastRecomputedAt().Before(first))
+}
+
+func Test_Node_LastChangedStabilization(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, 100)
+	c := Cutoff(g, v, func(oldv, newv int) bool {
+		return newv-oldv < 10
+	})
+	m := Map(g, c, ident)
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, c.Node().LastChangedStabilization())
+	testutil.Equal(t, 1, m.Node().LastChangedStabilization())
+
+	v.Set(102)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, v.Node().LastChangedStabilization())
+	testutil.Equal(t, 1, c.Node().LastChangedStabilization(), "a cutoff node should not advance")
+	testutil.Equal(t, 1, m.Node().LastChangedStabilization())
+
+	v.Set(120)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, c.Node().LastChangedStabilization())
+	testutil.Equal(t, 3, m.Node().LastChangedStabilization())
+}
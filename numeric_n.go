@@ -0,0 +1,183 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// SumN returns an incremental whose value is the sum of the values of
+// a given list of input incrementals.
+//
+// The sum is maintained incrementally; when the node is recomputed only the
+// inputs that changed since it was last recomputed are folded into the sum,
+// by the difference between their new and previous values.
+//
+// For floating point inputs the running sum can accumulate rounding error
+// relative to summing the inputs from scratch; [Graph.RecomputeAll] will
+// recompute the sum from scratch.
+func SumN[A Number](scope Scope, inputs ...Incr[A]) MapNIncr[A, A] {
+	return newNumericN(scope, "sum_n", numericNSum, inputs)
+}
+
+// MeanN returns an incremental whose value is the arithmetic mean of the
+// values of a given list of input incrementals, or zero if there are no inputs.
+//
+// The mean is computed from a running sum the same way as [SumN]; for
+// integer types the mean is truncated by integer division.
+func MeanN[A Number](scope Scope, inputs ...Incr[A]) MapNIncr[A, A] {
+	return newNumericN(scope, "mean_n", numericNMean, inputs)
+}
+
+// MinN returns an incremental whose value is the minimum of the values of
+// a given list of input incrementals, or zero if there are no inputs.
+//
+// The minimum is maintained incrementally; only if the input that held the
+// minimum value increases (or is removed) are all the inputs compared again.
+func MinN[A Number](scope Scope, inputs ...Incr[A]) MapNIncr[A, A] {
+	return newNumericN(scope, "min_n", numericNMin, inputs)
+}
+
+// MaxN returns an incremental whose value is the maximum of the values of
+// a given list of input incrementals, or zero if there are no inputs.
+//
+// The maximum is maintained incrementally; only if the input that held the
+// maximum value decreases (or is removed) are all the inputs compared again.
+func MaxN[A Number](scope Scope, inputs ...Incr[A]) MapNIncr[A, A] {
+	return newNumericN(scope, "max_n", numericNMax, inputs)
+}
+
+type numericNOp int
+
+const (
+	numericNSum numericNOp = iota
+	numericNMean
+	numericNMin
+	numericNMax
+)
+
+func newNumericN[A Number](scope Scope, kind string, op numericNOp, inputs []Incr[A]) MapNIncr[A, A] {
+	return WithinScope(scope, &numericNIncr[A]{
+		n:      NewNode(kind),
+		op:     op,
+		inputs: newDynamicInputs[A, A](inputs),
+	})
+}
+
+var (
+	_ MapNIncr[int, int] = (*numericNIncr[int])(nil)
+	_ IParents           = (*numericNIncr[int])(nil)
+	_ IStabilize         = (*numericNIncr[int])(nil)
+	_ fmt.Stringer       = (*numericNIncr[int])(nil)
+)
+
+type numericNIncr[A Number] struct {
+	n  *Node
+	op numericNOp
+	// inputs holds the inputs, and for each the value of the
+	// input as of when it was last folded into the aggregate.
+	inputs dynamicInputs[A, A]
+	// sum is the running sum of the inputs for sum and mean nodes.
+	sum A
+	// hasValue is set once a min or max node has folded in an input.
+	hasValue bool
+	// rescan is set when the aggregate must be computed from scratch.
+	rescan bool
+	value  A
+}
+
+func (nn *numericNIncr[A]) Parents() []INode {
+	return nn.inputs.parents()
+}
+
+func (nn *numericNIncr[A]) AddInput(i Incr[A]) error {
+	return nn.inputs.add(nn, i)
+}
+
+func (nn *numericNIncr[A]) RemoveInput(id Identifier) error {
+	return nn.inputs.remove(nn, id, func(value A) {
+		switch nn.op {
+		case numericNSum, numericNMean:
+			nn.sum -= value
+		default:
+			nn.rescan = nn.rescan || value == nn.value
+		}
+	})
+}
+
+func (nn *numericNIncr[A]) Node() *Node { return nn.n }
+
+func (nn *numericNIncr[A]) Value() A { return nn.value }
+
+func (nn *numericNIncr[A]) Stabilize(_ context.Context) error {
+	if nn.rescan || nn.inputs.stale(nn) {
+		nn.reset()
+	}
+	nn.inputs.eachChanged(nn.fold)
+	if nn.rescan {
+		nn.reset()
+		nn.inputs.eachChanged(nn.fold)
+	}
+	nn.updateSum()
+	return nil
+}
+
+func (nn *numericNIncr[A]) String() string { return nn.n.String() }
+
+// fold updates the aggregate for a single input whose value changed
+// from the previous value to a given value; seen is false for
+// inputs we haven't read before.
+func (nn *numericNIncr[A]) fold(value A, previous *A, seen bool) {
+	old := *previous
+	*previous = value
+	switch nn.op {
+	case numericNSum, numericNMean:
+		nn.sum += value - old
+	case numericNMin, numericNMax:
+		if !nn.hasValue {
+			nn.value = value
+			nn.hasValue = true
+			return
+		}
+		if nn.better(value, nn.value) {
+			nn.value = value
+			return
+		}
+		// the input that held the extreme value got worse,
+		// so another input may now hold the extreme value.
+		if seen && old == nn.value && value != old {
+			nn.rescan = true
+		}
+	}
+}
+
+func (nn *numericNIncr[A]) better(a, b A) bool {
+	if nn.op == numericNMin {
+		return a < b
+	}
+	return a > b
+}
+
+// reset clears the aggregate such that every input is folded in again.
+func (nn *numericNIncr[A]) reset() {
+	var zero A
+	nn.rescan = false
+	nn.hasValue = false
+	nn.sum = zero
+	nn.value = zero
+	nn.inputs.reset()
+}
+
+// updateSum sets the value of sum and mean nodes from the running sum.
+func (nn *numericNIncr[A]) updateSum() {
+	switch nn.op {
+	case numericNSum:
+		nn.value = nn.sum
+	case numericNMean:
+		if len(nn.inputs.inputs) == 0 {
+			var zero A
+			nn.value = zero
+			return
+		}
+		nn.value = nn.sum / A(len(nn.inputs.inputs))
+	}
+}
@@ -0,0 +1,310 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_SumN(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	const count = 1000
+	var reads int
+	vars := make([]VarIncr[int], count)
+	inputs := make([]Incr[int], count)
+	for x := 0; x < count; x++ {
+		vars[x] = Var(g, x)
+		inputs[x] = readCounter(g, vars[x], &reads)
+	}
+	s := SumN(g, inputs...)
+	o := MustObserve(g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, sumOfVars(vars), o.Value())
+	testutil.Equal(t, count, reads)
+
+	vars[500].Set(-100)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, sumOfVars(vars), o.Value())
+	testutil.Equal(t, count+1, reads)
+
+	vars[1].Set(10)
+	vars[999].Set(0)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, sumOfVars(vars), o.Value())
+	testutil.Equal(t, count+3, reads)
+}
+
+func Test_SumN_addRemoveInput(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 1)
+	v1 := Var(g, 2)
+	v2 := Var(g, 3)
+	s := SumN[int](g, v0, v1)
+	o := MustObserve(g, s)
+	_ = MustObserve(g, v2)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, o.Value())
+
+	err = s.AddInput(v2)
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, o.Value())
+
+	err = s.RemoveInput(v0.Node().ID())
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5, o.Value())
+
+	v2.Set(10)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 12, o.Value())
+
+	err = s.RemoveInput(v1.Node().ID())
+	testutil.NoError(t, err)
+	err = s.RemoveInput(v2.Node().ID())
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, o.Value())
+	testutil.NoError(t, g.Validate())
+
+	err = s.RemoveInput(v0.Node().ID())
+	testutil.Equal(t, ErrInputNotFound, err)
+}
+
+func Test_NumericN_RemoveInput_beforeObserve(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fn       func(Scope, ...Incr[int]) MapNIncr[int, int]
+		expected int
+	}{
+		{"sum", SumN[int], 10},
+		{"mean", MeanN[int], 5},
+		{"min", MinN[int], 2},
+		{"max", MaxN[int], 8},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := testContext()
+			g := New()
+
+			v0 := Var(g, 1)
+			v1 := Var(g, 2)
+			v2 := Var(g, 8)
+			n := tc.fn(g, v0, v1, v2)
+
+			err := n.RemoveInput(v0.Node().ID())
+			testutil.NoError(t, err)
+
+			o := MustObserve(g, n)
+			err = g.Stabilize(ctx)
+			testutil.NoError(t, err)
+			testutil.Equal(t, tc.expected, o.Value())
+			testutil.Equal(t, false, g.Has(v0))
+		})
+	}
+}
+
+func Test_SumN_duplicateInputs(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, 2)
+	o := MustObserve(g, SumN[int](g, v, v, v))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, o.Value())
+
+	v.Set(3)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 9, o.Value())
+}
+
+func Test_SumN_reobserved(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 1)
+	v1 := Var(g, 2)
+	s := SumN[int](g, v0, v1)
+	o := MustObserve(g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, o.Value())
+
+	o.Unobserve(ctx)
+	v0.Set(10)
+
+	o = MustObserve(g, s)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 12, o.Value())
+}
+
+func Test_SumN_float(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 0.5)
+	v1 := Var(g, 0.25)
+	s := SumN[float64](g, v0, v1)
+	o := MustObserve(g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0.75, o.Value())
+
+	v1.Set(2.0)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2.5, o.Value())
+
+	err = g.RecomputeAll(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2.5, o.Value())
+}
+
+func Test_MeanN(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 2)
+	v1 := Var(g, 4)
+	v2 := Var(g, 9)
+	m := MeanN[int](g, v0, v1)
+	o := MustObserve(g, m)
+	_ = MustObserve(g, v2)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, o.Value())
+
+	err = m.AddInput(v2)
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5, o.Value())
+
+	v0.Set(5)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, o.Value())
+
+	for _, id := range []Identifier{v0.Node().ID(), v1.Node().ID(), v2.Node().ID()} {
+		err = m.RemoveInput(id)
+		testutil.NoError(t, err)
+	}
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, o.Value())
+}
+
+func Test_MinN(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	const count = 1000
+	var reads int
+	vars := make([]VarIncr[int], count)
+	inputs := make([]Incr[int], count)
+	for x := 0; x < count; x++ {
+		vars[x] = Var(g, x+10)
+		inputs[x] = readCounter(g, vars[x], &reads)
+	}
+	m := MinN(g, inputs...)
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10, o.Value())
+	testutil.Equal(t, count, reads)
+
+	// a new minimum only has to be compared with the current minimum.
+	vars[500].Set(5)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5, o.Value())
+	testutil.Equal(t, count+1, reads)
+
+	// as does an input that isn't the minimum increasing.
+	vars[700].Set(2000)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5, o.Value())
+	testutil.Equal(t, count+2, reads)
+
+	// but the minimum increasing requires comparing all the inputs.
+	vars[500].Set(500)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10, o.Value())
+	testutil.Equal(t, 2*count+3, reads)
+
+	err = m.RemoveInput(inputs[0].Node().ID())
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 11, o.Value())
+}
+
+func Test_MaxN(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 1)
+	v1 := Var(g, 5)
+	v2 := Var(g, 3)
+	m := MaxN[int](g, v0, v1, v2)
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5, o.Value())
+
+	v1.Set(0)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, o.Value())
+
+	v0.Set(7)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 7, o.Value())
+
+	err = m.RemoveInput(v0.Node().ID())
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, o.Value())
+
+	err = m.RemoveInput(v1.Node().ID())
+	testutil.NoError(t, err)
+	err = m.RemoveInput(v2.Node().ID())
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, o.Value())
+}
+
+func sumOfVars(vars []VarIncr[int]) (output int) {
+	for _, v := range vars {
+		output += v.Value()
+	}
+	return
+}
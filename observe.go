@@ -3,6 +3,7 @@ package incr
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
 // MustObserve observes a node, specifically including it for computation
@@ -30,6 +31,25 @@ func Observe[A any](g *Graph, observed Incr[A]) (ObserveIncr[A], error) {
 	return o, nil
 }
 
+// ObserveWeak returns an observer that reads the value of a node without
+// making the node necessary, that is, the node (and its parents) are only
+// recomputed if some other observer needs them.
+//
+// The value of the observer is the last value the node computed, or the
+// zero value if the node has never been computed. Update handlers registered
+// on the observer are called when the node is recomputed.
+//
+// Weak observers are not tracked by the graph as observers.
+func ObserveWeak[A any](g *Graph, observed Incr[A]) ObserveIncr[A] {
+	o := WithinScope(g, &observeIncr[A]{
+		n:        NewNode("weak_observer"),
+		observed: observed,
+		weak:     true,
+	})
+	observed.Node().addWeakObservers(o)
+	return o
+}
+
 // ObserveIncr is an incremental that observes a graph
 // of incrementals starting a given input.
 type ObserveIncr[A any] interface {
@@ -45,6 +65,35 @@ type ObserveIncr[A any] interface {
 	OnUpdate(func(context.Context, A))
 	// Value returns the observed node value.
 	Value() A
+	// ValueOK returns the observed node value and true, or the zero value and false
+	// if the observer has been unobserved (or, for weak observers, if the
+	// observed node hasn't been computed).
+	ValueOK() (A, bool)
+	// Observed returns the node the observer observes, including
+	// after the observer has been unobserved.
+	Observed() Incr[A]
+	// Reobserve re-observes the observed node after the observer has been
+	// unobserved, linking the observer (and the observed node and its parents)
+	// back into the graph as if the observer was just created.
+	//
+	// Calling Reobserve on an observer that is currently observing
+	// its node has no effect.
+	Reobserve(context.Context) error
+	// ValueStable returns the observed node value along with the
+	// stabilization number the value was computed in.
+	//
+	// It waits for any stabilization pass in flight to complete, and as
+	// a result must not be called from within node functions. It can be
+	// called from within [Graph.Read].
+	ValueStable() (A, uint64)
+	// Subscribe returns a channel that receives the observed node value
+	// after each stabilization in which it changes, and a function that
+	// cancels the subscription and closes the channel.
+	//
+	// The buffer sets the channel's capacity. By default values are sent without
+	// blocking and are dropped if the channel is full; use [SubscribeWithBlocking]
+	// to wait for the receiver instead.
+	Subscribe(buffer int, opts ...SubscribeOption) (<-chan A, func())
 }
 
 // IObserver is an INode that can be unobserved.
@@ -54,7 +103,8 @@ type IObserver interface {
 	// Unobserve effectively removes a given node from the observed ref count for a graph.
 	//
 	// As well, it unlinks the observer from its parent nodes, and as a result
-	// you should _not_ re-use the node.
+	// you should _not_ re-use the node unless it supports being re-observed
+	// (e.g. with [ObserveIncr.Reobserve]).
 	//
 	// To observe parts of a graph again, use the `MustObserve(...)` helper.
 	Unobserve(context.Context)
@@ -66,8 +116,12 @@ var (
 )
 
 type observeIncr[A any] struct {
-	n        *Node
-	observed Incr[A]
+	n             *Node
+	observed      Incr[A]
+	unobserved    bool
+	weak          bool
+	subscribersMu sync.Mutex
+	subscribers   map[*observeSubscription[A]]struct{}
 }
 
 func (o *observeIncr[A]) OnUpdate(fn func(context.Context, A)) {
@@ -79,17 +133,71 @@ func (o *observeIncr[A]) OnUpdate(fn func(context.Context, A)) {
 func (o *observeIncr[A]) Node() *Node { return o.n }
 
 func (o *observeIncr[A]) Unobserve(ctx context.Context) {
-	GraphForNode(o).unobserveNode(o, o.observed)
-	o.observed = nil
+	if o.unobserved {
+		return
+	}
+	if o.weak {
+		o.observed.Node().removeWeakObserver(o.n.id)
+	} else {
+		GraphForNode(o).unobserveNode(o, o.observed)
+	}
+	o.unobserved = true
 }
 
+func (o *observeIncr[A]) Reobserve(ctx context.Context) error {
+	if !o.unobserved {
+		return nil
+	}
+	if o.weak {
+		o.observed.Node().addWeakObservers(o)
+		o.unobserved = false
+		return nil
+	}
+	if err := GraphForNode(o).observeNode(o, o.observed); err != nil {
+		return err
+	}
+	o.unobserved = false
+	return nil
+}
+
+func (o *observeIncr[A]) Observed() Incr[A] { return o.observed }
+
 func (o *observeIncr[A]) Value() (output A) {
-	if o.observed == nil {
+	if o.unobserved || !o.hasValue() {
 		return
 	}
 	return o.observed.Value()
 }
 
+func (o *observeIncr[A]) ValueOK() (output A, ok bool) {
+	if o.unobserved || !o.hasValue() {
+		return
+	}
+	return o.observed.Value(), true
+}
+
+// hasValue returns if the observed node has a value to return, which
+// for weak observers is only the case if the node has been computed.
+func (o *observeIncr[A]) hasValue() bool {
+	if !o.weak {
+		return true
+	}
+	on := o.observed.Node()
+	return on.isNecessary() || on.numRecomputes > 0
+}
+
+func (o *observeIncr[A]) ValueStable() (value A, stabilizationNum uint64) {
+	graph := GraphForNode(o)
+	graph.readMu.RLock()
+	defer graph.readMu.RUnlock()
+	if o.unobserved || !o.hasValue() {
+		return
+	}
+	value = o.observed.Value()
+	stabilizationNum = o.observed.Node().changedAt
+	return
+}
+
 func (o *observeIncr[A]) String() string {
 	if o.n.label != "" {
 		return fmt.Sprintf("%s[%s]:%s", o.n.kind, o.n.id.Short(), o.n.label)
@@ -0,0 +1,32 @@
+package incr
+
+// MustObserveIf observes a node only while a given flag incremental is true.
+//
+// If this detects a cycle or any other issue a panic will be raised.
+func MustObserveIf[A any](g *Graph, flag Incr[bool], input Incr[A]) ObserveIncr[A] {
+	o, err := ObserveIf(g, flag, input)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// ObserveIf observes a node only while a given flag incremental is true.
+//
+// While the flag is false the input (and its parents) are not necessary
+// because of the observer and are not recomputed, and the observer value is
+// the zero value of the input type. When the flag becomes true again the input
+// is made necessary and recomputed if it is stale.
+//
+// It is largely a "macro" for a [Bind] over the flag whose bind function returns
+// the input if the flag is true and nil otherwise, which is then observed.
+func ObserveIf[A any](g *Graph, flag Incr[bool], input Incr[A]) (ObserveIncr[A], error) {
+	b := Bind(g, flag, func(_ Scope, flagValue bool) Incr[A] {
+		if flagValue {
+			return input
+		}
+		return nil
+	})
+	b.Node().SetKind("observe_if")
+	return Observe[A](g, b)
+}
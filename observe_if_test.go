@@ -0,0 +1,115 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_ObserveIf(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	flag := Var(g, false)
+	v := Var(g, "foo")
+	var recomputes int
+	m := Map(g, v, func(vv string) string {
+		recomputes++
+		return vv + "!"
+	})
+	o := MustObserveIf(g, flag, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "", o.Value())
+	testutil.Equal(t, false, m.Node().isNecessary())
+	testutil.Equal(t, uint64(0), m.Node().recomputedAt)
+	testutil.Equal(t, 0, recomputes)
+
+	flag.Set(true)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo!", o.Value())
+	testutil.Equal(t, true, m.Node().isNecessary())
+	testutil.Equal(t, 1, recomputes)
+
+	v.Set("bar")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar!", o.Value())
+	testutil.Equal(t, 2, recomputes)
+
+	flag.Set(false)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "", o.Value())
+	testutil.Equal(t, false, m.Node().isNecessary())
+	testutil.Equal(t, false, g.Has(m))
+
+	recomputedAt := m.Node().recomputedAt
+	v.Set("baz")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "", o.Value())
+	testutil.Equal(t, recomputedAt, m.Node().recomputedAt)
+	testutil.Equal(t, 2, recomputes)
+
+	flag.Set(true)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "baz!", o.Value())
+	testutil.Equal(t, 3, recomputes)
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_ObserveIf_sharedInput(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	flag := Var(g, true)
+	v := Var(g, "foo")
+	m := Map(g, v, ident)
+	oif := MustObserveIf(g, flag, m)
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", oif.Value())
+	testutil.Equal(t, "foo", o.Value())
+
+	flag.Set(false)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "", oif.Value())
+	testutil.Equal(t, true, m.Node().isNecessary(), "the input is still observed directly")
+
+	v.Set("bar")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", o.Value())
+}
+
+func Test_ObserveIf_error(t *testing.T) {
+	g0 := New()
+	g1 := New()
+	flag := Var(g1, true)
+	v := Var(g0, "foo")
+
+	o, err := ObserveIf(g0, flag, v)
+	testutil.Nil(t, o)
+	testutil.Error(t, err)
+}
+
+func Test_MustObserveIf_panic(t *testing.T) {
+	g0 := New()
+	g1 := New()
+	flag := Var(g1, true)
+	v := Var(g0, "foo")
+
+	var recovered any
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		_ = MustObserveIf(g0, flag, v)
+	}()
+	testutil.NotNil(t, recovered)
+}
@@ -0,0 +1,80 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// MustObserveMany observes many nodes at once, returning an observer for each
+// distinct node in the order they were passed.
+//
+// If this detects a cycle or any other issue a panic will be raised.
+func (graph *Graph) MustObserveMany(ctx context.Context, nodes ...INode) []IObserver {
+	observers, err := graph.ObserveMany(ctx, nodes...)
+	if err != nil {
+		panic(err)
+	}
+	return observers
+}
+
+// ObserveMany observes many nodes at once, returning an observer for each
+// distinct node in the order they were passed.
+//
+// Nodes that are passed more than once are only observed once. If any of
+// the nodes fail to be observed, the observers created before the failure
+// are unobserved along with the failed node and the error is returned.
+//
+// Use [Observe] if you need typed access to the observed node's value.
+func (graph *Graph) ObserveMany(ctx context.Context, nodes ...INode) ([]IObserver, error) {
+	seen := make(map[Identifier]struct{}, len(nodes))
+	observers := make([]IObserver, 0, len(nodes))
+	for _, n := range nodes {
+		if _, ok := seen[n.Node().id]; ok {
+			continue
+		}
+		seen[n.Node().id] = struct{}{}
+		o := WithinScope(graph, &observeManyIncr{
+			n:        NewNode("observer"),
+			observed: n,
+		})
+		if err := graph.observeNode(o, n); err != nil {
+			if graph.HasObserver(o) {
+				graph.unobserveNode(o, n)
+			}
+			for _, created := range observers {
+				created.Unobserve(ctx)
+			}
+			return nil, err
+		}
+		observers = append(observers, o)
+	}
+	return observers, nil
+}
+
+var (
+	_ IObserver    = (*observeManyIncr)(nil)
+	_ fmt.Stringer = (*observeManyIncr)(nil)
+)
+
+// observeManyIncr is an untyped observer used by [Graph.ObserveMany].
+type observeManyIncr struct {
+	n        *Node
+	observed INode
+}
+
+func (o *observeManyIncr) Node() *Node { return o.n }
+
+func (o *observeManyIncr) Unobserve(ctx context.Context) {
+	if o.observed == nil {
+		return
+	}
+	GraphForNode(o).unobserveNode(o, o.observed)
+	o.observed = nil
+}
+
+func (o *observeManyIncr) String() string {
+	if o.n.label != "" {
+		return fmt.Sprintf("%s[%s]:%s", o.n.kind, o.n.id.Short(), o.n.label)
+	}
+	return fmt.Sprintf("%s[%s]", o.n.kind, o.n.id.Short())
+}
@@ -0,0 +1,73 @@
+package incr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_ObserveMany(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v0 := Var(g, "foo")
+	v1 := Var(g, "bar")
+	m0 := Map2(g, v0, v1, concat)
+
+	observers, err := g.ObserveMany(ctx, v0, m0, v1, m0)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, len(observers))
+	testutil.Equal(t, 3, len(g.observers))
+
+	testutil.Equal(t, true, v0.Node().isNecessary())
+	testutil.Equal(t, true, v1.Node().isNecessary())
+	testutil.Equal(t, true, m0.Node().isNecessary())
+	testutil.Equal(t, 1, len(m0.Node().observers))
+
+	testutil.Matches(t, `observer\[(.*)\]`, fmt.Sprint(observers[0]))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foobar", m0.Value())
+
+	for _, o := range observers {
+		o.Unobserve(ctx)
+	}
+	testutil.Equal(t, 0, len(g.observers))
+	testutil.Equal(t, false, m0.Node().isNecessary())
+	testutil.Equal(t, false, v0.Node().isNecessary())
+}
+
+func Test_Graph_ObserveMany_error(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphMaxHeight(4))
+	v := Var(g, "foo")
+	m0 := Map(g, v, ident)
+	m1 := Map(g, m0, ident)
+	m2 := Map(g, m1, ident)
+	m3 := Map(g, m2, ident)
+
+	observers, err := g.ObserveMany(ctx, m0, m3)
+	testutil.Error(t, err)
+	testutil.Nil(t, observers)
+	testutil.Equal(t, false, m0.Node().isNecessary())
+}
+
+func Test_Graph_MustObserveMany_panic(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphMaxHeight(4))
+	v := Var(g, "foo")
+	m0 := Map(g, v, ident)
+	m1 := Map(g, m0, ident)
+	m2 := Map(g, m1, ident)
+	m3 := Map(g, m2, ident)
+
+	var recovered any
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		_ = g.MustObserveMany(ctx, m3)
+	}()
+	testutil.NotNil(t, recovered)
+}
@@ -0,0 +1,106 @@
+package incr
+
+import (
+	"context"
+	"sync"
+)
+
+// SubscribeOption mutates [SubscribeOptions].
+type SubscribeOption func(*SubscribeOptions)
+
+// SubscribeWithBlocking sets if sends to a subscription channel should block
+// until the receiver is ready, rather than drop values when the channel is full.
+//
+// Sends happen after the stabilization has released the graph for readers (see [Graph.Read]),
+// but a blocked send will delay the return of the stabilization call until the
+// value is received or the subscription is canceled.
+func SubscribeWithBlocking(blocking bool) func(*SubscribeOptions) {
+	return func(so *SubscribeOptions) {
+		so.Blocking = blocking
+	}
+}
+
+// SubscribeOptions are options for [ObserveIncr.Subscribe].
+type SubscribeOptions struct {
+	Blocking bool
+}
+
+func (o *observeIncr[A]) Subscribe(buffer int, opts ...SubscribeOption) (<-chan A, func()) {
+	var options SubscribeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	sub := &observeSubscription[A]{
+		ch:       make(chan A, max(buffer, 0)),
+		done:     make(chan struct{}),
+		blocking: options.Blocking,
+	}
+	o.subscribersMu.Lock()
+	if o.subscribers == nil {
+		o.subscribers = make(map[*observeSubscription[A]]struct{})
+		o.n.OnUpdate(o.notifySubscribers)
+	}
+	o.subscribers[sub] = struct{}{}
+	o.subscribersMu.Unlock()
+	return sub.ch, func() {
+		o.subscribersMu.Lock()
+		delete(o.subscribers, sub)
+		o.subscribersMu.Unlock()
+		sub.cancel()
+	}
+}
+
+// notifySubscribers is the update handler that sends the
+// observed value to each of the subscriptions.
+func (o *observeIncr[A]) notifySubscribers(_ context.Context) {
+	value := o.Value()
+	o.subscribersMu.Lock()
+	subs := make([]*observeSubscription[A], 0, len(o.subscribers))
+	for sub := range o.subscribers {
+		subs = append(subs, sub)
+	}
+	o.subscribersMu.Unlock()
+	for _, sub := range subs {
+		sub.send(value)
+	}
+}
+
+type observeSubscription[A any] struct {
+	mu         sync.Mutex
+	cancelOnce sync.Once
+	ch         chan A
+	done       chan struct{}
+	blocking   bool
+	canceled   bool
+}
+
+func (s *observeSubscription[A]) send(value A) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.canceled {
+		return
+	}
+	if s.blocking {
+		select {
+		case s.ch <- value:
+		case <-s.done:
+		}
+		return
+	}
+	select {
+	case s.ch <- value:
+	default:
+	}
+}
+
+func (s *observeSubscription[A]) cancel() {
+	s.cancelOnce.Do(func() {
+		// close done first so that any blocked send is released
+		// before we close the channel.
+		close(s.done)
+		s.mu.Lock()
+		s.canceled = true
+		close(s.ch)
+		s.mu.Unlock()
+	})
+}
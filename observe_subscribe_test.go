@@ -0,0 +1,142 @@
+package incr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Observe_Subscribe(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	m := Map(g, v, ident)
+	o := MustObserve(g, m)
+
+	buffered, cancelBuffered := o.Subscribe(3)
+	defer cancelBuffered()
+	drained, cancelDrained := o.Subscribe(1)
+	defer cancelDrained()
+
+	var drainedValues []string
+	for _, value := range []string{"a", "b", "c"} {
+		v.Set(value)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		drainedValues = append(drainedValues, <-drained)
+	}
+	testutil.Equal(t, []string{"a", "b", "c"}, drainedValues)
+	testutil.Equal(t, 3, len(buffered))
+	testutil.Equal(t, "a", <-buffered)
+	testutil.Equal(t, "b", <-buffered)
+	testutil.Equal(t, "c", <-buffered)
+}
+
+func Test_Observe_Subscribe_drops(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	o := MustObserve(g, Map(g, v, ident))
+
+	values, cancel := o.Subscribe(1)
+	defer cancel()
+
+	for _, value := range []string{"a", "b", "c"} {
+		v.Set(value)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+	}
+	testutil.Equal(t, 1, len(values))
+	testutil.Equal(t, "a", <-values)
+}
+
+func Test_Observe_Subscribe_unchanged(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	o := MustObserve(g, Map(g, v, ident))
+
+	values, cancel := o.Subscribe(4)
+	defer cancel()
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, len(values))
+}
+
+func Test_Observe_Subscribe_cancel(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	o := MustObserve(g, Map(g, v, ident))
+
+	values, cancel := o.Subscribe(4)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	cancel()
+	cancel()
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	var received []string
+	for value := range values {
+		received = append(received, value)
+	}
+	testutil.Equal(t, []string{"a"}, received)
+}
+
+func Test_Observe_Subscribe_blocking(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	o := MustObserve(g, Map(g, v, ident))
+
+	values, cancel := o.Subscribe(0, SubscribeWithBlocking(true))
+
+	received := make(chan []string)
+	go func() {
+		var output []string
+		for value := range values {
+			output = append(output, value)
+		}
+		received <- output
+	}()
+
+	for _, value := range []string{"a", "b", "c"} {
+		v.Set(value)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+	}
+	cancel()
+	testutil.Equal(t, []string{"a", "b", "c"}, <-received)
+}
+
+func Test_Observe_Subscribe_blocking_cancel(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	o := MustObserve(g, Map(g, v, ident))
+
+	_, cancel := o.Subscribe(0, SubscribeWithBlocking(true))
+
+	stabilized := make(chan error)
+	go func() {
+		stabilized <- g.Stabilize(ctx)
+	}()
+
+	select {
+	case <-stabilized:
+		t.Fatal("expected stabilize to block on the subscriber")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel()
+	err := <-stabilized
+	testutil.NoError(t, err)
+}
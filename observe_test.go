@@ -123,6 +123,70 @@ func Test_Observe_unobserve_multi(t *testing.T) {
 	testutil.Equal(t, -1, o1.Node().height)
 }
 
+func Test_Observe_Reobserve(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "foo")
+	m0 := Map(g, v, ident)
+	o0 := MustObserve(g, v)
+	o1 := MustObserve(g, m0)
+
+	testutil.Equal(t, m0, o1.Observed())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", o1.Value())
+
+	numNodes := g.numNodes
+	numObserved := len(g.nodes)
+	numObservers := len(g.observers)
+
+	// reobserving a live observer is a no-op.
+	err = o1.Reobserve(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, numNodes, g.numNodes)
+	testutil.Equal(t, numObserved, len(g.nodes))
+	testutil.Equal(t, numObservers, len(g.observers))
+
+	o1.Unobserve(ctx)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "", o1.Value())
+	testutil.Equal(t, "foo", o0.Value())
+	testutil.Equal(t, false, m0.Node().isNecessary())
+	testutil.Equal(t, m0, o1.Observed())
+
+	// unobserving twice is a no-op.
+	o1.Unobserve(ctx)
+
+	v.Set("bar")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", o0.Value())
+	testutil.Equal(t, "", o1.Value())
+
+	err = o1.Reobserve(ctx)
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", o1.Value())
+	testutil.Equal(t, numNodes, g.numNodes)
+	testutil.Equal(t, numObserved, len(g.nodes))
+	testutil.Equal(t, numObservers, len(g.observers))
+	testutil.Equal(t, 1, m0.Node().height)
+
+	var updates int
+	o1.OnUpdate(func(_ context.Context, _ string) {
+		updates++
+	})
+	v.Set("baz")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "baz", o1.Value())
+	testutil.Equal(t, 1, updates)
+	testutil.NoError(t, g.Validate())
+}
+
 func Test_Observe_unobserve_var(t *testing.T) {
 	g := New()
 	v := Var(g, "foo")
@@ -217,3 +281,110 @@ func Test_Observe_onUpdate(t *testing.T) {
 	testutil.Equal(t, 2, updateCalls)
 	testutil.Equal(t, []string{"foo", "not-foo"}, gotValues)
 }
+
+func Test_ObserveWeak(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	var calls int
+	m := Map(g, v, func(vv string) string {
+		calls++
+		return vv + "!"
+	})
+	w := ObserveWeak(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, calls, "weak observers should not make the node necessary")
+	testutil.Equal(t, "", w.Value())
+	testutil.Equal(t, false, m.Node().isNecessary())
+	testutil.Equal(t, false, g.Has(m))
+	testutil.NoError(t, g.Validate())
+
+	o := MustObserve(g, m)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+	testutil.Equal(t, "hello!", w.Value())
+
+	v.Set("world")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, calls)
+	testutil.Equal(t, "world!", w.Value())
+
+	// once the strong observer is gone the weak observer keeps
+	// the last computed value, and the node isn't recomputed.
+	o.Unobserve(ctx)
+	v.Set("again")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, calls)
+	testutil.Equal(t, "world!", w.Value())
+	testutil.NoError(t, g.Validate())
+
+	value, _ := w.ValueStable()
+	testutil.Equal(t, "world!", value)
+}
+
+func Test_ObserveWeak_onUpdate(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	m := Map(g, v, func(vv string) string { return vv + "!" })
+	w := ObserveWeak(g, m)
+
+	var updates []string
+	w.OnUpdate(func(_ context.Context, value string) {
+		updates = append(updates, value)
+	})
+	var strongUpdates int
+	o := MustObserve(g, m)
+	o.OnUpdate(func(_ context.Context, _ string) {
+		strongUpdates++
+	})
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"hello!"}, updates)
+	testutil.Equal(t, 1, strongUpdates)
+
+	v.Set("world")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"hello!", "world!"}, updates)
+	testutil.Equal(t, 2, strongUpdates)
+
+	w.Unobserve(ctx)
+	testutil.Equal(t, "", w.Value())
+
+	v.Set("again")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"hello!", "world!"}, updates)
+
+	err = w.Reobserve(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "again!", w.Value())
+
+	v.Set("last")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"hello!", "world!", "last!"}, updates)
+}
+
+func Test_ObserveWeak_var(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	w := ObserveWeak(g, v)
+	testutil.Equal(t, "", w.Value())
+
+	_ = MustObserve(g, v)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello", w.Value())
+}
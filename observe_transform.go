@@ -0,0 +1,66 @@
+package incr
+
+import "context"
+
+// ObserverView is a read-only view of the value of an observer,
+// e.g. as returned by [TransformObserver].
+//
+// Every [ObserveIncr] is also an ObserverView.
+type ObserverView[A any] interface {
+	// Value returns the value, or the zero value if the
+	// underlying observer has been unobserved.
+	Value() A
+	// ValueOK returns the value and true, or the zero value and
+	// false if the underlying observer has been unobserved.
+	ValueOK() (A, bool)
+	// OnUpdate lets you register an update handler that is called with the
+	// value after each stabilization in which the observed node is recomputed.
+	OnUpdate(func(context.Context, A))
+}
+
+// TransformObserver returns a view of an observer (or of another view) that applies
+// a function to its value, e.g. to format the value for presentation.
+//
+// Unlike [Map], the view does not add a node to the graph, and it has no effect on
+// stabilization; the function is applied each time the value is read, and as such
+// should be cheap to call. Update handlers registered on the view are registered
+// on the underlying observer.
+//
+// The view keeps working after the underlying observer is unobserved,
+// returning the zero value (see [ObserverView.ValueOK]).
+func TransformObserver[A, B any](o ObserverView[A], fn func(A) B) ObserverView[B] {
+	return &transformObserver[A, B]{
+		source: o,
+		fn:     fn,
+	}
+}
+
+var (
+	_ ObserverView[int] = (*transformObserver[string, int])(nil)
+	_ ObserverView[int] = (ObserveIncr[int])(nil)
+)
+
+type transformObserver[A, B any] struct {
+	source ObserverView[A]
+	fn     func(A) B
+}
+
+func (t *transformObserver[A, B]) Value() (output B) {
+	output, _ = t.ValueOK()
+	return
+}
+
+func (t *transformObserver[A, B]) ValueOK() (output B, ok bool) {
+	var value A
+	if value, ok = t.source.ValueOK(); !ok {
+		return
+	}
+	output = t.fn(value)
+	return
+}
+
+func (t *transformObserver[A, B]) OnUpdate(fn func(context.Context, B)) {
+	t.source.OnUpdate(func(ctx context.Context, value A) {
+		fn(ctx, t.fn(value))
+	})
+}
@@ -0,0 +1,76 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_TransformObserver(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 1.5)
+	o := MustObserve(g, Map(g, v, ident))
+
+	celsius := TransformObserver(o, func(f float64) float64 { return f * 10 })
+	formatted := TransformObserver(celsius, func(c float64) string { return fmt.Sprintf("%.1fC", c) })
+
+	var updates []string
+	formatted.OnUpdate(func(_ context.Context, value string) {
+		updates = append(updates, value)
+	})
+	numNodes := g.numNodes
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 15.0, celsius.Value())
+	testutil.Equal(t, "15.0C", formatted.Value())
+	testutil.Equal(t, numNodes, g.numNodes)
+
+	v.Set(2.25)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 22.5, celsius.Value())
+	value, ok := formatted.ValueOK()
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, "22.5C", value)
+	testutil.Equal(t, []string{"15.0C", "22.5C"}, updates)
+
+	o.Unobserve(ctx)
+	testutil.Equal(t, 0.0, celsius.Value())
+	testutil.Equal(t, "", formatted.Value())
+	value, ok = formatted.ValueOK()
+	testutil.Equal(t, false, ok)
+	testutil.Equal(t, "", value)
+
+	err = o.Reobserve(ctx)
+	testutil.NoError(t, err)
+	v.Set(3.0)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "30.0C", formatted.Value())
+}
+
+func Test_Observe_ValueOK(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "hello")
+	o := MustObserve(g, v)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	value, ok := o.ValueOK()
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, "hello", value)
+
+	weak := ObserveWeak(g, Map(g, v, ident))
+	_, ok = weak.ValueOK()
+	testutil.Equal(t, false, ok)
+
+	o.Unobserve(ctx)
+	value, ok = o.ValueOK()
+	testutil.Equal(t, false, ok)
+	testutil.Equal(t, "", value)
+}
@@ -0,0 +1,41 @@
+package incr
+
+// Option is a value that may or may not be present.
+//
+// It is used by [Map2Option] to model values that are
+// not yet available without resorting to nil pointers.
+type Option[A any] struct {
+	Value A
+	Valid bool
+}
+
+// Some returns an [Option] with a given value present.
+func Some[A any](value A) Option[A] {
+	return Option[A]{Value: value, Valid: true}
+}
+
+// None returns an [Option] with no value present.
+func None[A any]() Option[A] {
+	return Option[A]{}
+}
+
+// Get returns the value of the option and if it is present.
+func (o Option[A]) Get() (value A, ok bool) {
+	return o.Value, o.Valid
+}
+
+// Map2Option applies a function to the values of two [Option] input incrementals
+// and returns a new [Option] incremental.
+//
+// If either input is None, the output is None and the function is not called;
+// if both inputs are present, the output is Some of the function's result.
+func Map2Option[A, B, C any](scope Scope, a Incr[Option[A]], b Incr[Option[B]], fn func(A, B) C) Incr[Option[C]] {
+	m := Map2(scope, a, b, func(oa Option[A], ob Option[B]) Option[C] {
+		if !oa.Valid || !ob.Valid {
+			return None[C]()
+		}
+		return Some(fn(oa.Value, ob.Value))
+	})
+	m.Node().SetKind("map2_option")
+	return m
+}
@@ -0,0 +1,53 @@
+package incr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Option(t *testing.T) {
+	value, ok := Some("hello").Get()
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, "hello", value)
+
+	value, ok = None[string]().Get()
+	testutil.Equal(t, false, ok)
+	testutil.Equal(t, "", value)
+}
+
+func Test_Map2Option(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	a := Var(g, None[*int]())
+	b := Var(g, Some("value"))
+
+	var calls int
+	m := Map2Option(g, a, b, func(av *int, bv string) string {
+		calls++
+		return fmt.Sprintf("%s=%d", bv, *av)
+	})
+	om := MustObserve(g, m)
+
+	testutil.Equal(t, "map2_option", m.Node().Kind())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, calls)
+	testutil.Equal(t, false, om.Value().Valid)
+
+	one := 1
+	a.Set(Some(&one))
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+	testutil.Equal(t, Some("value=1"), om.Value())
+
+	b.Set(None[string]())
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+	testutil.Equal(t, None[string](), om.Value())
+}
@@ -0,0 +1,88 @@
+package incr
+
+import "context"
+
+// OrphanedNodes returns the nodes the graph is tracking that are not reachable
+// from any observer (or sentinel) by walking the observed nodes' parents.
+//
+// Orphaned nodes typically indicate a leak, e.g. a custom node that links
+// a parent with [Link] without also returning it from its [IParents]
+// implementation, so that the parent is not unlinked when the node is
+// unobserved.
+func (graph *Graph) OrphanedNodes() (output []NodeMetadata) {
+	for _, n := range graph.orphanedNodes() {
+		output = append(output, n.Node().nodeMetadata())
+	}
+	return
+}
+
+// CollectOrphans removes the nodes returned by [Graph.OrphanedNodes] from the graph
+// with [Graph.GC], returning the number of nodes removed.
+//
+// Orphaned nodes are kept necessary by children that are no longer tracked by the
+// graph (or are orphaned themselves), so they're first unlinked from their children,
+// invalidating [Bind] nodes among them, such that the sweep collects them.
+//
+// There are no invalidation handlers for nodes in general, so rather than invalidation
+// handlers, the collected nodes fire the handlers that fire when [Graph.GC] removes a
+// node: the handlers added with [Graph.OnNodeRemoved] for each collected node, and the
+// handlers added with [Node.OnUnobserved] for each collected node that was observed.
+//
+// CollectOrphans cannot be called while the graph is stabilizing, in which
+// case [ErrAlreadyStabilizing] is returned and no nodes are collected.
+func (graph *Graph) CollectOrphans(ctx context.Context) (collected int, err error) {
+	if err = graph.ensureNotStabilizing(ctx); err != nil {
+		return
+	}
+	for _, n := range graph.orphanedNodes() {
+		nn := n.Node()
+		nn.maybeInvalidate()
+		for _, c := range copySlice(nn.children) {
+			graph.unlink(c, n)
+		}
+	}
+	return graph.GC(ctx)
+}
+
+// orphanedNodes returns the nodes tracked by the graph that are not
+// reachable from any observer or sentinel.
+func (graph *Graph) orphanedNodes() (output []INode) {
+	reachable := make(map[Identifier]struct{})
+	pending := new(queue[INode])
+
+	// observers are not linked to the nodes they observe as children,
+	// so we start the walk from the nodes that have observers.
+	graph.nodesMu.Lock()
+	for id, n := range graph.nodes {
+		if len(n.Node().observers) > 0 {
+			reachable[id] = struct{}{}
+			pending.push(n)
+		}
+	}
+	graph.nodesMu.Unlock()
+	graph.sentinelsMu.Lock()
+	for _, s := range graph.sentinels {
+		pending.push(s)
+	}
+	graph.sentinelsMu.Unlock()
+
+	for pending.len() > 0 {
+		n, _ := pending.pop()
+		for _, p := range n.Node().parents {
+			if _, ok := reachable[p.Node().id]; ok {
+				continue
+			}
+			reachable[p.Node().id] = struct{}{}
+			pending.push(p)
+		}
+	}
+
+	graph.nodesMu.Lock()
+	defer graph.nodesMu.Unlock()
+	for id, n := range graph.nodes {
+		if _, ok := reachable[id]; !ok {
+			output = append(output, n)
+		}
+	}
+	return
+}
@@ -0,0 +1,154 @@
+package incr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_OrphanedNodes_bindChurn(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v1 := Var(g, 1)
+	v2 := Var(g, 2)
+	v3 := Var(g, 3)
+	v4 := Var(g, 4)
+
+	o := MustObserve(g, Bind4(g, v1, v2, v3, v4, func(bs Scope, x1, x2, x3, x4 int) Incr[int] {
+		return Bind3(bs, v2, v3, v3, func(bs Scope, y2, y3, y4 int) Incr[int] {
+			return Bind2(bs, v4, v4, func(bs Scope, z3, z4 int) Incr[int] {
+				return Bind(bs, v4, func(bs Scope, w4 int) Incr[int] {
+					return Return(bs, x1+x2+x3+x4+y2+y3+y4+z3+z4+w4)
+				})
+			})
+		})
+	}))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Empty(t, g.OrphanedNodes())
+
+	for x := 0; x < 8; x++ {
+		switch x % 4 {
+		case 0:
+			v1.Set(v1.Value() + 1)
+		case 1:
+			v2.Set(v2.Value() + 1)
+		case 2:
+			v3.Set(v3.Value() + 1)
+		case 3:
+			v4.Set(v4.Value() + 1)
+		}
+		err = g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, v1.Value()+(2*v2.Value())+(3*v3.Value())+(4*v4.Value()), o.Value())
+		testutil.Empty(t, g.OrphanedNodes())
+	}
+	collected, err := g.CollectOrphans(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, collected)
+}
+
+func Test_Graph_CollectOrphans_strayLink(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	lt := newLinkTestIncr(g, v0)
+	o := MustObserve(g, lt)
+
+	v1 := Var(g, "b")
+	stray := Map(g, v1, ident)
+	stray.Node().SetLabel("stray")
+	strayObserver := MustObserve(g, stray)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	// link the stray node without adding it to the
+	// link test node's parents, then drop the stray's observer.
+	err = Link(lt, stray)
+	testutil.NoError(t, err)
+	strayObserver.Unobserve(ctx)
+	testutil.Empty(t, g.OrphanedNodes())
+
+	// unobserving the link test node does not unlink the
+	// stray node because it is not one of the node's parents.
+	o.Unobserve(ctx)
+	testutil.Equal(t, true, g.Has(stray))
+	testutil.Equal(t, true, g.Has(v1))
+
+	orphans := g.OrphanedNodes()
+	testutil.Equal(t, 2, len(orphans))
+	testutil.Any(t, orphans, func(nm NodeMetadata) bool {
+		return nm.ID == stray.Node().ID() && nm.Label == "stray"
+	})
+	testutil.Any(t, orphans, func(nm NodeMetadata) bool {
+		return nm.ID == v1.Node().ID()
+	})
+
+	collected, err := g.CollectOrphans(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, collected)
+	testutil.Equal(t, false, g.Has(stray))
+	testutil.Equal(t, false, g.Has(v1))
+	testutil.Empty(t, stray.Node().Children())
+	testutil.Empty(t, v1.Node().Children())
+	testutil.Empty(t, g.OrphanedNodes())
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_Graph_CollectOrphans_handlers(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	lt := newLinkTestIncr(g, v0)
+	o := MustObserve(g, lt)
+
+	v1 := Var(g, "b")
+	stray := Map(g, v1, ident)
+	strayObserver := MustObserve(g, stray)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	err = Link(lt, stray)
+	testutil.NoError(t, err)
+	strayObserver.Unobserve(ctx)
+	o.Unobserve(ctx)
+
+	unobserved := make(map[Identifier]int)
+	for _, n := range []INode{stray, v1} {
+		id := n.Node().ID()
+		n.Node().OnUnobserved(func(_ context.Context) {
+			unobserved[id]++
+		})
+	}
+	removed := make(map[Identifier]int)
+	g.OnNodeRemoved(func(n INode) {
+		removed[n.Node().ID()]++
+	})
+
+	collected, err := g.CollectOrphans(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, collected)
+
+	// each collected node fires its unobserved handlers
+	// and the graph's node removed handlers once.
+	expected := map[Identifier]int{stray.Node().ID(): 1, v1.Node().ID(): 1}
+	testutil.Equal(t, expected, unobserved)
+	testutil.Equal(t, expected, removed)
+}
+
+func Test_Graph_CollectOrphans_duringStabilization(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	g.status = StatusStabilizing
+
+	collected, err := g.CollectOrphans(ctx)
+	testutil.Equal(t, ErrAlreadyStabilizing, err)
+	testutil.Equal(t, 0, collected)
+}
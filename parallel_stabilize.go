@@ -3,6 +3,7 @@ package incr
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 )
 
 // ParallelStabilize stabilizes a graph in parallel.
@@ -19,7 +20,12 @@ import (
 // You should only reach for [Graph.ParallelStabilize] if you have very long running node recomputations
 // that would benefit from processing in parallel, e.g. if you have nodes that are I/O bound or CPU intensive.
 func (graph *Graph) ParallelStabilize(ctx context.Context) (err error) {
-	if err = graph.ensureNotStabilizing(ctx); err != nil {
+	if err = graph.claimStabilization(ctx); err != nil {
+		return
+	}
+	if graph.skipEmptyStabilizations && !graph.NeedsStabilization() {
+		graph.lastStabilizeChanged = false
+		atomic.StoreInt32(&graph.status, StatusNotStabilizing)
 		return
 	}
 	ctx = graph.stabilizeStart(ctx)
@@ -34,6 +40,8 @@ func (graph *Graph) parallelStabilize(ctx context.Context) (err error) {
 	if graph.recomputeHeap.len() == 0 {
 		return
 	}
+	graph.readMu.Lock()
+	defer graph.readMu.Unlock()
 
 	var immediateRecompute []INode
 	var immediateRecomputeMu sync.Mutex
@@ -56,19 +64,13 @@ func (graph *Graph) parallelStabilize(ctx context.Context) (err error) {
 		}
 	}
 	if err != nil {
-		if graph.clearRecomputeHeapOnError {
-			aborted := graph.recomputeHeap.clear()
-			for _, node := range aborted {
-				for _, ah := range node.Node().onAbortedHandlers {
-					ah(ctx, err)
-				}
-			}
-		}
+		graph.stabilizeHandleError(ctx, err)
 	}
 	if len(immediateRecompute) > 0 {
 		graph.recomputeHeap.mu.Lock()
 		for _, n := range immediateRecompute {
 			if n.Node().heightInRecomputeHeap == HeightUnset {
+				n.Node().recomputeReason = RecomputeReasonAlways
 				graph.recomputeHeap.addNodeUnsafe(n)
 			}
 		}
@@ -3,6 +3,7 @@ package incr
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -373,5 +374,5 @@ func Test_ParallelStabilize_alwaysInRecomputeHeapOnError(t *testing.T) {
 
 	err := g.ParallelStabilize(testContext())
 	testutil.Error(t, err)
-	testutil.Equal(t, "this is only a test", err.Error())
+	testutil.Equal(t, "this is only a test", errors.Unwrap(err).Error())
 }
@@ -0,0 +1,72 @@
+package incr
+
+// NodeMetadata is a snapshot of the identifying metadata of a node.
+type NodeMetadata struct {
+	ID     Identifier
+	Kind   string
+	Label  string
+	Height int
+	// RecomputeReason is the reason the node is queued for recomputation
+	// if it is in the recompute heap, or the reason it was last recomputed.
+	RecomputeReason RecomputeReason
+}
+
+// PendingRecompute returns a snapshot of the nodes that are currently
+// queued for recomputation, that is, the contents of the recompute heap,
+// in ascending height order.
+//
+// The heights returned are the heights the nodes are queued at in the recompute heap.
+//
+// This does not modify the recompute heap, and can be used to inspect what
+// a call to [Graph.Stabilize] would start with.
+func (graph *Graph) PendingRecompute() (output []NodeMetadata) {
+	pending := graph.recomputeHeap.nodes()
+	output = make([]NodeMetadata, 0, len(pending))
+	for _, n := range pending {
+		nn := n.Node()
+		output = append(output, NodeMetadata{
+			ID:              nn.id,
+			Kind:            nn.kind,
+			Label:           nn.label,
+			Height:          nn.heightInRecomputeHeap,
+			RecomputeReason: nn.recomputeReason,
+		})
+	}
+	return
+}
+
+// WouldAffectObservers returns the observers that are reachable from the nodes
+// currently queued for recomputation, that is, the observers that may
+// see updated values after the next call to [Graph.Stabilize].
+//
+// No stabilize functions are called, and as a result cutoffs are not
+// considered; the observers returned are the upper bound of what could update.
+//
+// This does not modify the recompute heap.
+func (graph *Graph) WouldAffectObservers() (output []IObserver) {
+	seen := make(map[Identifier]struct{})
+	seenObservers := make(map[Identifier]struct{})
+	q := new(queue[INode])
+	for _, n := range graph.recomputeHeap.nodes() {
+		seen[n.Node().id] = struct{}{}
+		q.push(n)
+	}
+	for q.len() > 0 {
+		n, _ := q.pop()
+		for _, o := range n.Node().observers {
+			if _, ok := seenObservers[o.Node().id]; ok {
+				continue
+			}
+			seenObservers[o.Node().id] = struct{}{}
+			output = append(output, o)
+		}
+		for _, c := range n.Node().children {
+			if _, ok := seen[c.Node().id]; ok {
+				continue
+			}
+			seen[c.Node().id] = struct{}{}
+			q.push(c)
+		}
+	}
+	return
+}
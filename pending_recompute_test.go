@@ -0,0 +1,94 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_PendingRecompute_WouldAffectObservers(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	// a -> b -> c -> d -> z
+	//   -> f -> e -> [z]
+	// y -> x
+
+	edge := func(l string) func(string) string {
+		return func(v string) string {
+			return v + "->" + l
+		}
+	}
+
+	a := Var(g, "a")
+	b := Map(g, a, edge("b"))
+	c := Map(g, b, edge("c"))
+	d := Map(g, c, edge("d"))
+	f := Map(g, a, edge("f"))
+	e := Map(g, f, edge("e"))
+	z := Map2(g, d, e, func(v0, v1 string) string {
+		return v0 + "+" + v1 + "->z"
+	})
+	oz := MustObserve(g, z)
+	oc := MustObserve(g, c)
+
+	y := Var(g, "y")
+	x := Map(g, y, edge("x"))
+	ox := MustObserve(g, x)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Empty(t, g.PendingRecompute())
+	testutil.Empty(t, g.WouldAffectObservers())
+
+	a.Set("!a")
+	y.Set("!y")
+
+	pending := g.PendingRecompute()
+	testutil.Equal(t, 2, len(pending))
+	testutil.Any(t, pending, func(nm NodeMetadata) bool { return nm.ID == a.Node().ID() && nm.Height == 0 && nm.Kind == "var" })
+	testutil.Any(t, pending, func(nm NodeMetadata) bool { return nm.ID == y.Node().ID() && nm.Height == 0 })
+
+	affected := g.WouldAffectObservers()
+	testutil.Equal(t, 3, len(affected))
+	testutil.Equal(t, true, hasKey(affected, oz.Node().ID()))
+	testutil.Equal(t, true, hasKey(affected, oc.Node().ID()))
+	testutil.Equal(t, true, hasKey(affected, ox.Node().ID()))
+
+	// the heap should be untouched by the dry run
+	testutil.Equal(t, 2, g.recomputeHeap.len())
+	testutil.NoError(t, g.recomputeHeap.sanityCheck())
+	testutil.Equal(t, "a->b->c->d+a->f->e->z", oz.Value())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "!a->b->c->d+!a->f->e->z", oz.Value())
+	testutil.Equal(t, "!y->x", ox.Value())
+	testutil.Equal(t, 2, z.Node().numRecomputes)
+}
+
+func Test_Graph_WouldAffectObservers_partial(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	a := Var(g, "a")
+	ma := Map(g, a, ident)
+	oa := MustObserve(g, ma)
+
+	b := Var(g, "b")
+	mb := Map(g, b, ident)
+	_ = MustObserve(g, mb)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	a.Set("!a")
+
+	pending := g.PendingRecompute()
+	testutil.Equal(t, 1, len(pending))
+	testutil.Equal(t, a.Node().ID(), pending[0].ID)
+
+	affected := g.WouldAffectObservers()
+	testutil.Equal(t, 1, len(affected))
+	testutil.Equal(t, oa.Node().ID(), affected[0].Node().ID())
+}
@@ -0,0 +1,93 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+)
+
+// Previous returns an incremental whose value is the value the input
+// incremental had before it most recently changed, or the initial value
+// if the input has only been computed once (or not at all).
+//
+// Combined with a map over both the input and the [Previous] node
+// you can compute differences between stabilizations, e.g.
+//
+//	delta := incr.Map2(g, input, incr.Previous(g, input, 0), func(current, previous int) int {
+//		return current - previous
+//	})
+//
+// The [Previous] node is recomputed after the input, so it holds on to the
+// value it read from the input the last time it changed to output on the
+// next change. Recomputations of the node for which the input hasn't changed,
+// e.g. because the node was marked stale, don't advance the node.
+func Previous[A any](scope Scope, input Incr[A], initial A) Incr[A] {
+	return WithinScope(scope, &previousIncr[A]{
+		n:     NewNode("previous"),
+		input: input,
+		value: initial,
+		last:  initial,
+	})
+}
+
+var (
+	_ Incr[string] = (*previousIncr[string])(nil)
+	_ IParents     = (*previousIncr[string])(nil)
+	_ ICutoff      = (*previousIncr[string])(nil)
+	_ IStabilize   = (*previousIncr[string])(nil)
+	_ fmt.Stringer = (*previousIncr[string])(nil)
+)
+
+type previousIncr[A any] struct {
+	n       *Node
+	input   Incr[A]
+	value   A
+	last    A
+	changes inputChanges
+}
+
+func (p *previousIncr[A]) Parents() []INode {
+	return []INode{p.input}
+}
+
+func (p *previousIncr[A]) Node() *Node { return p.n }
+
+func (p *previousIncr[A]) Value() A { return p.value }
+
+func (p *previousIncr[A]) Cutoff(_ context.Context) (bool, error) {
+	return !p.changes.changed(p.input), nil
+}
+
+func (p *previousIncr[A]) Stabilize(_ context.Context) error {
+	if !p.changes.advance(p, p.input) {
+		return nil
+	}
+	p.value = p.last
+	p.last = p.input.Value()
+	return nil
+}
+
+func (p *previousIncr[A]) String() string {
+	return p.n.String()
+}
+
+// Number is a constraint for the numeric types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Delta returns an incremental whose value is the difference between the
+// input incremental's current value and its value before it most recently
+// changed (see [Previous]).
+//
+// For the first computation of the input the previous value is the zero
+// value, such that the delta is the input's value.
+func Delta[A Number](scope Scope, input Incr[A]) Incr[A] {
+	var zero A
+	delta := Map2(scope, input, Previous(scope, input, zero), func(current, previous A) A {
+		return current - previous
+	})
+	delta.Node().SetKind("delta")
+	return delta
+}
@@ -0,0 +1,150 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Previous(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 10)
+	p := Previous(g, v, 0)
+	delta := Map2(g, v, p, func(current, previous int) int {
+		return current - previous
+	})
+	op := MustObserve(g, p)
+	od := MustObserve(g, delta)
+
+	testutil.Matches(t, `previous\[(.*)\]`, p.(*previousIncr[int]).String())
+	testutil.Equal(t, 0, op.Value())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, op.Value())
+	testutil.Equal(t, 10, od.Value())
+
+	v.Set(15)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10, op.Value())
+	testutil.Equal(t, 5, od.Value())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10, op.Value())
+	testutil.Equal(t, 5, od.Value())
+
+	v.Set(12)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 15, op.Value())
+	testutil.Equal(t, -3, od.Value())
+}
+
+func Test_Previous_recomputedWithoutChange(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 1)
+	p := Previous(g, v, 0)
+	d := Delta(g, v)
+	op := MustObserve(g, p)
+	od := MustObserve(g, d)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	v.Set(10)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, op.Value())
+	testutil.Equal(t, 9, od.Value())
+
+	// marking the nodes stale shouldn't advance the previous values.
+	g.SetStale(p)
+	g.SetStale(d.(IParents).Parents()[1])
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, op.Value())
+	testutil.Equal(t, 9, od.Value())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, op.Value())
+	testutil.Equal(t, 9, od.Value())
+
+	v.Set(12)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10, op.Value())
+	testutil.Equal(t, 2, od.Value())
+}
+
+func Test_Previous_parallel(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	m := Map(g, v, func(vv string) string { return vv + "!" })
+	p := Previous(g, m, "")
+	op := MustObserve(g, p)
+
+	err := g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "", op.Value())
+
+	v.Set("b")
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a!", op.Value())
+}
+
+func Test_Delta(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphDeterministicOrdering())
+	v := Var(g, 1.5)
+	d := Delta(g, v)
+	p := Previous(g, v, 0.0)
+	od := MustObserve(g, d)
+	op := MustObserve(g, p)
+
+	testutil.Equal(t, "delta", d.Node().Kind())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1.5, od.Value())
+	testutil.Equal(t, v.Node().Height()+1, p.Node().Height())
+
+	var lagged, deltas []float64
+	for _, value := range []float64{2.5, 2.0, 4.0} {
+		v.Set(value)
+		err = g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		lagged = append(lagged, op.Value())
+		deltas = append(deltas, od.Value())
+	}
+	testutil.Equal(t, []float64{1.5, 2.5, 2.0}, lagged)
+	testutil.Equal(t, []float64{1.0, -0.5, 2.0}, deltas)
+}
+
+func Test_Delta_namedType(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	type cents int64
+	v := Var(g, cents(100))
+	od := MustObserve(g, Delta(g, v))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, cents(100), od.Value())
+
+	v.Set(cents(250))
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, cents(150), od.Value())
+
+	// the delta is unchanged if the input doesn't change.
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, cents(150), od.Value())
+}
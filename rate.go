@@ -0,0 +1,81 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Rate returns an incremental that computes the per-second rate of change
+// of the input incremental between successive values.
+//
+// The rate is advanced each time the input changes, using the given clock
+// to timestamp each value; the rate is zero until a second value is seen.
+// If the clock hasn't advanced between values the previous rate is kept.
+//
+// If the clock is nil, the current UTC time is used.
+func Rate(scope Scope, input Incr[float64], clock func(context.Context) time.Time) Incr[float64] {
+	if clock == nil {
+		clock = func(_ context.Context) time.Time { return time.Now().UTC() }
+	}
+	return WithinScope(scope, &rateIncr{
+		n:           NewNode("rate"),
+		input:       input,
+		clockSource: clock,
+	})
+}
+
+var (
+	_ Incr[float64] = (*rateIncr)(nil)
+	_ IParents      = (*rateIncr)(nil)
+	_ ICutoff       = (*rateIncr)(nil)
+	_ IStabilize    = (*rateIncr)(nil)
+	_ fmt.Stringer  = (*rateIncr)(nil)
+)
+
+type rateIncr struct {
+	n           *Node
+	input       Incr[float64]
+	clockSource func(context.Context) time.Time
+	initialized bool
+	changes     inputChanges
+	last        float64
+	lastAt      time.Time
+	value       float64
+}
+
+func (r *rateIncr) Parents() []INode {
+	return []INode{r.input}
+}
+
+func (r *rateIncr) Node() *Node { return r.n }
+
+func (r *rateIncr) Value() float64 { return r.value }
+
+func (r *rateIncr) Cutoff(_ context.Context) (bool, error) {
+	return !r.changes.changed(r.input), nil
+}
+
+func (r *rateIncr) Stabilize(ctx context.Context) error {
+	if !r.changes.advance(r, r.input) {
+		return nil
+	}
+	now := r.clockSource(ctx)
+	newValue := r.input.Value()
+	if !r.initialized {
+		r.last = newValue
+		r.lastAt = now
+		r.initialized = true
+		return nil
+	}
+	if elapsed := now.Sub(r.lastAt); elapsed > 0 {
+		r.value = (newValue - r.last) / elapsed.Seconds()
+	}
+	r.last = newValue
+	r.lastAt = now
+	return nil
+}
+
+func (r *rateIncr) String() string {
+	return r.n.String()
+}
@@ -0,0 +1,84 @@
+package incr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Rate(t *testing.T) {
+	ctx := testContext()
+	clock := time.Date(2024, 01, 02, 03, 04, 05, 0, time.UTC)
+	g := New()
+	v := Var(g, 100.0)
+	r := Rate(g, v, func(_ context.Context) time.Time { return clock })
+	o := MustObserve(g, r)
+
+	testutil.Matches(t, `rate\[(.*)\]`, r.(*rateIncr).String())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0.0, o.Value())
+
+	clock = clock.Add(2 * time.Second)
+	v.Set(110)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5.0, o.Value())
+
+	// the rate only advances when the input changes.
+	clock = clock.Add(10 * time.Second)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5.0, o.Value())
+
+	v.Set(50)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, -6.0, o.Value())
+
+	// the clock didn't advance so we keep the previous rate.
+	v.Set(60)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, -6.0, o.Value())
+}
+
+func Test_Rate_setStale(t *testing.T) {
+	ctx := testContext()
+	clock := time.Date(2024, 01, 02, 03, 04, 05, 0, time.UTC)
+	g := New()
+	v := Var(g, 100.0)
+	r := Rate(g, v, func(_ context.Context) time.Time { return clock })
+	o := MustObserve(g, r)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	clock = clock.Add(2 * time.Second)
+	v.Set(110)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5.0, o.Value())
+
+	// recomputing the node without the input changing
+	// shouldn't compute a rate against the same value.
+	clock = clock.Add(2 * time.Second)
+	g.SetStale(r)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5.0, o.Value())
+
+	clock = clock.Add(2 * time.Second)
+	v.Set(130)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5.0, o.Value())
+}
+
+func Test_Rate_defaultClock(t *testing.T) {
+	g := New()
+	r := Rate(g, Var(g, 1.0), nil)
+	testutil.NotNil(t, r.(*rateIncr).clockSource)
+}
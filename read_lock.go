@@ -0,0 +1,73 @@
+package incr
+
+import "sync"
+
+// readLock interlocks readers (see [Graph.Read]) with the stabilization
+// passes that update node values.
+//
+// Unlike a [sync.RWMutex], readers are only blocked while a pass holds the lock
+// and not while a pass is waiting for the lock, such that read locks can be
+// nested (e.g. calling [ObserveIncr.ValueStable] from within [Graph.Read])
+// without deadlocking. As a result, a continuous stream of overlapping
+// readers will hold off stabilization passes.
+type readLock struct {
+	mu      sync.Mutex
+	readers int
+	writing bool
+	// released is closed (and reset) each time the lock
+	// is released to wake up goroutines waiting for it.
+	released chan struct{}
+}
+
+func (l *readLock) RLock() {
+	l.mu.Lock()
+	for l.writing {
+		l.waitUnsafe()
+	}
+	l.readers++
+	l.mu.Unlock()
+}
+
+func (l *readLock) RUnlock() {
+	l.mu.Lock()
+	l.readers--
+	if l.readers == 0 {
+		l.releaseUnsafe()
+	}
+	l.mu.Unlock()
+}
+
+func (l *readLock) Lock() {
+	l.mu.Lock()
+	for l.writing || l.readers > 0 {
+		l.waitUnsafe()
+	}
+	l.writing = true
+	l.mu.Unlock()
+}
+
+func (l *readLock) Unlock() {
+	l.mu.Lock()
+	l.writing = false
+	l.releaseUnsafe()
+	l.mu.Unlock()
+}
+
+// waitUnsafe waits for the lock to be released and must
+// be called while holding the mutex, which is reacquired.
+func (l *readLock) waitUnsafe() {
+	if l.released == nil {
+		l.released = make(chan struct{})
+	}
+	released := l.released
+	l.mu.Unlock()
+	<-released
+	l.mu.Lock()
+}
+
+func (l *readLock) releaseUnsafe() {
+	if l.released != nil {
+		close(l.released)
+		l.released = nil
+	}
+}
@@ -0,0 +1,101 @@
+package incr
+
+import (
+	"cmp"
+	"context"
+	"reflect"
+	"slices"
+)
+
+// RecomputeAll marks every node the graph is tracking as stale, except for
+// constant nodes, and stabilizes the graph, recomputing each node from its
+// inputs as if for the first time.
+//
+// Node heights are preserved, and during the stabilization pass nodes are not
+// skipped or cut off, e.g. [Cutoff] nodes pass through their input values
+// regardless of their cutoff functions. Stateful nodes, e.g. [Previous], [EWMA]
+// and [Rate], keep their state, as their inputs haven't actually changed.
+//
+// This is useful if the implementation of a node's function changes at runtime,
+// e.g. when hot-reloading, and the graph's values need to be recomputed from scratch.
+func (graph *Graph) RecomputeAll(ctx context.Context) (err error) {
+	if err = graph.claimStabilization(ctx); err != nil {
+		return
+	}
+	graph.setStaleMany(graph.recomputeAllNodes(), RecomputeReasonSetStale)
+	ctx = graph.stabilizeStart(ctx)
+	graph.recomputingAll = true
+	defer func() {
+		graph.recomputingAll = false
+		graph.stabilizeEnd(ctx, err)
+	}()
+	err = graph.stabilize(ctx)
+	return
+}
+
+// Discrepancy is a node whose incremental value differs from the
+// value it has after recomputing the graph from scratch, as
+// returned by [Graph.VerifyAgainstFullRecompute].
+type Discrepancy struct {
+	Node        NodeMetadata
+	Incremental any
+	Recomputed  any
+}
+
+// VerifyAgainstFullRecompute checks that the graph's incremental values
+// match the values computed from scratch.
+//
+// It first stabilizes the graph, snapshots each node's value, then calls
+// [Graph.RecomputeAll] and returns the nodes whose values differ in node creation order.
+//
+// Values are compared with [reflect.DeepEqual] unless a node has a comparison
+// function set with [Node.SetVerifyEqual].
+//
+// Discrepancies typically indicate incorrect cutoff functions, that is, cutoffs
+// that suppress real changes from propagating through the graph.
+func (graph *Graph) VerifyAgainstFullRecompute(ctx context.Context) ([]Discrepancy, error) {
+	if err := graph.Stabilize(ctx); err != nil {
+		return nil, err
+	}
+	nodes := graph.recomputeAllNodes()
+	slices.SortFunc(nodes, func(a, b INode) int {
+		return cmp.Compare(a.Node().order, b.Node().order)
+	})
+	incremental := make([]any, len(nodes))
+	for index, n := range nodes {
+		incremental[index] = ExpertNode(n).Value()
+	}
+	if err := graph.RecomputeAll(ctx); err != nil {
+		return nil, err
+	}
+	var output []Discrepancy
+	for index, n := range nodes {
+		recomputed := ExpertNode(n).Value()
+		equal := reflect.DeepEqual
+		if n.Node().verifyEqualFn != nil {
+			equal = n.Node().verifyEqualFn
+		}
+		if !equal(incremental[index], recomputed) {
+			output = append(output, Discrepancy{
+				Node:        n.Node().nodeMetadata(),
+				Incremental: incremental[index],
+				Recomputed:  recomputed,
+			})
+		}
+	}
+	return output, nil
+}
+
+// recomputeAllNodes returns the non-constant nodes tracked by the graph.
+func (graph *Graph) recomputeAllNodes() (output []INode) {
+	graph.nodesMu.Lock()
+	defer graph.nodesMu.Unlock()
+	output = make([]INode, 0, len(graph.nodes))
+	for _, n := range graph.nodes {
+		if n.Node().isConstant() {
+			continue
+		}
+		output = append(output, n)
+	}
+	return
+}
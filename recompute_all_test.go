@@ -0,0 +1,193 @@
+package incr
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_RecomputeAll(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "foo")
+	c := Constant(g, "bar")
+	suffix := "-a"
+	m := Map2(g, v, c, func(a, b string) string {
+		return a + b + suffix
+	})
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foobar-a", o.Value())
+
+	heightBefore := m.Node().height
+
+	// simulate the map function changing out from under the graph.
+	suffix = "-b"
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foobar-a", o.Value())
+
+	err = g.RecomputeAll(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foobar-b", o.Value())
+
+	testutil.Equal(t, heightBefore, m.Node().height)
+	testutil.Equal(t, 1, ExpertNode(v).NumRecomputes(), "vars are not recomputed when they're first observed")
+	testutil.Equal(t, 0, ExpertNode(c).NumRecomputes(), "constants should not be recomputed")
+	testutil.Equal(t, 2, ExpertNode(m).NumRecomputes())
+	testutil.Equal(t, false, g.recomputingAll)
+}
+
+func Test_Graph_RecomputeAll_bypassesCutoff(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, 1)
+	// a deliberately wrong cutoff that suppresses every change after the first.
+	co := Cutoff(g, v, func(oldv, _ int) bool { return oldv != 0 })
+	o := MustObserve(g, Map(g, co, func(vv int) int { return vv * 10 }))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10, o.Value())
+
+	v.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10, o.Value())
+
+	err = g.RecomputeAll(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 20, o.Value())
+}
+
+func Test_Graph_RecomputeAll_errorsWhileStabilizing(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	var recomputeAllErr error
+	v := Var(g, "foo")
+	m := MapContext(g, v, func(ctx context.Context, vv string) (string, error) {
+		recomputeAllErr = g.RecomputeAll(ctx)
+		return vv, nil
+	})
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Error(t, recomputeAllErr)
+}
+
+func Test_Graph_VerifyAgainstFullRecompute(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	// a deliberately wrong cutoff that ignores changes to the case of the input.
+	co := Cutoff(g, v, func(oldv, newv string) bool {
+		return strings.EqualFold(oldv, newv)
+	})
+	co.Node().SetLabel("bad-cutoff")
+	m := Map(g, co, func(vv string) string {
+		return vv + "!"
+	})
+	m.Node().SetLabel("downstream")
+	o := MustObserve(g, m)
+
+	discrepancies, err := g.VerifyAgainstFullRecompute(ctx)
+	testutil.NoError(t, err)
+	testutil.Empty(t, discrepancies)
+	testutil.Equal(t, "hello!", o.Value())
+
+	v.Set("HELLO")
+
+	discrepancies, err = g.VerifyAgainstFullRecompute(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, len(discrepancies))
+
+	testutil.Equal(t, co.Node().ID(), discrepancies[0].Node.ID)
+	testutil.Equal(t, "bad-cutoff", discrepancies[0].Node.Label)
+	testutil.Equal(t, "hello", discrepancies[0].Incremental)
+	testutil.Equal(t, "HELLO", discrepancies[0].Recomputed)
+
+	testutil.Equal(t, m.Node().ID(), discrepancies[1].Node.ID)
+	testutil.Equal(t, "downstream", discrepancies[1].Node.Label)
+	testutil.Equal(t, "hello!", discrepancies[1].Incremental)
+	testutil.Equal(t, "HELLO!", discrepancies[1].Recomputed)
+
+	testutil.Equal(t, "HELLO!", o.Value(), "the graph should be left recomputed")
+
+	discrepancies, err = g.VerifyAgainstFullRecompute(ctx)
+	testutil.NoError(t, err)
+	testutil.Empty(t, discrepancies)
+}
+
+func Test_Graph_VerifyAgainstFullRecompute_verifyEqual(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	co := Cutoff(g, v, func(oldv, newv string) bool {
+		return strings.EqualFold(oldv, newv)
+	})
+	m := Map(g, co, func(vv string) string {
+		return vv + "!"
+	})
+	equalFold := func(a, b any) bool {
+		return strings.EqualFold(a.(string), b.(string))
+	}
+	co.Node().SetVerifyEqual(equalFold)
+	m.Node().SetVerifyEqual(equalFold)
+	_ = MustObserve(g, m)
+
+	_, err := g.VerifyAgainstFullRecompute(ctx)
+	testutil.NoError(t, err)
+
+	v.Set("HELLO")
+	discrepancies, err := g.VerifyAgainstFullRecompute(ctx)
+	testutil.NoError(t, err)
+	testutil.Empty(t, discrepancies)
+}
+
+func Test_Graph_VerifyAgainstFullRecompute_stateful(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, 1.0)
+	p := Previous(g, v, 0.0)
+	e := EWMA(g, v, 0.5)
+	op := MustObserve(g, p)
+	oe := MustObserve(g, e)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	v.Set(10)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1.0, op.Value())
+	testutil.Equal(t, 5.5, oe.Value())
+
+	// recomputing the graph from scratch shouldn't advance stateful
+	// nodes as if their inputs had changed again.
+	discrepancies, err := g.VerifyAgainstFullRecompute(ctx)
+	testutil.NoError(t, err)
+	testutil.Empty(t, discrepancies)
+	testutil.Equal(t, 1.0, op.Value())
+	testutil.Equal(t, 5.5, oe.Value())
+
+	err = g.RecomputeAll(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1.0, op.Value())
+	testutil.Equal(t, 5.5, oe.Value())
+
+	v.Set(20)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 10.0, op.Value())
+	testutil.Equal(t, 12.75, oe.Value())
+}
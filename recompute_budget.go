@@ -0,0 +1,31 @@
+package incr
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// recentRecomputeKindsSize is the number of recently recomputed
+// node kinds reported when the recompute budget is exceeded.
+const recentRecomputeKindsSize = 8
+
+// checkRecomputeBudget counts a node that is about to be recomputed against the
+// maximum recomputes per stabilization, returning an error wrapping [ErrRecomputeBudgetExceeded]
+// with the kinds of the most recently recomputed nodes if the budget is exceeded.
+//
+// If the budget is exceeded the node is returned to the recompute heap.
+func (graph *Graph) checkRecomputeBudget(n INode) error {
+	count := atomic.AddUint64(&graph.stabilizationRecomputes, 1)
+	graph.recentRecomputeKindsMu.Lock()
+	defer graph.recentRecomputeKindsMu.Unlock()
+	if count > graph.maxRecomputesPerStabilize {
+		graph.recomputeHeap.addIfNotPresent(n, n.Node().recomputeReason)
+		return fmt.Errorf("%w; limit %d; last recomputed: %s", ErrRecomputeBudgetExceeded, graph.maxRecomputesPerStabilize, strings.Join(graph.recentRecomputeKinds, ", "))
+	}
+	if len(graph.recentRecomputeKinds) == recentRecomputeKindsSize {
+		graph.recentRecomputeKinds = append(graph.recentRecomputeKinds[:0], graph.recentRecomputeKinds[1:]...)
+	}
+	graph.recentRecomputeKinds = append(graph.recentRecomputeKinds, n.Node().kind)
+	return nil
+}
@@ -0,0 +1,74 @@
+package incr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_maxRecomputesPerStabilize(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphMaxRecomputesPerStabilize(5))
+
+	v := Var(g, "a")
+	var chain Incr[string] = v
+	for x := 0; x < 10; x++ {
+		chain = Map(g, chain, ident)
+	}
+	o := MustObserve(g, chain)
+
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, true, errors.Is(err, ErrRecomputeBudgetExceeded))
+	testutil.Equal(t, true, strings.Contains(err.Error(), "limit 5"))
+	testutil.Equal(t, true, strings.Contains(err.Error(), "last recomputed: map, map, map, map, map"))
+	testutil.Equal(t, 5, g.recomputeHeap.len(), "the nodes that were not recomputed should be left in the recompute heap")
+	testutil.Equal(t, "", o.Value())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+}
+
+func Test_Graph_maxRecomputesPerStabilize_runaway(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphMaxRecomputesPerStabilize(100))
+
+	v := Var(g, 0)
+	var counter int
+	var m Incr[int]
+	m = Map(g, v, func(vv int) int {
+		// a logic bug that re-stales the node every time it's recomputed.
+		g.SetStale(m)
+		counter++
+		return vv + counter
+	})
+	m.Node().SetKind("restale")
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, true, errors.Is(err, ErrRecomputeBudgetExceeded))
+	testutil.Equal(t, true, strings.Contains(err.Error(), "last recomputed: restale, restale"))
+	testutil.Equal(t, 100, counter)
+	testutil.Equal(t, true, g.recomputeHeap.has(m))
+}
+
+func Test_Graph_maxRecomputesPerStabilize_unlimited(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphMaxRecomputesPerStabilize(-1))
+
+	v := Var(g, "a")
+	var chain Incr[string] = v
+	for x := 0; x < 200; x++ {
+		chain = Map(g, chain, ident)
+	}
+	o := MustObserve(g, chain)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+	testutil.Equal(t, 0, g.maxRecomputesPerStabilize)
+}
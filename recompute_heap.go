@@ -1,13 +1,15 @@
 package incr
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"sync"
 )
 
 func newRecomputeHeap(maxHeight int) *recomputeHeap {
 	return &recomputeHeap{
-		heights: make([]*recomputeHeapList, maxHeight),
+		lists: newRecomputeHeapDense(maxHeight),
 	}
 }
 
@@ -15,8 +17,59 @@ type recomputeHeap struct {
 	mu        sync.Mutex
 	minHeight int
 	maxHeight int
-	heights   []*recomputeHeapList
 	numItems  int
+	// numPriority is the number of nodes added with priority, used
+	// to order nodes by the most recent time they were added with priority.
+	numPriority uint64
+	// lists holds the list of nodes for each height.
+	lists recomputeHeapLists
+}
+
+// recomputeHeapLists holds the lists of the nodes in a recompute heap by
+// height, and tracks which heights are occupied, that is, have nodes.
+//
+// The methods are called while holding the recompute heap's lock.
+type recomputeHeapLists interface {
+	// listForAdd returns the list for a given height, allocating
+	// the list if needed, and marks the height as occupied.
+	listForAdd(height int) *recomputeHeapList
+	// list returns the list for a given height, or nil if
+	// the list for the height hasn't been allocated.
+	list(height int) *recomputeHeapList
+	// clearOccupied marks a given height as unoccupied.
+	clearOccupied(height int)
+	// nextOccupiedHeight returns the first occupied height at or
+	// above a given height, or [HeightUnset] if there are none.
+	nextOccupiedHeight(from int) int
+	// each calls a given function for each allocated
+	// list in ascending height order.
+	each(fn func(int, *recomputeHeapList))
+	// numHeights returns the number of heights there are lists for.
+	numHeights() int
+	// numAllocated returns the number of lists allocated.
+	numAllocated() int
+	// preallocate allocates the lists for heights up to a given size.
+	preallocate(size int)
+	// reset marks the lists as empty once their items have
+	// been removed, keeping the lists to be reused.
+	reset()
+	// sanityCheck checks that the lists are consistent
+	// with the heights of their items.
+	sanityCheck() error
+}
+
+// preallocateHeightLists allocates the lists for heights up to
+// the given size (or the number of heights, whichever is smaller).
+func (rh *recomputeHeap) preallocateHeightLists(size int) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	rh.lists.preallocate(size)
+}
+
+func (rh *recomputeHeap) numHeightListsAllocated() int {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	return rh.lists.numAllocated()
 }
 
 func (rh *recomputeHeap) clear() (aborted []INode) {
@@ -31,7 +84,7 @@ func (rh *recomputeHeap) clear() (aborted []INode) {
 		aborted = append(aborted, next)
 	}
 
-	rh.heights = make([]*recomputeHeapList, len(rh.heights))
+	rh.lists.reset()
 	rh.minHeight = 0
 	rh.maxHeight = 0
 	rh.numItems = 0
@@ -53,14 +106,43 @@ func (rh *recomputeHeap) add(nodes ...INode) {
 	}
 }
 
-func (rh *recomputeHeap) addIfNotPresent(n INode) {
+// addIfNotPresent adds a node to the heap if it is not already
+// in the heap, recording the reason it was added.
+func (rh *recomputeHeap) addIfNotPresent(n INode, reason RecomputeReason) {
 	rh.mu.Lock()
 	defer rh.mu.Unlock()
 	if n.Node().heightInRecomputeHeap == HeightUnset {
+		n.Node().recomputeReason = reason
 		rh.addNodeUnsafe(n)
 	}
 }
 
+// addManyIfNotPresent adds nodes to the heap that are not already
+// in the heap, recording the reason they were added.
+func (rh *recomputeHeap) addManyIfNotPresent(nodes []INode, reason RecomputeReason) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	for _, n := range nodes {
+		if n.Node().heightInRecomputeHeap == HeightUnset {
+			n.Node().recomputeReason = reason
+			rh.addNodeUnsafe(n)
+		}
+	}
+}
+
+// addFront adds a node to the front of the list for its height so
+// that it is recomputed before other nodes at the same height, moving
+// the node to the front if it is already in the heap.
+func (rh *recomputeHeap) addFront(n INode, reason RecomputeReason) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	if n.Node().heightInRecomputeHeap != HeightUnset {
+		rh.removeNodeUnsafe(n)
+	}
+	n.Node().recomputeReason = reason
+	rh.addNodeFrontUnsafe(n)
+}
+
 func (rh *recomputeHeap) fix(n INode) {
 	rh.mu.Lock()
 	defer rh.mu.Unlock()
@@ -72,12 +154,27 @@ func (rh *recomputeHeap) has(s INode) (ok bool) {
 	defer rh.mu.Unlock()
 
 	nodeID := s.Node().id
-	for x := rh.minHeight; x <= rh.maxHeight; x++ {
-		if rh.heights[x].has(nodeID) {
-			ok = true
-			return
-		}
+	rh.eachListUnsafe(func(_ int, l *recomputeHeapList) {
+		ok = ok || l.has(nodeID)
+	})
+	return
+}
+
+// nodes returns a snapshot of the nodes in the heap, in
+// ascending height order, without removing them from the heap.
+func (rh *recomputeHeap) nodes() (output []INode) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	output = make([]INode, 0, rh.numItems)
+	if rh.numItems == 0 {
+		return
 	}
+	rh.eachListUnsafe(func(_ int, l *recomputeHeapList) {
+		for cursor := l.head; cursor != nil; cursor = cursor.Node().nextInRecomputeHeap {
+			output = append(output, cursor)
+		}
+	})
 	return
 }
 
@@ -101,13 +198,13 @@ func (rh *recomputeHeap) setIterToMinHeight(iter *recomputeHeapListIter) {
 	rh.mu.Lock()
 	defer rh.mu.Unlock()
 
-	var heightBlock *recomputeHeapList
-	for x := 0; x < len(rh.heights); x++ {
-		heightBlock = rh.heights[x]
-		if heightBlock != nil && heightBlock.len() > 0 {
-			break
-		}
+	minHeight := rh.nextOccupiedHeightUnsafe(0)
+	if minHeight == HeightUnset {
+		iter.cursor = nil
+		return
 	}
+	heightBlock := rh.listUnsafe(minHeight)
+	rh.clearOccupiedUnsafe(minHeight)
 	iter.cursor = heightBlock.head
 	heightBlock.head = nil
 	heightBlock.tail = nil
@@ -116,6 +213,31 @@ func (rh *recomputeHeap) setIterToMinHeight(iter *recomputeHeapListIter) {
 	rh.minHeight = rh.nextMinHeightUnsafe()
 }
 
+// removeMinHeightOrdered removes the nodes in the minimum height block of the
+// heap, returning the nodes added with priority first, most recent first, and
+// otherwise sorted by the order they were created in.
+func (rh *recomputeHeap) removeMinHeightOrdered() (output []INode) {
+	var iter recomputeHeapListIter
+	rh.setIterToMinHeight(&iter)
+	for n, ok := iter.Next(); ok; n, ok = iter.Next() {
+		output = append(output, n)
+	}
+	slices.SortFunc(output, func(a, b INode) int {
+		if c := cmp.Compare(b.Node().priorityInRecomputeHeap, a.Node().priorityInRecomputeHeap); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Node().order, b.Node().order)
+	})
+	return
+}
+
+// removeMin removes the node with the minimum height from the heap.
+func (rh *recomputeHeap) removeMin() (INode, bool) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	return rh.removeMinUnsafe()
+}
+
 func (rh *recomputeHeap) remove(node INode) {
 	rh.mu.Lock()
 	defer rh.mu.Unlock()
@@ -127,41 +249,59 @@ func (rh *recomputeHeap) remove(node INode) {
 //
 
 func (rh *recomputeHeap) removeMinUnsafe() (node INode, ok bool) {
-	for x := rh.minHeight; x <= rh.maxHeight; x++ {
-		if rh.heights[x] != nil && rh.heights[x].len() > 0 {
-			_, node, ok = rh.heights[x].pop()
-			rh.numItems--
-			node.Node().heightInRecomputeHeap = HeightUnset
-			if rh.heights[x].len() > 0 {
-				rh.minHeight = x
-			} else {
-				rh.minHeight = rh.nextMinHeightUnsafe()
-			}
-			return
-		}
+	if rh.numItems == 0 {
+		return
+	}
+	x := rh.nextOccupiedHeightUnsafe(rh.minHeight)
+	if x == HeightUnset {
+		return
+	}
+	list := rh.listUnsafe(x)
+	_, node, ok = list.pop()
+	rh.numItems--
+	node.Node().heightInRecomputeHeap = HeightUnset
+	if list.len() > 0 {
+		rh.minHeight = x
+	} else {
+		rh.clearOccupiedUnsafe(x)
+		rh.minHeight = rh.nextMinHeightUnsafe()
 	}
 	return
 }
 
 func (rh *recomputeHeap) addNodeUnsafe(s INode) {
+	s.Node().priorityInRecomputeHeap = 0
+	rh.heightListForAddUnsafe(s).push(s)
+	rh.numItems++
+}
+
+func (rh *recomputeHeap) addNodeFrontUnsafe(s INode) {
+	rh.numPriority++
+	s.Node().priorityInRecomputeHeap = rh.numPriority
+	rh.heightListForAddUnsafe(s).pushFront(s)
+	rh.numItems++
+}
+
+// heightListForAddUnsafe prepares the heap to have a node
+// added, returning the list for the node's height.
+func (rh *recomputeHeap) heightListForAddUnsafe(s INode) *recomputeHeapList {
 	sn := s.Node()
 	height := sn.height
-	s.Node().heightInRecomputeHeap = height
+	sn.heightInRecomputeHeap = height
 	rh.maybeUpdateMinMaxHeightsUnsafe(height)
-	rh.maybeAddNewHeightsUnsafe(height)
-	if rh.heights[height] == nil {
-		rh.heights[height] = new(recomputeHeapList)
-	}
-	rh.heights[height].push(s)
-	rh.numItems++
+	return rh.lists.listForAdd(height)
 }
 
 func (rh *recomputeHeap) removeNodeUnsafe(item INode) {
 	rh.numItems--
 	id := item.Node().id
 	height := item.Node().heightInRecomputeHeap
-	rh.heights[height].remove(id)
-	isLastAtHeight := rh.heights[height].len() == 0
+	list := rh.listUnsafe(height)
+	list.remove(id)
+	isLastAtHeight := list.len() == 0
+	if isLastAtHeight {
+		rh.clearOccupiedUnsafe(height)
+	}
 	if height == rh.minHeight && isLastAtHeight {
 		rh.minHeight = rh.nextMinHeightUnsafe()
 	}
@@ -182,28 +322,56 @@ func (rh *recomputeHeap) maybeUpdateMinMaxHeightsUnsafe(newHeight int) {
 	}
 }
 
-func (rh *recomputeHeap) maybeAddNewHeightsUnsafe(newHeight int) {
-	if len(rh.heights) <= newHeight {
-		required := (newHeight - len(rh.heights)) + 1
-		for x := 0; x < required; x++ {
-			rh.heights = append(rh.heights, nil)
-		}
-	}
-}
-
 func (rh *recomputeHeap) nextMinHeightUnsafe() (next int) {
 	if rh.numItems == 0 {
 		return
 	}
-	for x := 0; x < len(rh.heights); x++ {
-		if rh.heights[x] != nil && rh.heights[x].len() > 0 {
-			next = x
-			break
-		}
+	if next = rh.nextOccupiedHeightUnsafe(0); next == HeightUnset {
+		next = 0
 	}
 	return
 }
 
+// nextOccupiedHeightUnsafe returns the first height at or above a given
+// height that has items, or [HeightUnset] if there are no such heights.
+func (rh *recomputeHeap) nextOccupiedHeightUnsafe(from int) int {
+	if from < 0 {
+		from = 0
+	}
+	return rh.lists.nextOccupiedHeight(from)
+}
+
+func (rh *recomputeHeap) clearOccupiedUnsafe(height int) {
+	rh.lists.clearOccupied(height)
+}
+
+// listUnsafe returns the list for a given height, or nil
+// if the list for the height hasn't been allocated.
+func (rh *recomputeHeap) listUnsafe(height int) *recomputeHeapList {
+	return rh.lists.list(height)
+}
+
+// eachListUnsafe calls a given function for each allocated
+// list in the heap in ascending height order.
+func (rh *recomputeHeap) eachListUnsafe(fn func(int, *recomputeHeapList)) {
+	rh.lists.each(fn)
+}
+
+// isSparse returns if the heap only allocates lists for the heights
+// that have nodes; see [newSparseRecomputeHeap].
+func (rh *recomputeHeap) isSparse() bool {
+	_, ok := rh.lists.(*recomputeHeapSparse)
+	return ok
+}
+
+// numHeights returns the number of heights the heap has lists for,
+// which for sparse heaps is the number of lists allocated.
+func (rh *recomputeHeap) numHeights() int {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	return rh.lists.numHeights()
+}
+
 func (rh *recomputeHeap) fixUnsafe(n INode) {
 	rh.removeNodeUnsafe(n)
 	rh.addNodeUnsafe(n)
@@ -212,23 +380,8 @@ func (rh *recomputeHeap) fixUnsafe(n INode) {
 // sanityCheck loops through each item in each height block
 // and checks that all the height values match.
 func (rh *recomputeHeap) sanityCheck() error {
-	if rh.numItems > 0 && (rh.heights[rh.minHeight] == nil || rh.heights[rh.minHeight].len() == 0) {
+	if rh.numItems > 0 && rh.listUnsafe(rh.minHeight).len() == 0 {
 		return fmt.Errorf("recompute heap; sanity check; lookup has items but min height block is empty")
 	}
-	for heightIndex, height := range rh.heights {
-		if height == nil {
-			continue
-		}
-		cursor := height.head
-		for cursor != nil {
-			if cursor.Node().heightInRecomputeHeap != heightIndex {
-				return fmt.Errorf("recompute heap; sanity check; at height %d item has height %d", heightIndex, cursor.Node().heightInRecomputeHeap)
-			}
-			if cursor.Node().heightInRecomputeHeap != cursor.Node().height {
-				return fmt.Errorf("recompute heap; sanity check; at height %d item has height %d and node has height %d", heightIndex, cursor.Node().heightInRecomputeHeap, cursor.Node().height)
-			}
-			cursor = cursor.Node().nextInRecomputeHeap
-		}
-	}
-	return nil
+	return rh.lists.sanityCheck()
 }
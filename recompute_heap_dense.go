@@ -0,0 +1,150 @@
+package incr
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+func newRecomputeHeapDense(maxHeight int) *recomputeHeapDense {
+	return &recomputeHeapDense{
+		heights:  make([]*recomputeHeapList, maxHeight),
+		occupied: make([]uint64, occupiedWordsForHeights(maxHeight)),
+	}
+}
+
+var _ recomputeHeapLists = (*recomputeHeapDense)(nil)
+
+// recomputeHeapDense holds a list for each height up to
+// the maximum height of the nodes added to the heap.
+type recomputeHeapDense struct {
+	heights []*recomputeHeapList
+
+	// occupied is a bitset with a bit set for each
+	// height that has items in its list.
+	//
+	// it lets us find the next non-empty height with
+	// a trailing zeros scan instead of walking each height.
+	occupied []uint64
+}
+
+func occupiedWordsForHeights(heights int) int {
+	return (heights + 63) >> 6
+}
+
+func (d *recomputeHeapDense) listForAdd(height int) *recomputeHeapList {
+	d.maybeAddNewHeights(height)
+	if d.heights[height] == nil {
+		d.heights[height] = new(recomputeHeapList)
+	}
+	d.setOccupied(height)
+	return d.heights[height]
+}
+
+func (d *recomputeHeapDense) list(height int) *recomputeHeapList {
+	if height < 0 || height >= len(d.heights) {
+		return nil
+	}
+	return d.heights[height]
+}
+
+func (d *recomputeHeapDense) nextOccupiedHeight(from int) int {
+	word := from >> 6
+	if word >= len(d.occupied) {
+		return HeightUnset
+	}
+	// mask off the bits below `from` in the first word.
+	bitset := d.occupied[word] & (^uint64(0) << uint(from&63))
+	for {
+		if bitset != 0 {
+			return (word << 6) + bits.TrailingZeros64(bitset)
+		}
+		word++
+		if word == len(d.occupied) {
+			return HeightUnset
+		}
+		bitset = d.occupied[word]
+	}
+}
+
+func (d *recomputeHeapDense) setOccupied(height int) {
+	for len(d.occupied) <= height>>6 {
+		d.occupied = append(d.occupied, 0)
+	}
+	d.occupied[height>>6] |= 1 << uint(height&63)
+}
+
+func (d *recomputeHeapDense) clearOccupied(height int) {
+	if height>>6 < len(d.occupied) {
+		d.occupied[height>>6] &^= 1 << uint(height&63)
+	}
+}
+
+func (d *recomputeHeapDense) each(fn func(int, *recomputeHeapList)) {
+	for height, l := range d.heights {
+		if l != nil {
+			fn(height, l)
+		}
+	}
+}
+
+func (d *recomputeHeapDense) numHeights() int {
+	return len(d.heights)
+}
+
+func (d *recomputeHeapDense) numAllocated() (output int) {
+	for _, height := range d.heights {
+		if height != nil {
+			output++
+		}
+	}
+	return
+}
+
+func (d *recomputeHeapDense) preallocate(size int) {
+	for x := 0; x < size && x < len(d.heights); x++ {
+		if d.heights[x] == nil {
+			d.heights[x] = new(recomputeHeapList)
+		}
+	}
+}
+
+// reset marks each height as unoccupied, keeping the lists that
+// have been allocated (e.g. preallocated) as they're empty.
+func (d *recomputeHeapDense) reset() {
+	clear(d.occupied)
+}
+
+func (d *recomputeHeapDense) maybeAddNewHeights(newHeight int) {
+	if len(d.heights) <= newHeight {
+		required := (newHeight - len(d.heights)) + 1
+		for x := 0; x < required; x++ {
+			d.heights = append(d.heights, nil)
+		}
+		for len(d.occupied) < occupiedWordsForHeights(len(d.heights)) {
+			d.occupied = append(d.occupied, 0)
+		}
+	}
+}
+
+func (d *recomputeHeapDense) sanityCheck() error {
+	for heightIndex, height := range d.heights {
+		isOccupied := d.nextOccupiedHeight(heightIndex) == heightIndex
+		if isOccupied != (height.len() > 0) {
+			return fmt.Errorf("recompute heap; sanity check; at height %d occupied is %v but list has %d items", heightIndex, isOccupied, height.len())
+		}
+		if height == nil {
+			continue
+		}
+		cursor := height.head
+		for cursor != nil {
+			if cursor.Node().heightInRecomputeHeap != heightIndex {
+				return fmt.Errorf("recompute heap; sanity check; at height %d item has height %d", heightIndex, cursor.Node().heightInRecomputeHeap)
+			}
+			if cursor.Node().heightInRecomputeHeap != cursor.Node().height {
+				return fmt.Errorf("recompute heap; sanity check; at height %d item has height %d and node has height %d", heightIndex, cursor.Node().heightInRecomputeHeap, cursor.Node().height)
+			}
+			cursor = cursor.Node().nextInRecomputeHeap
+		}
+	}
+	return nil
+}
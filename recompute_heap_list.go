@@ -30,6 +30,22 @@ func (l *recomputeHeapList) push(v INode) {
 	l.tail = v
 }
 
+// pushFront adds a node to the front of the list, that is
+// it will be the next node returned by pop.
+func (l *recomputeHeapList) pushFront(v INode) {
+	l.count = l.count + 1
+	v.Node().nextInRecomputeHeap = nil
+	v.Node().previousInRecomputeHeap = nil
+	if l.head == nil {
+		l.head = v
+		l.tail = v
+		return
+	}
+	l.head.Node().previousInRecomputeHeap = v
+	v.Node().nextInRecomputeHeap = l.head
+	l.head = v
+}
+
 func (l *recomputeHeapList) pop() (k Identifier, v INode, ok bool) {
 	if l.head == nil {
 		return
@@ -692,3 +692,34 @@ func Test_recomputeHeapList_removeHeadItem_one(t *testing.T) {
 	testutil.Nil(t, n0.Node().nextInRecomputeHeap)
 	testutil.Nil(t, n0.Node().previousInRecomputeHeap)
 }
+
+func Test_recomputeHeapList_pushFront(t *testing.T) {
+	g := New()
+	q := new(recomputeHeapList)
+
+	n0 := newHeightIncr(g, 0)
+	n1 := newHeightIncr(g, 0)
+	n2 := newHeightIncr(g, 0)
+
+	q.pushFront(n0)
+	testutil.Equal(t, 1, q.len())
+	testutil.Equal(t, n0.n.id, nodePtrID(q.head))
+	testutil.Equal(t, n0.n.id, nodePtrID(q.tail))
+
+	q.push(n1)
+	q.pushFront(n2)
+	testutil.Equal(t, 3, q.len())
+	testutil.Equal(t, n2.n.id, nodePtrID(q.head))
+	testutil.Equal(t, n1.n.id, nodePtrID(q.tail))
+	testutil.Nil(t, rhprev(q.head))
+	testutil.Equal(t, n0.n.id, nodePtrID(rhnext(q.head)))
+	testutil.Equal(t, n2.n.id, nodePtrID(rhprev(n0)))
+
+	_, n, _ := q.pop()
+	testutil.Equal(t, n2.n.id, n.Node().id)
+	_, n, _ = q.pop()
+	testutil.Equal(t, n0.n.id, n.Node().id)
+	_, n, _ = q.pop()
+	testutil.Equal(t, n1.n.id, n.Node().id)
+	testutil.Equal(t, 0, q.len())
+}
@@ -0,0 +1,169 @@
+package incr
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+)
+
+// newSparseRecomputeHeap returns a recompute heap that allocates the lists
+// for heights as they're needed, and keeps the heights that have lists in
+// a priority queue rather than allocating a list for each height.
+//
+// This uses less memory for graphs that are tall but only have
+// nodes at a few heights in the heap at a time.
+//
+// The size, if greater than zero, is the number of heights
+// to allocate space for in the lookup of lists by height.
+func newSparseRecomputeHeap(size int) *recomputeHeap {
+	return &recomputeHeap{
+		lists: newRecomputeHeapSparse(size),
+	}
+}
+
+func newRecomputeHeapSparse(size int) *recomputeHeapSparse {
+	return &recomputeHeapSparse{
+		heights: allocateMapWithSize[int, *recomputeHeapList](size),
+	}
+}
+
+var _ recomputeHeapLists = (*recomputeHeapSparse)(nil)
+
+// recomputeHeapSparse holds the lists for only the heights that
+// have had nodes added since the lists were last dropped.
+type recomputeHeapSparse struct {
+	heights  map[int]*recomputeHeapList
+	occupied recomputeHeapHeights
+	// free holds lists that have been dropped
+	// to be reused for the next height that needs a list.
+	free []*recomputeHeapList
+}
+
+func (s *recomputeHeapSparse) listForAdd(height int) *recomputeHeapList {
+	l, ok := s.heights[height]
+	if !ok {
+		if last := len(s.free) - 1; last >= 0 {
+			l = s.free[last]
+			s.free = s.free[:last]
+		} else {
+			l = new(recomputeHeapList)
+		}
+		s.heights[height] = l
+		heap.Push(&s.occupied, height)
+	}
+	return l
+}
+
+func (s *recomputeHeapSparse) list(height int) *recomputeHeapList {
+	return s.heights[height]
+}
+
+// clearOccupied does nothing for sparse heaps, which drop the heights
+// of empty lists as they reach the top of the heap of heights instead.
+func (s *recomputeHeapSparse) clearOccupied(_ int) {}
+
+// nextOccupiedHeight returns the first height at or above a given
+// height that has items, or [HeightUnset] if there are no such heights.
+//
+// Lists that are empty are dropped as their heights reach the top of the
+// heap, and kept to be reused for the next height that needs a list.
+func (s *recomputeHeapSparse) nextOccupiedHeight(from int) int {
+	for len(s.occupied) > 0 {
+		height := s.occupied[0]
+		if s.heights[height].len() > 0 {
+			break
+		}
+		heap.Pop(&s.occupied)
+		s.free = append(s.free, s.heights[height])
+		delete(s.heights, height)
+	}
+	if len(s.occupied) == 0 {
+		return HeightUnset
+	}
+	if s.occupied[0] >= from {
+		return s.occupied[0]
+	}
+	next := HeightUnset
+	for height, l := range s.heights {
+		if height >= from && l.len() > 0 && (next == HeightUnset || height < next) {
+			next = height
+		}
+	}
+	return next
+}
+
+func (s *recomputeHeapSparse) each(fn func(int, *recomputeHeapList)) {
+	heights := make([]int, 0, len(s.heights))
+	for height := range s.heights {
+		heights = append(heights, height)
+	}
+	slices.Sort(heights)
+	for _, height := range heights {
+		fn(height, s.heights[height])
+	}
+}
+
+func (s *recomputeHeapSparse) numHeights() int {
+	return len(s.heights)
+}
+
+func (s *recomputeHeapSparse) numAllocated() int {
+	return len(s.heights)
+}
+
+// preallocate does nothing for sparse heaps, which
+// allocate the lists for heights as they're needed.
+func (s *recomputeHeapSparse) preallocate(_ int) {}
+
+// reset drops the lists for each height, keeping them to be
+// reused for the next heights that need lists.
+func (s *recomputeHeapSparse) reset() {
+	for _, l := range s.heights {
+		s.free = append(s.free, l)
+	}
+	clear(s.heights)
+	s.occupied = s.occupied[:0]
+}
+
+// sanityCheck checks that the lists are consistent with
+// the heights of their items and with the heap of heights.
+func (s *recomputeHeapSparse) sanityCheck() error {
+	if len(s.occupied) != len(s.heights) {
+		return fmt.Errorf("recompute heap; sanity check; %d heights are occupied but %d lists are allocated", len(s.occupied), len(s.heights))
+	}
+	for _, height := range s.occupied {
+		l, ok := s.heights[height]
+		if !ok {
+			return fmt.Errorf("recompute heap; sanity check; height %d is occupied but has no list", height)
+		}
+		for cursor := l.head; cursor != nil; cursor = cursor.Node().nextInRecomputeHeap {
+			if cursor.Node().heightInRecomputeHeap != height {
+				return fmt.Errorf("recompute heap; sanity check; at height %d item has height %d", height, cursor.Node().heightInRecomputeHeap)
+			}
+			if cursor.Node().heightInRecomputeHeap != cursor.Node().height {
+				return fmt.Errorf("recompute heap; sanity check; at height %d item has height %d and node has height %d", height, cursor.Node().heightInRecomputeHeap, cursor.Node().height)
+			}
+		}
+	}
+	return nil
+}
+
+// recomputeHeapHeights implements [heap.Interface] for the
+// heights that have lists in a sparse recompute heap.
+type recomputeHeapHeights []int
+
+func (h recomputeHeapHeights) Len() int           { return len(h) }
+func (h recomputeHeapHeights) Less(i, j int) bool { return h[i] < h[j] }
+func (h recomputeHeapHeights) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *recomputeHeapHeights) Push(x any) {
+	*h = append(*h, x.(int))
+}
+
+func (h *recomputeHeapHeights) Pop() any {
+	old := *h
+	last := len(old) - 1
+	height := old[last]
+	*h = old[:last]
+	return height
+}
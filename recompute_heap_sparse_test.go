@@ -0,0 +1,112 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_sparseRecomputeHeap_listsAllocatedOnDemand(t *testing.T) {
+	g := New()
+	rh := newSparseRecomputeHeap(0)
+	testutil.Equal(t, 0, rh.numHeightListsAllocated())
+	rh.preallocateHeightLists(32)
+	testutil.Equal(t, 0, rh.numHeightListsAllocated())
+
+	n10 := newHeightIncr(g, 10)
+	n4000 := newHeightIncr(g, 4000)
+	n4001 := newHeightIncr(g, 4000)
+	rh.add(n4000, n10, n4001)
+	testutil.Equal(t, 2, rh.numHeightListsAllocated())
+	testutil.Equal(t, 2, rh.numHeights())
+	testutil.NoError(t, rh.sanityCheck())
+
+	node, ok := rh.removeMinUnsafe()
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, n10.n.id, node.Node().id)
+	testutil.Equal(t, 4000, rh.minHeight)
+	testutil.NoError(t, rh.sanityCheck())
+
+	// the empty list is dropped once its height reaches the top of the heap.
+	testutil.Equal(t, 1, rh.numHeightListsAllocated())
+	testutil.Equal(t, 1, len(rh.lists.(*recomputeHeapSparse).free))
+
+	// and reused for the next height that needs a list.
+	n20 := newHeightIncr(g, 20)
+	rh.add(n20)
+	testutil.Equal(t, 2, rh.numHeightListsAllocated())
+	testutil.Equal(t, 0, len(rh.lists.(*recomputeHeapSparse).free))
+	testutil.Equal(t, 20, rh.minHeight)
+	testutil.NoError(t, rh.sanityCheck())
+
+	rh.remove(n20)
+	testutil.Equal(t, 4000, rh.minHeight)
+	testutil.Equal(t, 2, rh.len())
+	testutil.NoError(t, rh.sanityCheck())
+}
+
+func Test_sparseRecomputeHeap_nextOccupiedHeightUnsafe(t *testing.T) {
+	g := New()
+	rh := newSparseRecomputeHeap(0)
+	testutil.Equal(t, HeightUnset, rh.nextOccupiedHeightUnsafe(0))
+
+	n3 := newHeightIncr(g, 3)
+	n70 := newHeightIncr(g, 70)
+	n1300 := newHeightIncr(g, 1300)
+	rh.add(n3, n70, n1300)
+
+	testutil.Equal(t, 3, rh.nextOccupiedHeightUnsafe(0))
+	testutil.Equal(t, 3, rh.nextOccupiedHeightUnsafe(3))
+	testutil.Equal(t, 70, rh.nextOccupiedHeightUnsafe(4))
+	testutil.Equal(t, 1300, rh.nextOccupiedHeightUnsafe(71))
+	testutil.Equal(t, HeightUnset, rh.nextOccupiedHeightUnsafe(1301))
+
+	rh.remove(n70)
+	testutil.Equal(t, 1300, rh.nextOccupiedHeightUnsafe(4))
+	testutil.NoError(t, rh.sanityCheck())
+
+	rh.clear()
+	testutil.Equal(t, HeightUnset, rh.nextOccupiedHeightUnsafe(0))
+	testutil.Equal(t, 0, rh.numHeightListsAllocated())
+}
+
+func Test_sparseRecomputeHeap_sanityCheck_badItemHeight(t *testing.T) {
+	g := New()
+	rh := newSparseRecomputeHeap(0)
+	n2 := newHeightIncr(g, 2)
+	rh.add(n2, newHeightIncr(g, 2))
+	testutil.NoError(t, rh.sanityCheck())
+
+	n2.n.height = 3
+	testutil.NotNil(t, rh.sanityCheck())
+}
+
+func Test_Graph_recomputeHeapSparse(t *testing.T) {
+	ctx := testContext()
+	g := New(
+		OptGraphMaxHeight(2048),
+		OptGraphRecomputeHeapSparse(true),
+	)
+	testutil.Equal(t, true, g.recomputeHeap.isSparse())
+
+	v := Var(g, 0)
+	var cursor Incr[int] = v
+	for x := 0; x < 1024; x++ {
+		cursor = Map(g, cursor, func(vv int) int { return vv + 1 })
+	}
+	other := Var(g, 10)
+	m := Map2(g, cursor, other, add[int])
+	o := MustObserve(g, m)
+	testutil.Equal(t, 1025, m.Node().height)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1034, o.Value())
+
+	v.Set(1)
+	other.Set(20)
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1045, o.Value())
+	testutil.NoError(t, g.Validate())
+}
@@ -0,0 +1,104 @@
+package incr
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// RecomputeHeights recomputes the heights of a given node and its descendants
+// from the heights of their parents, e.g. after restructuring the graph with
+// [Link] and [Unlink] between stabilizations.
+//
+// Unlike [Link], which only ever increases heights, this can also reduce
+// the heights of nodes, such as after a node's deepest parent is unlinked.
+//
+// Nodes whose heights change and that are in the recompute heap are moved
+// to their new heights, and an error is returned if a cycle is detected
+// among the descendants of the node.
+//
+// It must not be called during stabilization.
+func (graph *Graph) RecomputeHeights(from INode) error {
+	if from == nil {
+		return errChildNil
+	}
+	if atomic.LoadInt32(&graph.status) != StatusNotStabilizing {
+		return ErrAlreadyStabilizing
+	}
+	if !from.Node().isNecessary() {
+		return nil
+	}
+	ordered, err := graph.recomputeHeightsOrder(from)
+	if err != nil {
+		return err
+	}
+	for _, n := range ordered {
+		nn := n.Node()
+		height := nn.createdIn.scopeHeight() + 1
+		for _, p := range nn.parents {
+			height = max(height, p.Node().height+1)
+		}
+		if height == nn.height {
+			continue
+		}
+		if err = graph.adjustHeightsHeap.setHeight(n, height); err != nil {
+			return err
+		}
+		if nn.heightInRecomputeHeap != HeightUnset {
+			graph.recomputeHeap.fix(n)
+		}
+	}
+	return nil
+}
+
+// recomputeHeightsOrder returns a given node and its necessary descendants
+// in topological order, that is parents before children, or an error
+// if a cycle is detected.
+func (graph *Graph) recomputeHeightsOrder(from INode) ([]INode, error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[Identifier]int)
+	var postOrder []INode
+	var visit func(INode) error
+	visit = func(n INode) error {
+		switch state[n.Node().id] {
+		case visiting:
+			return fmt.Errorf("recompute heights; cycle detected at %v", n)
+		case visited:
+			return nil
+		}
+		state[n.Node().id] = visiting
+		for _, c := range recomputeHeightsChildren(n) {
+			if err := visit(c); err != nil {
+				return err
+			}
+		}
+		state[n.Node().id] = visited
+		postOrder = append(postOrder, n)
+		return nil
+	}
+	if err := visit(from); err != nil {
+		return nil, err
+	}
+	output := make([]INode, 0, len(postOrder))
+	for x := len(postOrder) - 1; x >= 0; x-- {
+		output = append(output, postOrder[x])
+	}
+	return output, nil
+}
+
+// recomputeHeightsChildren returns the nodes whose heights depend on a given
+// node's height, that is its children and, for bind change nodes, the
+// necessary nodes created in the bind's scope.
+func recomputeHeightsChildren(n INode) (output []INode) {
+	output = append(output, n.Node().children...)
+	if typed, ok := n.(IBindChange); ok {
+		for _, nodeOnRight := range typed.RightScopeNodes() {
+			if nodeOnRight.Node().isNecessary() {
+				output = append(output, nodeOnRight)
+			}
+		}
+	}
+	return
+}
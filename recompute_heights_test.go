@@ -0,0 +1,142 @@
+package incr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_RecomputeHeights(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	m0 := Map(g, v0, ident)
+	m1 := Map(g, m0, ident)
+	m2 := Map(g, m1, ident)
+	lt := newLinkTestIncr(g, m2)
+	m3 := Map(g, lt, func(v string) string { return v + "!" })
+	o := MustObserve(g, m3)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a!", o.Value())
+	testutil.Equal(t, 4, lt.Node().Height())
+	testutil.Equal(t, 5, m3.Node().Height())
+
+	v1 := Var(g, "b")
+	lt.removeInput(m2.Node().ID())
+	Unlink(lt, m2)
+	lt.inputs = append(lt.inputs, v1)
+	err = Link(lt, v1)
+	testutil.NoError(t, err)
+
+	testutil.Equal(t, false, g.Has(m2))
+	testutil.Equal(t, 4, lt.Node().Height(), "unlink should not reduce heights")
+	testutil.Equal(t, true, g.recomputeHeap.has(lt))
+
+	err = g.RecomputeHeights(lt)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, lt.Node().Height())
+	testutil.Equal(t, 2, m3.Node().Height())
+	testutil.Equal(t, 1, lt.Node().heightInRecomputeHeap)
+	testutil.NoError(t, g.SanityCheck())
+	testutil.NoError(t, g.Validate())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b!", o.Value())
+	testutil.NoError(t, g.SanityCheck())
+
+	v1.Set("c")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "c!", o.Value())
+}
+
+func Test_Graph_RecomputeHeights_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	m0 := Map(g, v0, ident)
+	m1 := Map(g, m0, ident)
+	lt := newLinkTestIncr(g, m1)
+	b := Bind(g, lt, func(bs Scope, v string) Incr[string] {
+		return Map(bs, Return(bs, v), func(vv string) string { return vv + "!" })
+	})
+	o := MustObserve(g, b)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a!", o.Value())
+	heightBefore := b.Node().Height()
+
+	lt.removeInput(m1.Node().ID())
+	Unlink(lt, m1)
+	lt.inputs = append(lt.inputs, v0)
+	err = Link(lt, v0)
+	testutil.NoError(t, err)
+
+	err = g.RecomputeHeights(lt)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, lt.Node().Height())
+	testutil.Equal(t, heightBefore-2, b.Node().Height())
+	testutil.NoError(t, g.Validate())
+
+	v0.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b!", o.Value())
+	testutil.NoError(t, g.SanityCheck())
+}
+
+func Test_Graph_RecomputeHeights_cycle(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	lt := newLinkTestIncr(g, v0)
+	m0 := Map(g, lt, ident)
+	_ = MustObserve(g, m0)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	// link the nodes in a cycle without checking heights.
+	ExpertGraph(g).RemoveParent(lt, v0)
+	g.link(lt, m0)
+
+	err = g.RecomputeHeights(lt)
+	testutil.Error(t, err)
+}
+
+func Test_Graph_RecomputeHeights_unobserved(t *testing.T) {
+	g := New()
+	m0 := Map(g, Var(g, "a"), ident)
+	err := g.RecomputeHeights(m0)
+	testutil.NoError(t, err)
+	testutil.Equal(t, HeightUnset, m0.Node().Height())
+
+	err = g.RecomputeHeights(nil)
+	testutil.Error(t, err)
+}
+
+func Test_Graph_RecomputeHeights_stabilizing(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	var recomputeErr error
+	v0 := Var(g, "a")
+	var m0 Incr[string]
+	m0 = MapContext(g, v0, func(_ context.Context, v string) (string, error) {
+		recomputeErr = g.RecomputeHeights(m0)
+		return v, nil
+	})
+	_ = MustObserve(g, m0)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, ErrAlreadyStabilizing, recomputeErr)
+}
@@ -0,0 +1,171 @@
+package incr
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// RecomputeNode forces the recomputation of a given node and the necessary
+// nodes that depend on it (i.e. its children, and their children, and so on).
+//
+// Unlike calling [Graph.SetStale] and then [Graph.Stabilize], other stale nodes in
+// the graph that are not descendants of the given node are left in the recompute heap
+// and will not be recomputed until the next full stabilization.
+//
+// The given node must be part of the graph (i.e. it must be necessary), otherwise
+// an error is returned.
+func (graph *Graph) RecomputeNode(ctx context.Context, gn INode) (err error) {
+	if !graph.Has(gn) {
+		err = fmt.Errorf("recompute node; node %v is not part of the graph", gn)
+		return
+	}
+	if err = graph.claimStabilization(ctx); err != nil {
+		return
+	}
+	ctx = graph.stabilizeStart(ctx)
+	defer func() {
+		graph.stabilizeEnd(ctx, err)
+	}()
+	err = graph.recomputeNode(ctx, gn)
+	return
+}
+
+// recomputeNode recomputes a given node and then the nodes in the recompute heap
+// that descend from it in height order, leaving the other nodes in the recompute heap.
+func (graph *Graph) recomputeNode(ctx context.Context, gn INode) (err error) {
+	graph.readMu.Lock()
+	defer graph.readMu.Unlock()
+
+	if gn.Node().heightInRecomputeHeap != HeightUnset {
+		graph.recomputeHeap.remove(gn)
+	}
+	gn.Node().setAt = graph.stabilizationNum
+	gn.Node().recomputeReason = RecomputeReasonSetStale
+
+	// candidates holds the descendants of the node that have been added to
+	// the recompute heap, such that we can take them out of the recompute heap
+	// in height order without walking the nodes that don't descend from the node.
+	descendants := graph.necessaryDescendants(gn)
+	candidates := new(recomputeNodeCandidates)
+	candidates.pushInRecomputeHeap(descendants)
+
+	var immediateRecompute []INode
+	next := gn
+	for next != nil {
+		err = graph.recompute(ctx, next, false /*parallel*/)
+		if next.Node().always {
+			immediateRecompute = append(immediateRecompute, next)
+		}
+		if err != nil {
+			break
+		}
+		// bind nodes can link in new descendants of the node, including
+		// nodes with lower heights than the nodes we've already recomputed.
+		if _, isBindChange := next.(IBindChange); isBindChange {
+			descendants = graph.necessaryDescendants(gn)
+			candidates.pushInRecomputeHeap(descendants)
+		} else {
+			for _, c := range next.Node().children {
+				if _, ok := descendants[c.Node().id]; ok && c.Node().heightInRecomputeHeap != HeightUnset {
+					candidates.push(c)
+				}
+			}
+		}
+		next = candidates.next(graph.recomputeHeap)
+	}
+	graph.stabilizeFinishPass(ctx, err, immediateRecompute)
+	return
+}
+
+// necessaryDescendants returns the necessary nodes that take a given node
+// as an input transitively by identifier, not including the node itself, along
+// with the nodes created in the scopes of [Bind] nodes that descend from the node.
+func (graph *Graph) necessaryDescendants(gn INode) map[Identifier]INode {
+	output := make(map[Identifier]INode)
+	seen := map[Identifier]struct{}{
+		gn.Node().id: {},
+	}
+	q := new(queue[INode])
+	q.push(gn)
+	for q.len() > 0 {
+		n, _ := q.pop()
+		next := n.Node().children
+		if typed, ok := n.(IBindChange); ok {
+			next = append(slices.Clone(next), typed.RightScopeNodes()...)
+		}
+		for _, c := range next {
+			if _, ok := seen[c.Node().id]; ok {
+				continue
+			}
+			seen[c.Node().id] = struct{}{}
+			if c.Node().isNecessary() {
+				output[c.Node().id] = c
+				q.push(c)
+			}
+		}
+	}
+	return output
+}
+
+// recomputeNodeCandidates implements [heap.Interface] for the nodes that
+// may be in the recompute heap, ordered by their heights as of when they were pushed.
+type recomputeNodeCandidates []recomputeNodeCandidate
+
+type recomputeNodeCandidate struct {
+	height int
+	node   INode
+}
+
+func (c recomputeNodeCandidates) Len() int           { return len(c) }
+func (c recomputeNodeCandidates) Less(i, j int) bool { return c[i].height < c[j].height }
+func (c recomputeNodeCandidates) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+func (c *recomputeNodeCandidates) Push(x any) {
+	*c = append(*c, x.(recomputeNodeCandidate))
+}
+
+func (c *recomputeNodeCandidates) Pop() any {
+	old := *c
+	last := len(old) - 1
+	candidate := old[last]
+	*c = old[:last]
+	return candidate
+}
+
+func (c *recomputeNodeCandidates) push(n INode) {
+	heap.Push(c, recomputeNodeCandidate{height: n.Node().height, node: n})
+}
+
+// pushInRecomputeHeap pushes the given nodes that are in the recompute heap.
+func (c *recomputeNodeCandidates) pushInRecomputeHeap(nodes map[Identifier]INode) {
+	for _, n := range nodes {
+		if n.Node().heightInRecomputeHeap != HeightUnset {
+			c.push(n)
+		}
+	}
+}
+
+// next removes the candidate with the minimum height that is still
+// in a given recompute heap from the recompute heap and returns it, or
+// returns <nil> if there are no such candidates.
+//
+// Candidates whose heights were raised since they were pushed, e.g. by a
+// [Bind] node, are pushed again with their new heights.
+func (c *recomputeNodeCandidates) next(rh *recomputeHeap) INode {
+	for c.Len() > 0 {
+		candidate := heap.Pop(c).(recomputeNodeCandidate)
+		n := candidate.node
+		if n.Node().heightInRecomputeHeap == HeightUnset {
+			continue
+		}
+		if n.Node().height != candidate.height {
+			c.push(n)
+			continue
+		}
+		rh.remove(n)
+		return n
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_RecomputeNode(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	var aCalls, bCalls int
+	va := Var(g, "a")
+	ma0 := Map(g, va, func(v string) string { aCalls++; return v + "0" })
+	ma1 := Map(g, ma0, func(v string) string { aCalls++; return v + "1" })
+	oa := MustObserve(g, ma1)
+
+	vb := Var(g, "b")
+	mb0 := Map(g, vb, func(v string) string { bCalls++; return v + "0" })
+	mb1 := Map(g, mb0, func(v string) string { bCalls++; return v + "1" })
+	ob := MustObserve(g, mb1)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a01", oa.Value())
+	testutil.Equal(t, "b01", ob.Value())
+	testutil.Equal(t, 2, aCalls)
+	testutil.Equal(t, 2, bCalls)
+
+	// make the second chain stale, it should _not_ be picked up.
+	vb.Set("B")
+	mb0RecomputedAt := mb0.Node().recomputedAt
+	mb1RecomputedAt := mb1.Node().recomputedAt
+
+	err = g.RecomputeNode(ctx, ma0)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 4, aCalls)
+	testutil.Equal(t, 2, bCalls)
+	testutil.Equal(t, "a01", oa.Value())
+	testutil.Equal(t, "b01", ob.Value())
+	testutil.Equal(t, mb0RecomputedAt, mb0.Node().recomputedAt)
+	testutil.Equal(t, mb1RecomputedAt, mb1.Node().recomputedAt)
+	testutil.Equal(t, true, g.recomputeHeap.has(vb))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 4, aCalls)
+	testutil.Equal(t, 4, bCalls)
+	testutil.Equal(t, "B01", ob.Value())
+}
+
+func Test_Graph_RecomputeNode_leavesOtherNodes(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	va := Var(g, "a")
+	ma := Map(g, va, ident)
+	_ = MustObserve(g, ma)
+
+	vb := Var(g, "b")
+	mb := Map(g, vb, ident)
+	_ = MustObserve(g, mb)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	vb.Set("B")
+	g.SetStaleWithPriority(mb)
+
+	err = g.RecomputeNode(ctx, va)
+	testutil.NoError(t, err)
+
+	// the nodes that don't descend from the node keep
+	// their places and reasons in the recompute heap.
+	testutil.Equal(t, 2, g.recomputeHeap.len())
+	testutil.Equal(t, RecomputeReasonVarSet, vb.Node().recomputeReason)
+	testutil.Equal(t, RecomputeReasonSetStale, mb.Node().recomputeReason)
+	testutil.NotEqual(t, uint64(0), mb.Node().priorityInRecomputeHeap)
+	testutil.NoError(t, g.recomputeHeap.sanityCheck())
+}
+
+func Test_Graph_RecomputeNode_var(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	var calls int
+	m := Map(g, v, func(v string) string { calls++; return v })
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+
+	err = g.RecomputeNode(ctx, v)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, calls)
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+}
+
+func Test_Graph_RecomputeNode_staleDescendant(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	c := Cutoff(g, v, func(oldv, newv string) bool { return oldv == newv })
+	var calls int
+	m := Map(g, c, func(v string) string { calls++; return v })
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+
+	g.SetStale(m)
+	testutil.Equal(t, true, g.recomputeHeap.has(m))
+
+	err = g.RecomputeNode(ctx, v)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.recomputeHeap.has(m))
+	testutil.Equal(t, 2, calls)
+	testutil.Equal(t, "a", o.Value())
+}
+
+func Test_Graph_RecomputeNode_notNecessary(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	err := g.RecomputeNode(ctx, v)
+	testutil.Error(t, err)
+}
+
+func Test_Graph_RecomputeNode_alreadyStabilizing(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	_ = MustObserve(g, v)
+
+	g.status = StatusStabilizing
+	err := g.RecomputeNode(ctx, v)
+	testutil.Equal(t, ErrAlreadyStabilizing, err)
+}
+
+func Test_Graph_RecomputeNode_duringStabilization(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	m := Map(g, v, ident)
+	o := MustObserve(g, m)
+
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+
+	err = g.RecomputeNode(ctx, m)
+	testutil.Equal(t, ErrAlreadyStabilizing, err)
+
+	err = s.Finish()
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+}
+
+func Test_Graph_RecomputeNode_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	other := Var(g, "x")
+	om := Map(g, other, ident)
+	b := Bind(g, v, func(bs Scope, vv string) Incr[string] {
+		return Map(bs, Return(bs, vv), func(rv string) string { return rv + "!" })
+	})
+	o := MustObserve(g, b)
+	oo := MustObserve(g, om)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a!", o.Value())
+
+	other.Set("y")
+	v.Set("b")
+	err = g.RecomputeNode(ctx, v)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b!", o.Value(), "nodes linked in by the bind should be recomputed")
+	testutil.Equal(t, "x", oo.Value())
+	testutil.Equal(t, true, g.recomputeHeap.has(other))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "y", oo.Value())
+}
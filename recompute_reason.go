@@ -0,0 +1,48 @@
+package incr
+
+// RecomputeReason is the reason a node was added to the recompute heap,
+// and is useful when debugging why a node was recomputed.
+type RecomputeReason uint8
+
+// RecomputeReason values.
+const (
+	// RecomputeReasonNone means the node has not been scheduled for recomputation.
+	RecomputeReasonNone RecomputeReason = iota
+	// RecomputeReasonParentChanged means one of the node's inputs changed.
+	RecomputeReasonParentChanged
+	// RecomputeReasonAlways means the node is an [Always] node and is recomputed every stabilization.
+	RecomputeReasonAlways
+	// RecomputeReasonSetStale means the node was explicitly marked stale with [Graph.SetStale].
+	RecomputeReasonSetStale
+	// RecomputeReasonLinked means the node was linked to a new input, e.g. by a [Bind] changing its right-hand side,
+	// or unlinked from an input, e.g. by [Unlink] or an input being removed from a [MapN] node.
+	RecomputeReasonLinked
+	// RecomputeReasonNecessary means the node became necessary and is stale, e.g. it was just observed.
+	RecomputeReasonNecessary
+	// RecomputeReasonInvalidated means one of the node's inputs was invalidated, e.g. by a [Bind] changing its right-hand side.
+	RecomputeReasonInvalidated
+	// RecomputeReasonVarSet means the node is a [Var] whose value was set.
+	RecomputeReasonVarSet
+)
+
+// String implements fmt.Stringer.
+func (rr RecomputeReason) String() string {
+	switch rr {
+	case RecomputeReasonParentChanged:
+		return "parent_changed"
+	case RecomputeReasonAlways:
+		return "always"
+	case RecomputeReasonSetStale:
+		return "set_stale"
+	case RecomputeReasonLinked:
+		return "linked"
+	case RecomputeReasonNecessary:
+		return "necessary"
+	case RecomputeReasonInvalidated:
+		return "invalidated"
+	case RecomputeReasonVarSet:
+		return "var_set"
+	default:
+		return "none"
+	}
+}
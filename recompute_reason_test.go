@@ -0,0 +1,148 @@
+package incr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_RecomputeReason_String(t *testing.T) {
+	testutil.Equal(t, "none", RecomputeReasonNone.String())
+	testutil.Equal(t, "parent_changed", RecomputeReasonParentChanged.String())
+	testutil.Equal(t, "always", RecomputeReasonAlways.String())
+	testutil.Equal(t, "set_stale", RecomputeReasonSetStale.String())
+	testutil.Equal(t, "var_set", RecomputeReasonVarSet.String())
+	testutil.Equal(t, "linked", RecomputeReasonLinked.String())
+	testutil.Equal(t, "necessary", RecomputeReasonNecessary.String())
+	testutil.Equal(t, "invalidated", RecomputeReasonInvalidated.String())
+}
+
+func Test_RecomputeReason_Always_Cutoff(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	filename := Var(g, "test")
+	filenameAlways := Always(g, filename)
+	modtime := 1
+	statfile := Map(g, filenameAlways, func(s string) int { return modtime })
+	statfileCutoff := Cutoff(g, statfile, func(ov, nv int) bool {
+		return ov == nv
+	})
+	readFile := Map2(g, filename, statfileCutoff, func(p string, mt int) string {
+		return p + "-" + string(rune('0'+mt))
+	})
+	_ = MustObserve(g, readFile)
+
+	testutil.Equal(t, RecomputeReasonNecessary, ExpertNode(readFile).RecomputeReason())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonNecessary, ExpertNode(readFile).LastRecomputeReason())
+	testutil.Equal(t, RecomputeReasonAlways, ExpertNode(filenameAlways).RecomputeReason())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonAlways, ExpertNode(filenameAlways).LastRecomputeReason())
+	testutil.Equal(t, RecomputeReasonParentChanged, ExpertNode(statfile).LastRecomputeReason())
+	testutil.Equal(t, RecomputeReasonParentChanged, ExpertNode(statfileCutoff).LastRecomputeReason())
+	// the cutoff should keep the read file from recomputing, and the
+	// reason should reset as a result.
+	testutil.Equal(t, RecomputeReasonNone, ExpertNode(readFile).LastRecomputeReason())
+
+	filename.Set("test2")
+	testutil.Equal(t, RecomputeReasonVarSet, ExpertNode(filename).RecomputeReason())
+	pending := g.PendingRecompute()
+	testutil.Any(t, pending, func(nm NodeMetadata) bool {
+		return nm.ID == filename.Node().ID() && nm.RecomputeReason == RecomputeReasonVarSet
+	})
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonVarSet, ExpertNode(filename).LastRecomputeReason())
+	testutil.Equal(t, RecomputeReasonParentChanged, ExpertNode(readFile).LastRecomputeReason())
+}
+
+func Test_RecomputeReason_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	a := Map(g, Return(g, "a"), ident)
+	b := Map(g, Return(g, "b"), ident)
+	bind := Bind(g, v, func(_ Scope, which string) Incr[string] {
+		if which == "a" {
+			return a
+		}
+		return b
+	})
+	_ = MustObserve(g, bind)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonParentChanged, ExpertNode(bind).LastRecomputeReason())
+	testutil.Equal(t, RecomputeReasonNecessary, ExpertNode(b).LastRecomputeReason())
+	testutil.Equal(t, RecomputeReasonNone, ExpertNode(a).LastRecomputeReason())
+}
+
+func Test_RecomputeReason_linked(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	lt := newLinkTestIncr(g, v0)
+	_ = MustObserve(g, lt)
+
+	v1 := Var(g, "b")
+	m1 := Map(g, v1, ident)
+	_ = MustObserve(g, m1)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	lt.inputs = append(lt.inputs, m1)
+	err = Link(lt, m1)
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonLinked, ExpertNode(lt).RecomputeReason())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonLinked, ExpertNode(lt).LastRecomputeReason())
+	testutil.Equal(t, RecomputeReasonNone, ExpertNode(lt).RecomputeReason())
+}
+
+func Test_RecomputeReason_StructuredTracer(t *testing.T) {
+	rt := new(reasonRecordingTracer)
+	ctx := WithTracer(testContext(), rt)
+	g := New()
+
+	v := Var(g, "a")
+	m := Map(g, v, ident)
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonNecessary, rt.reasons[m.Node().ID()])
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonVarSet, rt.reasons[v.Node().ID()])
+	testutil.Equal(t, RecomputeReasonParentChanged, rt.reasons[m.Node().ID()])
+}
+
+type reasonRecordingTracer struct {
+	recordingStructuredTracer
+	reasons map[Identifier]RecomputeReason
+}
+
+func (r *reasonRecordingTracer) OnRecompute(_ context.Context, node NodeMetadata) {
+	if r.reasons == nil {
+		r.reasons = make(map[Identifier]RecomputeReason)
+	}
+	r.reasons[node.ID] = node.RecomputeReason
+}
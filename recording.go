@@ -0,0 +1,295 @@
+package incr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// StartRecording starts recording the var sets, stale marks, observes,
+// unobserves and stabilizations applied to the graph, returning the recording
+// which can later be applied to another graph with [ReplayRecording], e.g. to
+// reproduce a bug seen in production in a test.
+//
+// Recording continues until [Graph.StopRecording] is called; calling StartRecording
+// again replaces the graph's current recording with a new one.
+func (graph *Graph) StartRecording() *Recording {
+	recording := new(Recording)
+	graph.recording.Store(recording)
+	return recording
+}
+
+// StopRecording stops the recording started with [Graph.StartRecording], if any.
+func (graph *Graph) StopRecording() {
+	graph.recording.Store(nil)
+}
+
+// RecordingEventKind is the kind of a [RecordingEvent].
+type RecordingEventKind string
+
+// RecordingEventKind values.
+const (
+	RecordingEventSet       RecordingEventKind = "set"
+	RecordingEventSetStale  RecordingEventKind = "set_stale"
+	RecordingEventObserve   RecordingEventKind = "observe"
+	RecordingEventUnobserve RecordingEventKind = "unobserve"
+	RecordingEventStabilize RecordingEventKind = "stabilize"
+)
+
+// RecordingEvent is an event captured by a [Recording].
+type RecordingEvent struct {
+	// Kind is the kind of the event.
+	Kind RecordingEventKind `json:"kind"`
+	// StabilizationNum is the stabilization number of the graph as of the event,
+	// that is, the stabilization the event was applied before (or during).
+	StabilizationNum uint64 `json:"stabilization_num"`
+	// NodeID is the identifier of the node the event applies to, which
+	// for observe and unobserve events is the observed node.
+	NodeID Identifier `json:"node_id,omitempty"`
+	// NodeLabel is the label of the node the event applies to, if any.
+	NodeLabel string `json:"node_label,omitempty"`
+	// ObserverID is the identifier of the observer for observe and unobserve events.
+	ObserverID Identifier `json:"observer_id,omitempty"`
+	// Value is the value set for set events.
+	Value any `json:"value,omitempty"`
+}
+
+// Recording is a log of the events applied to a graph as
+// captured by [Graph.StartRecording].
+//
+// A recording can be serialized to JSON if the values set on vars can be.
+type Recording struct {
+	mu     sync.Mutex
+	events []RecordingEvent
+}
+
+// Events returns a copy of the events captured by the recording so far.
+func (r *Recording) Events() []RecordingEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	output := make([]RecordingEvent, len(r.events))
+	copy(output, r.events)
+	return output
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *Recording) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recordingJSON{Events: r.Events()})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Recording) UnmarshalJSON(data []byte) error {
+	var decoded recordingJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.events = decoded.Events
+	r.mu.Unlock()
+	return nil
+}
+
+type recordingJSON struct {
+	Events []RecordingEvent `json:"events"`
+}
+
+func (r *Recording) record(e RecordingEvent) {
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+}
+
+// ReplayRecording applies the events of a given recording to a graph in order.
+//
+// Nodes are resolved by identifier, e.g. when replaying onto the graph the recording
+// was made on or a clone of it, and otherwise by label, in which case the node must be
+// the only node with that label; to replay onto a graph that has been constructed again
+// (e.g. in a test) label the nodes involved with [Node.SetLabel].
+//
+// Nodes are resolved from the nodes the graph tracks and from a given list of nodes
+// and their parents, which should include any nodes the graph doesn't track yet,
+// e.g. nodes that are only observed by events in the recording.
+//
+// Observe events create an observer of the resolved node; unobserve events
+// unobserve the observer created for the matching observe event.
+//
+// An error is returned if a node can't be resolved, if a value can't be set on a
+// var, or if a stabilization returns an error, in which case the remaining
+// events are not applied.
+func ReplayRecording(ctx context.Context, g *Graph, r *Recording, nodes ...INode) error {
+	rp := &replay{
+		graph:     g,
+		nodes:     make(map[Identifier]INode),
+		labels:    make(map[string][]INode),
+		observers: make(map[Identifier]IObserver),
+	}
+	rp.index(nodes)
+	for index, e := range r.Events() {
+		if err := rp.apply(ctx, e); err != nil {
+			return fmt.Errorf("replay recording; event %d (%s): %w", index, e.Kind, err)
+		}
+	}
+	return nil
+}
+
+// replay holds the state of a call to [ReplayRecording].
+type replay struct {
+	graph *Graph
+	// nodes and labels index the nodes passed to [ReplayRecording]
+	// and their parents by identifier and label respectively.
+	nodes  map[Identifier]INode
+	labels map[string][]INode
+	// observers holds the observers created for observe
+	// events by the identifier of the recorded observer.
+	observers map[Identifier]IObserver
+}
+
+func (rp *replay) index(nodes []INode) {
+	pending := new(queue[INode])
+	for _, n := range nodes {
+		pending.push(n)
+	}
+	for pending.len() > 0 {
+		n, _ := pending.pop()
+		nn := n.Node()
+		if _, ok := rp.nodes[nn.id]; ok {
+			continue
+		}
+		rp.nodes[nn.id] = n
+		if nn.label != "" {
+			rp.labels[nn.label] = append(rp.labels[nn.label], n)
+		}
+		// the node may not have been initialized yet, so
+		// we can't rely on its node metadata for its parents.
+		if typed, ok := n.(IParents); ok {
+			for _, p := range typed.Parents() {
+				pending.push(p)
+			}
+		}
+	}
+}
+
+func (rp *replay) apply(ctx context.Context, e RecordingEvent) error {
+	switch e.Kind {
+	case RecordingEventStabilize:
+		return rp.graph.Stabilize(ctx)
+	case RecordingEventUnobserve:
+		o, ok := rp.observers[e.ObserverID]
+		if !ok {
+			n, err := rp.resolve(e.ObserverID, "")
+			if err != nil {
+				return err
+			}
+			if o, ok = n.(IObserver); !ok {
+				return fmt.Errorf("node %v is not an observer", n)
+			}
+		}
+		o.Unobserve(ctx)
+		delete(rp.observers, e.ObserverID)
+		return nil
+	}
+	n, err := rp.resolve(e.NodeID, e.NodeLabel)
+	if err != nil {
+		return err
+	}
+	switch e.Kind {
+	case RecordingEventSet:
+		s, ok := n.(recordingSetter)
+		if !ok {
+			return fmt.Errorf("node %v is not a var", n)
+		}
+		return s.setRecorded(e.Value)
+	case RecordingEventSetStale:
+		rp.graph.SetStale(n)
+		return nil
+	case RecordingEventObserve:
+		o := WithinScope(rp.graph, &replayObserver{
+			n:        NewNode("observer"),
+			observed: n,
+		})
+		if err := rp.graph.observeNode(o, n); err != nil {
+			return err
+		}
+		rp.observers[e.ObserverID] = o
+		return nil
+	default:
+		return fmt.Errorf("unknown event kind %q", e.Kind)
+	}
+}
+
+func (rp *replay) resolve(id Identifier, label string) (INode, error) {
+	if n, ok := rp.graph.GetNode(id); ok {
+		return n, nil
+	}
+	if n, ok := rp.nodes[id]; ok {
+		return n, nil
+	}
+	if label != "" {
+		found := rp.graph.FindByLabel(label)
+		for _, n := range rp.labels[label] {
+			if !rp.graph.Has(n) {
+				found = append(found, n)
+			}
+		}
+		switch len(found) {
+		case 0:
+		case 1:
+			return found[0], nil
+		default:
+			return nil, fmt.Errorf("found %d nodes with label %q", len(found), label)
+		}
+	}
+	return nil, fmt.Errorf("node %s (label %q) not found", id.Short(), label)
+}
+
+// recordingSetter is implemented by vars that can
+// apply values from a recording, which may have been
+// decoded from JSON as generic values.
+type recordingSetter interface {
+	setRecorded(any) error
+}
+
+// record adds an event to the graph's recording, if any.
+func (graph *Graph) record(kind RecordingEventKind, n INode, fn func(*RecordingEvent)) {
+	recording := graph.recording.Load()
+	if recording == nil {
+		return
+	}
+	e := RecordingEvent{
+		Kind:             kind,
+		StabilizationNum: graph.stabilizationNum,
+	}
+	if n != nil {
+		e.NodeID = n.Node().id
+		e.NodeLabel = n.Node().label
+	}
+	if fn != nil {
+		fn(&e)
+	}
+	recording.record(e)
+}
+
+var (
+	_ IObserver    = (*replayObserver)(nil)
+	_ fmt.Stringer = (*replayObserver)(nil)
+)
+
+// replayObserver is an untyped observer created by [ReplayRecording].
+type replayObserver struct {
+	n          *Node
+	observed   INode
+	unobserved bool
+}
+
+func (o *replayObserver) Node() *Node { return o.n }
+
+func (o *replayObserver) Unobserve(_ context.Context) {
+	if o.unobserved {
+		return
+	}
+	GraphForNode(o).unobserveNode(o, o.observed)
+	o.unobserved = true
+}
+
+func (o *replayObserver) String() string { return o.n.String() }
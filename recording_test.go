@@ -0,0 +1,199 @@
+package incr
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_StartRecording(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	r := g.StartRecording()
+
+	v := Var(g, "hello")
+	v.Node().SetLabel("v")
+	m := Map(g, v, ident)
+	m.Node().SetLabel("m")
+	om := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	v.Set("world")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	g.SetStale(v)
+	om.Unobserve(ctx)
+	g.StopRecording()
+
+	v.Set("not recorded")
+
+	events := r.Events()
+	testutil.Equal(t, 6, len(events))
+
+	testutil.Equal(t, RecordingEventObserve, events[0].Kind)
+	testutil.Equal(t, m.Node().ID(), events[0].NodeID)
+	testutil.Equal(t, "m", events[0].NodeLabel)
+	testutil.Equal(t, om.Node().ID(), events[0].ObserverID)
+	testutil.Equal(t, uint64(1), events[0].StabilizationNum)
+
+	testutil.Equal(t, RecordingEventStabilize, events[1].Kind)
+	testutil.Equal(t, uint64(1), events[1].StabilizationNum)
+
+	testutil.Equal(t, RecordingEventSet, events[2].Kind)
+	testutil.Equal(t, v.Node().ID(), events[2].NodeID)
+	testutil.Equal(t, "world", events[2].Value)
+	testutil.Equal(t, uint64(2), events[2].StabilizationNum)
+
+	testutil.Equal(t, RecordingEventStabilize, events[3].Kind)
+	testutil.Equal(t, uint64(2), events[3].StabilizationNum)
+
+	testutil.Equal(t, RecordingEventSetStale, events[4].Kind)
+	testutil.Equal(t, v.Node().ID(), events[4].NodeID)
+
+	testutil.Equal(t, RecordingEventUnobserve, events[5].Kind)
+	testutil.Equal(t, om.Node().ID(), events[5].ObserverID)
+}
+
+func Test_Graph_StartRecording_internalSetStale(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 1)
+	v1 := Var(g, 2)
+	s := SumN(g, v0)
+	_ = MustObserve(g, s)
+	_ = MustObserve(g, v1)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	r := g.StartRecording()
+
+	// nodes the graph marks stale itself aren't recorded.
+	err = s.AddInput(v1)
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonLinked, s.Node().recomputeReason)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	err = s.RemoveInput(v0.Node().ID())
+	testutil.NoError(t, err)
+	testutil.Equal(t, RecomputeReasonLinked, s.Node().recomputeReason)
+	err = g.RecomputeAll(ctx)
+	testutil.NoError(t, err)
+	g.StopRecording()
+
+	for _, e := range r.Events() {
+		testutil.NotEqual(t, RecordingEventSetStale, e.Kind)
+	}
+}
+
+func Test_ReplayRecording(t *testing.T) {
+	ctx := testContext()
+
+	build := func() (*Graph, VarIncr[int], Incr[int], *int) {
+		g := New()
+		v := Var(g, 1)
+		v.Node().SetLabel("v")
+		var calls int
+		m := Map(g, v, func(v int) int {
+			calls++
+			return v * 2
+		})
+		m.Node().SetLabel("m")
+		return g, v, m, &calls
+	}
+
+	g, v, m, calls := build()
+	r := g.StartRecording()
+	om := MustObserve(g, m)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	v.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	g.SetStale(v)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	v.Set(3)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, om.Value())
+	testutil.Equal(t, 4, *calls)
+
+	data, err := json.Marshal(r)
+	testutil.NoError(t, err)
+
+	var decoded Recording
+	err = json.Unmarshal(data, &decoded)
+	testutil.NoError(t, err)
+	testutil.Equal(t, len(r.Events()), len(decoded.Events()))
+
+	replayGraph, _, replayMap, replayCalls := build()
+	err = ReplayRecording(ctx, replayGraph, &decoded, replayMap)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, replayMap.Value())
+	testutil.Equal(t, *calls, *replayCalls)
+	testutil.Equal(t, g.StabilizationNum(), replayGraph.StabilizationNum())
+	testutil.Equal(t, true, replayGraph.Has(replayMap))
+}
+
+func Test_ReplayRecording_unobserve(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "hello")
+	m := Map(g, v, ident)
+
+	r := g.StartRecording()
+	om := MustObserve(g, m)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	om.Unobserve(ctx)
+	g.StopRecording()
+
+	testutil.Equal(t, false, g.Has(m))
+
+	// replay onto the same graph resolves nodes by identifier.
+	err = ReplayRecording(ctx, g, r, m)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.Has(m))
+	testutil.Equal(t, 0, len(g.observers))
+}
+
+func Test_ReplayRecording_notFound(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "hello")
+	v.Node().SetLabel("v")
+
+	r := g.StartRecording()
+	_ = MustObserve(g, v)
+
+	err := ReplayRecording(ctx, New(), r)
+	testutil.Error(t, err)
+	testutil.Matches(t, `replay recording; event 0 \(observe\): node .* \(label "v"\) not found`, err.Error())
+}
+
+func Test_ReplayRecording_ambiguousLabel(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "hello")
+	v.Node().SetLabel("v")
+
+	r := g.StartRecording()
+	_ = MustObserve(g, v)
+
+	replayGraph := New()
+	v0 := Var(replayGraph, "hello")
+	v0.Node().SetLabel("v")
+	v1 := Var(replayGraph, "hello")
+	v1.Node().SetLabel("v")
+
+	err := ReplayRecording(ctx, replayGraph, r, v0, v1)
+	testutil.Error(t, err)
+	testutil.Matches(t, `found 2 nodes with label "v"`, err.Error())
+}
@@ -0,0 +1,46 @@
+package incr
+
+import "context"
+
+// Result is a value that carries either a value or an error.
+//
+// It is used by [MapResult] to let errors flow through the
+// graph as data instead of aborting stabilization.
+type Result[A any] struct {
+	Value A
+	Err   error
+}
+
+// MapResult applies a function that can return an error to a given input incremental and
+// returns a new incremental of a [Result] holding the output value or the error.
+//
+// Unlike [MapContext], an error returned by the function will not abort the stabilization,
+// but will instead be set on the [Result], letting downstream nodes inspect the error and
+// decide what to do with it.
+//
+// To convert the result back into a node that aborts stabilization on error, use [UnwrapResult].
+func MapResult[A, B any](scope Scope, input Incr[A], fn func(A) (B, error)) Incr[Result[B]] {
+	return MapResultContext(scope, input, func(_ context.Context, a A) (B, error) {
+		return fn(a)
+	})
+}
+
+// MapResultContext is like [MapResult] but the function is passed the stabilization context.
+func MapResultContext[A, B any](scope Scope, input Incr[A], fn func(context.Context, A) (B, error)) Incr[Result[B]] {
+	m := MapContext(scope, input, func(ctx context.Context, a A) (Result[B], error) {
+		value, err := fn(ctx, a)
+		return Result[B]{Value: value, Err: err}, nil
+	})
+	m.Node().SetKind("map_result")
+	return m
+}
+
+// UnwrapResult returns an incremental that yields the value of an input [Result] incremental,
+// or returns the [Result] error during stabilization, aborting the stabilization.
+func UnwrapResult[A any](scope Scope, input Incr[Result[A]]) Incr[A] {
+	m := MapContext(scope, input, func(_ context.Context, r Result[A]) (A, error) {
+		return r.Value, r.Err
+	})
+	m.Node().SetKind("unwrap_result")
+	return m
+}
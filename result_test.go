@@ -0,0 +1,78 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_MapResult(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "1")
+	mr := MapResult(g, v, strconv.Atoi)
+	m := Map(g, mr, func(r Result[int]) string {
+		if r.Err != nil {
+			return "error"
+		}
+		return fmt.Sprint(r.Value * 2)
+	})
+	other := Map(g, Var(g, "other"), ident)
+	om := MustObserve(g, m)
+	oo := MustObserve(g, other)
+
+	testutil.Equal(t, "map_result", mr.Node().Kind())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "2", om.Value())
+	testutil.Equal(t, "other", oo.Value())
+
+	v.Set("not-a-number")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "error", om.Value())
+	testutil.NotNil(t, mr.Value().Err)
+}
+
+func Test_MapResultContext(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "1")
+	mr := MapResultContext(g, v, func(ctx context.Context, vv string) (int, error) {
+		testutil.BlueDye(ctx, t)
+		return strconv.Atoi(vv)
+	})
+	o := MustObserve(g, mr)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, o.Value().Value)
+	testutil.Nil(t, o.Value().Err)
+}
+
+func Test_UnwrapResult(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "1")
+	mr := MapResult(g, v, strconv.Atoi)
+	ur := UnwrapResult(g, mr)
+	o := MustObserve(g, ur)
+
+	testutil.Equal(t, "unwrap_result", ur.Node().Kind())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, o.Value())
+
+	v.Set("not-a-number")
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, 1, o.Value())
+}
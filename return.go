@@ -8,6 +8,11 @@ import (
 //
 // Note that it does not implement [IStabilize] and is effectively
 // always the same value, and never causes recomputations.
+//
+// A return node is never added to the recompute heap when it becomes necessary;
+// instead it is marked as changed in the current stabilization so that its
+// children still recompute. It is only scheduled for recomputation if it is
+// explicitly marked stale with [Graph.SetStale].
 func Return[A any](scope Scope, v A) Incr[A] {
 	return WithinScope(scope, &returnIncr[A]{
 		n: NewNode("return"),
@@ -15,10 +20,24 @@ func Return[A any](scope Scope, v A) Incr[A] {
 	})
 }
 
+// Constant yields a constant incremental for a given value.
+//
+// It behaves the same as [Return], specifically the value is available
+// immediately through [Incr.Value] without a stabilization, and the node is
+// never added to the recompute heap when it becomes necessary; it differs only
+// in its kind, which is "constant".
+func Constant[A any](scope Scope, v A) Incr[A] {
+	return WithinScope(scope, &returnIncr[A]{
+		n: NewNode("constant"),
+		v: v,
+	})
+}
+
 var (
 	_ Incr[string]         = (*returnIncr[string])(nil)
 	_ IShouldBeInvalidated = (*returnIncr[string])(nil)
 	_ IStale               = (*returnIncr[string])(nil)
+	_ iConstant            = (*returnIncr[string])(nil)
 	_ fmt.Stringer         = (*returnIncr[string])(nil)
 )
 
@@ -31,6 +50,8 @@ func (r returnIncr[A]) Stale() bool {
 	return r.n.recomputedAt == 0
 }
 
+func (r returnIncr[A]) isConstant() {}
+
 func (vn *returnIncr[T]) ShouldBeInvalidated() bool {
 	return false
 }
@@ -1,6 +1,7 @@
 package incr
 
 import (
+	"context"
 	"testing"
 
 	"github.com/wcharczuk/go-incr/testutil"
@@ -14,3 +15,129 @@ func Test_Return(t *testing.T) {
 
 	testutil.Equal(t, false, r.(*returnIncr[string]).ShouldBeInvalidated())
 }
+
+func Test_Return_recomputedOnce(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	r := Return(g, "hello")
+	v := Var(g, "world")
+	m := Map2(g, r, v, concat)
+
+	o := MustObserve(g, m)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "helloworld", o.Value())
+	// return nodes are constants, and are marked changed
+	// when they become necessary rather than recomputed.
+	testutil.Equal(t, 0, r.Node().numRecomputes)
+	testutil.Equal(t, 1, r.Node().changedAt)
+	testutil.Equal(t, true, r.Node().isConstant())
+
+	v.Set("there")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hellothere", o.Value())
+	testutil.Equal(t, 0, r.Node().numRecomputes)
+
+	// unobserve and re-observe; the return should
+	// not be re-added to the recompute heap.
+	o.Unobserve(ctx)
+	testutil.Equal(t, false, g.Has(r))
+
+	o = MustObserve(g, m)
+	testutil.Equal(t, true, g.Has(r))
+	testutil.Equal(t, false, g.recomputeHeap.has(r))
+	testutil.Equal(t, true, g.recomputeHeap.has(m))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hellothere", o.Value())
+	testutil.Equal(t, 0, r.Node().numRecomputes)
+
+	// explicitly setting the return stale should recompute it.
+	g.SetStale(r)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, r.Node().numRecomputes)
+}
+
+func Test_Constant(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	c := Constant(g, "hello")
+	testutil.Equal(t, "constant", c.Node().Kind())
+	testutil.Equal(t, "hello", c.Value())
+
+	v := Var(g, " world")
+	m := Map2(g, c, v, concat)
+	o := MustObserve(g, m)
+	testutil.Equal(t, false, g.recomputeHeap.has(c))
+	testutil.Equal(t, true, g.recomputeHeap.has(m))
+	testutil.Equal(t, "hello", c.Value())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello world", o.Value())
+	testutil.Equal(t, 0, c.Node().numRecomputes)
+	testutil.Equal(t, 1, c.Node().changedAt)
+	testutil.Equal(t, RecomputeReasonNecessary, c.Node().lastRecomputeReason)
+}
+
+func Test_Constant_manyNotInRecomputeHeap(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	var outputs []Incr[int]
+	for x := 0; x < 10; x++ {
+		var inputs []Incr[int]
+		for y := 0; y < 100; y++ {
+			inputs = append(inputs, Constant(g, 1))
+		}
+		outputs = append(outputs, MapN(g, sum, inputs...))
+	}
+	o := MustObserve(g, MapN(g, sum, outputs...))
+	testutil.Equal(t, 11, g.recomputeHeap.len())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1000, o.Value())
+	testutil.Equal(t, 11, g.numNodesRecomputed)
+}
+
+func Test_Constant_withinBind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "a")
+	b := Bind(g, v, func(bs Scope, which string) Incr[string] {
+		return Map(bs, Constant(bs, which+"-constant"), ident)
+	})
+	o := MustObserve(g, b)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a-constant", o.Value())
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b-constant", o.Value())
+}
+
+func Test_Constant_OnUpdate(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	c := Constant(g, "hello")
+	var updates int
+	c.Node().OnUpdate(func(_ context.Context) {
+		updates++
+	})
+	_ = MustObserve(g, c)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, updates)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, updates)
+}
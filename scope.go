@@ -1,6 +1,9 @@
 package incr
 
-import "fmt"
+import (
+	"fmt"
+	"sync/atomic"
+)
 
 // WithinScope updates a node's createdIn scope to reflect a new inner-most
 // bind scope applied by a bind.
@@ -15,8 +18,18 @@ import "fmt"
 // sufficient to pass the [Bind] function's provided scope to node constructors
 // to associate the scopes correctly. This method is exported for advanced use
 // cases where you want to manage scopes manually.
+//
+// If the scope's graph was created with [OptGraphIdentifierProvider], the node will
+// also be assigned a new identifier from that provider. The node is also assigned
+// its creation order within the graph (see [Node.Order]).
 func WithinScope[A INode](scope Scope, node A) A {
 	node.Node().createdIn = scope
+	if graph := scope.scopeGraph(); graph != nil {
+		if graph.identifierProvider != nil {
+			node.Node().id = graph.identifierProvider()
+		}
+		node.Node().order = atomic.AddUint64(&graph.nodeOrder, 1)
+	}
 	if scope != nil && scope.isTopScope() {
 		return node
 	}
@@ -34,10 +47,15 @@ func GraphForScope(s Scope) *Graph {
 
 // GraphForNode returns the graph for a given node as derrived through
 // the scope it was created in, which must return a graph reference.
+//
+// It returns nil for nodes removed with [Graph.RemoveNode].
 func GraphForNode(node INode) *Graph {
 	if node == nil {
 		return nil
 	}
+	if node.Node().createdIn == nil {
+		return nil
+	}
 	return node.Node().createdIn.scopeGraph()
 }
 
@@ -53,5 +71,6 @@ type Scope interface {
 	scopeGraph() *Graph
 	scopeHeight() int
 	addScopeNode(INode)
+	scopeNodes() []INode
 	fmt.Stringer
 }
@@ -18,3 +18,61 @@ func Test_graphFromNodeCreatedIn_unset(t *testing.T) {
 	g := GraphForNode(nil)
 	testutil.Nil(t, g)
 }
+
+func scopedConstructors() []func(Scope) INode {
+	return []func(Scope) INode{
+		func(s Scope) INode { return Var(s, "") },
+		func(s Scope) INode { return Return(s, "") },
+		func(s Scope) INode { return Map(s, Return(s, ""), ident) },
+		func(s Scope) INode { return Map2(s, Return(s, ""), Return(s, ""), concat) },
+		func(s Scope) INode {
+			return Map3[string, string, string, string](s, Return(s, ""), Return(s, ""), Return(s, ""), nil)
+		},
+		func(s Scope) INode { return MapN[string, string](s, nil, Return(s, "")) },
+		func(s Scope) INode {
+			return Bind(s, Return(s, ""), func(bs Scope, v string) Incr[string] { return Return(bs, v) })
+		},
+		func(s Scope) INode { return Cutoff(s, Return(s, ""), nil) },
+		func(s Scope) INode { return Func[string](s, nil) },
+		func(s Scope) INode { return Always(s, Return(s, "")) },
+		func(s Scope) INode { return Freeze(s, Return(s, "")) },
+		func(s Scope) INode { return Watch(s, Return(s, "")) },
+		func(s Scope) INode { return MapIf(s, Return(s, ""), Return(s, ""), Return(s, false)) },
+		func(s Scope) INode { return At(s, Return(s, []string{""}), Return(s, 0), nil) },
+		func(s Scope) INode { return SumN[int](s) },
+	}
+}
+
+func Test_constructors_attachToScopeGraph(t *testing.T) {
+	g := New()
+	for _, fn := range scopedConstructors() {
+		n := fn(g)
+		testutil.Equal(t, true, GraphForNode(n) == g, n.Node().Kind())
+		testutil.Equal(t, true, n.Node().createdIn == Scope(g))
+	}
+}
+
+func Test_constructors_attachToScopeGraph_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	var scope Scope
+	var nodes []INode
+	b := Bind(g, Return(g, ""), func(bs Scope, _ string) Incr[string] {
+		scope = bs
+		for _, fn := range scopedConstructors() {
+			nodes = append(nodes, fn(bs))
+		}
+		return Return(bs, "")
+	})
+	_ = MustObserve(g, b)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.NotNil(t, scope)
+	testutil.Equal(t, len(scopedConstructors()), len(nodes))
+	for _, n := range nodes {
+		testutil.Equal(t, true, GraphForNode(n) == g, n.Node().Kind())
+		testutil.Equal(t, true, n.Node().createdIn == scope)
+	}
+}
@@ -0,0 +1,131 @@
+package incr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Sequence returns an incremental whose value is the slice of the values of the
+// given input incrementals, with the order of the output slice matching the order the
+// inputs were provided (or added with [SequenceIncr.AddInput]).
+//
+// The sequence node will only propagate changes to its children if one of its inputs
+// changed, or if inputs were added or removed.
+//
+// Any <nil> inputs passed to the constructor will cause stabilization to fail with an error.
+func Sequence[A any](scope Scope, inputs ...Incr[A]) SequenceIncr[A] {
+	s := &sequenceIncr[A]{
+		n: NewNode("sequence"),
+	}
+	for index, input := range inputs {
+		if input == nil {
+			s.err = fmt.Errorf("sequence; input at index %d is <nil>", index)
+			continue
+		}
+		s.inputs = append(s.inputs, input)
+	}
+	return WithinScope(scope, s)
+}
+
+// SequenceIncr is a type of incremental that can add or remove inputs over time.
+type SequenceIncr[A any] interface {
+	Incr[[]A]
+	// AddInput adds an input to the end of the sequence.
+	//
+	// If the input is <nil> an error is returned.
+	AddInput(Incr[A]) error
+	// RemoveInput removes an input from the sequence by the input's identifier.
+	//
+	// If none of the inputs have the identifier [ErrInputNotFound] is returned.
+	RemoveInput(Identifier) error
+}
+
+var errSequenceInputNil = errors.New("sequence; input is <nil>, cannot continue")
+
+var (
+	_ SequenceIncr[string] = (*sequenceIncr[string])(nil)
+	_ IParents             = (*sequenceIncr[string])(nil)
+	_ IStabilize           = (*sequenceIncr[string])(nil)
+	_ ICutoff              = (*sequenceIncr[string])(nil)
+	_ fmt.Stringer         = (*sequenceIncr[string])(nil)
+)
+
+type sequenceIncr[A any] struct {
+	n            *Node
+	inputs       []Incr[A]
+	err          error
+	inputsDirty  bool
+	stabilizedAt uint64
+	val          []A
+}
+
+func (s *sequenceIncr[A]) Parents() []INode {
+	output := make([]INode, len(s.inputs))
+	for i := 0; i < len(s.inputs); i++ {
+		output[i] = s.inputs[i]
+	}
+	return output
+}
+
+func (s *sequenceIncr[A]) AddInput(i Incr[A]) error {
+	if i == nil {
+		return errSequenceInputNil
+	}
+	s.inputs = append(s.inputs, i)
+	s.inputsDirty = true
+	if s.n.height != HeightUnset {
+		// if we're already part of the graph, we have
+		// to tell the graph to update our parent<>child metadata
+		graph := GraphForNode(s)
+		if err := graph.addChild(s, i); err != nil {
+			return err
+		}
+		graph.setStale(s, RecomputeReasonLinked)
+	}
+	return nil
+}
+
+func (s *sequenceIncr[A]) RemoveInput(id Identifier) error {
+	var removed Incr[A]
+	s.inputs, removed = remove(s.inputs, id)
+	if removed == nil {
+		return ErrInputNotFound
+	}
+	s.inputsDirty = true
+	return removeInput(s, removed)
+}
+
+func (s *sequenceIncr[A]) Node() *Node { return s.n }
+
+func (s *sequenceIncr[A]) Value() []A { return s.val }
+
+// Cutoff returns true if none of the inputs have changed since
+// the last time the node was stabilized, and no inputs were added or removed.
+func (s *sequenceIncr[A]) Cutoff(_ context.Context) (bool, error) {
+	if s.stabilizedAt == 0 || s.inputsDirty {
+		return false, nil
+	}
+	for _, i := range s.inputs {
+		if i.Node().changedAt > s.stabilizedAt {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *sequenceIncr[A]) Stabilize(_ context.Context) error {
+	if s.err != nil {
+		return s.err
+	}
+	val := make([]A, len(s.inputs))
+	for index := range s.inputs {
+		val[index] = s.inputs[index].Value()
+	}
+	s.val = val
+	s.inputsDirty = false
+	s.stabilizedAt = GraphForNode(s).stabilizationNum
+	return nil
+}
+
+func (s *sequenceIncr[A]) String() string { return s.n.String() }
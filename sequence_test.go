@@ -0,0 +1,170 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Sequence(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	v2 := Var(g, "c")
+	s := Sequence[string](g, v0, v1, v2)
+	o := MustObserve[[]string](g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"a", "b", "c"}, o.Value())
+
+	v1.Set("B")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"a", "B", "c"}, o.Value())
+}
+
+func Test_Sequence_AddInput_afterStabilization(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	s := Sequence[string](g, v0, v1)
+	o := MustObserve[[]string](g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"a", "b"}, o.Value())
+
+	v2 := Var(g, "c")
+	err = s.AddInput(v2)
+	testutil.NoError(t, err)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 3, len(o.Value()))
+	testutil.Equal(t, []string{"a", "b", "c"}, o.Value())
+
+	v2.Set("C")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"a", "b", "C"}, o.Value())
+}
+
+func Test_Sequence_AddInput_alreadyNecessary(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	s := Sequence[string](g, v0)
+	o := MustObserve[[]string](g, s)
+	_ = MustObserve(g, v1)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"a"}, o.Value())
+
+	err = s.AddInput(v1)
+	testutil.NoError(t, err)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"a", "b"}, o.Value())
+}
+
+func Test_Sequence_AddInput_nil(t *testing.T) {
+	g := New()
+	s := Sequence[string](g)
+	err := s.AddInput(nil)
+	testutil.Error(t, err)
+}
+
+func Test_Sequence_nilInput(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	s := Sequence[string](g, v0, nil)
+	_ = MustObserve[[]string](g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+}
+
+func Test_Sequence_RemoveInput(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	v2 := Var(g, "c")
+	s := Sequence[string](g, v0, v1, v2)
+	o := MustObserve[[]string](g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"a", "b", "c"}, o.Value())
+
+	err = s.RemoveInput(v1.Node().ID())
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.Has(v1))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, len(o.Value()))
+	testutil.Equal(t, []string{"a", "c"}, o.Value())
+
+	err = s.RemoveInput(v1.Node().ID())
+	testutil.Equal(t, ErrInputNotFound, err)
+}
+
+func Test_Sequence_RemoveInput_beforeObserve(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	s := Sequence[string](g, v0, v1)
+
+	err := s.RemoveInput(v0.Node().ID())
+	testutil.NoError(t, err)
+
+	o := MustObserve[[]string](g, s)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []string{"b"}, o.Value())
+	testutil.Equal(t, false, g.Has(v0))
+}
+
+func Test_Sequence_cutoff(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	s := Sequence[string](g, v0, v1)
+	var calls int
+	m := Map(g, s, func(values []string) int {
+		calls++
+		return len(values)
+	})
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+
+	g.SetStale(s)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, calls)
+
+	v0.Set("A")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, calls)
+}
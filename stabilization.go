@@ -0,0 +1,109 @@
+package incr
+
+import "context"
+
+// StabilizeStart starts a stabilization that is advanced one height block
+// at a time with [Stabilization.Step], returning a handle to the stabilization.
+//
+// This is useful for debugging and teaching, where you may want to inspect
+// the values of nodes as the stabilization progresses; [Graph.Read] doesn't
+// block between steps.
+//
+// While the stabilization is open, that is until [Stabilization.Finish] is called,
+// the graph is considered stabilizing and calls to [Graph.Stabilize] will
+// return [ErrAlreadyStabilizing]. Values set on vars while the stabilization
+// is open are applied when [Stabilization.Finish] is called.
+func (graph *Graph) StabilizeStart(ctx context.Context) (*Stabilization, error) {
+	if err := graph.claimStabilization(ctx); err != nil {
+		return nil, err
+	}
+	ctx = graph.stabilizeStart(ctx)
+	return &Stabilization{
+		ctx:   ctx,
+		graph: graph,
+	}, nil
+}
+
+// Stabilization is a handle to a stabilization started with [Graph.StabilizeStart].
+type Stabilization struct {
+	ctx                context.Context
+	graph              *Graph
+	immediateRecompute []INode
+	err                error
+	done               bool
+	finished           bool
+}
+
+// Step recomputes each of the nodes in the minimum height block of
+// the recompute heap, returning if there are no more nodes to recompute.
+//
+// If a node returns an error the error is returned and the stabilization
+// is considered done; [Stabilization.Finish] must still be called to end the stabilization.
+func (s *Stabilization) Step() (done bool, err error) {
+	if s.done {
+		return true, s.err
+	}
+	graph := s.graph
+	graph.readMu.Lock()
+	defer graph.readMu.Unlock()
+	if graph.deterministicOrdering {
+		s.immediateRecompute, err = graph.recomputeMinHeightOrdered(s.ctx, s.immediateRecompute)
+	} else {
+		err = s.stepUnordered()
+	}
+	if err != nil || graph.recomputeHeap.len() == 0 {
+		s.err = err
+		s.done = true
+		graph.stabilizeFinishPass(s.ctx, err, s.immediateRecompute)
+	}
+	done = s.done
+	return
+}
+
+// stepUnordered recomputes the minimum height block of the recompute heap
+// in the order the nodes are held by the heap.
+//
+// If a node returns an error, the nodes in the block that have not been
+// recomputed yet are returned to the recompute heap.
+func (s *Stabilization) stepUnordered() (err error) {
+	graph := s.graph
+	var iter recomputeHeapListIter
+	graph.recomputeHeap.setIterToMinHeight(&iter)
+	for next, ok := iter.Next(); ok; next, ok = iter.Next() {
+		err = graph.recompute(s.ctx, next, false /*parallel*/)
+		if next.Node().always {
+			s.immediateRecompute = append(s.immediateRecompute, next)
+		}
+		if err != nil {
+			var remaining []INode
+			for n, ok := iter.Next(); ok; n, ok = iter.Next() {
+				remaining = append(remaining, n)
+			}
+			graph.recomputeHeap.add(remaining...)
+			return
+		}
+	}
+	return
+}
+
+// Remaining returns the number of nodes left in the recompute heap.
+func (s *Stabilization) Remaining() int {
+	return s.graph.recomputeHeap.len()
+}
+
+// Finish runs the stabilization to completion and ends the stabilization,
+// returning the first error returned by a node (if any).
+//
+// Finish must be called to end the stabilization, even if [Stabilization.Step]
+// has returned done. Calling Finish more than once has no effect.
+func (s *Stabilization) Finish() (err error) {
+	if s.finished {
+		return s.err
+	}
+	for !s.done {
+		_, _ = s.Step()
+	}
+	s.finished = true
+	s.graph.stabilizeEnd(s.ctx, s.err)
+	return s.err
+}
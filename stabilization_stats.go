@@ -0,0 +1,64 @@
+package incr
+
+import "time"
+
+// StabilizationStats are statistics about a stabilization pass
+// passed to [Graph.OnStabilizationEndStats] handlers.
+type StabilizationStats struct {
+	// StabilizationNum is the stabilization number of the pass.
+	StabilizationNum uint64
+	// Started is the time the stabilization pass started.
+	Started time.Time
+	// Elapsed is the duration of the stabilization pass, not
+	// including the time spent calling update handlers.
+	Elapsed time.Duration
+	// NodesRecomputed is the number of nodes recomputed during the pass.
+	NodesRecomputed int
+	// HeightHistogram is the number of nodes recomputed at each height
+	// during the pass, indexed by height.
+	//
+	// It is useful to tell how "wide" the graph is when deciding if [Graph.ParallelStabilize]
+	// is worth it; if a few heights have many nodes each parallelism can help,
+	// but if the graph is a deep chain with one node at each height it will not.
+	HeightHistogram []int
+}
+
+// MaxWidth returns the largest number of nodes recomputed
+// at any single height during the pass.
+func (ss StabilizationStats) MaxWidth() (output int) {
+	for _, count := range ss.HeightHistogram {
+		output = max(output, count)
+	}
+	return
+}
+
+// stabilizationStats returns the stats for the stabilization in progress.
+func (graph *Graph) stabilizationStats() StabilizationStats {
+	stats := StabilizationStats{
+		StabilizationNum: graph.stabilizationNum,
+		Started:          graph.stabilizationStarted,
+		Elapsed:          time.Since(graph.stabilizationStarted),
+		HeightHistogram:  make([]int, len(graph.heightHistogram)),
+	}
+	copy(stats.HeightHistogram, graph.heightHistogram)
+	for _, count := range stats.HeightHistogram {
+		stats.NodesRecomputed += count
+	}
+	return stats
+}
+
+// recordRecomputeHeight records that a node was recomputed at
+// a given height in the height histogram.
+func (graph *Graph) recordRecomputeHeight(height int, parallel bool) {
+	if height < 0 {
+		return
+	}
+	if parallel {
+		graph.heightHistogramMu.Lock()
+		defer graph.heightHistogramMu.Unlock()
+	}
+	for len(graph.heightHistogram) <= height {
+		graph.heightHistogram = append(graph.heightHistogram, 0)
+	}
+	graph.heightHistogram[height]++
+}
@@ -0,0 +1,99 @@
+package incr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_OnStabilizationEndStats(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 1)
+	var maps []Incr[int]
+	for x := 0; x < 10; x++ {
+		maps = append(maps, Map(g, v, func(vv int) int { return vv + 1 }))
+	}
+	o := MustObserve(g, MapN(g, sum, maps...))
+
+	var stats []StabilizationStats
+	g.OnStabilizationEndStats(func(_ context.Context, ss StabilizationStats, err error) {
+		testutil.NoError(t, err)
+		stats = append(stats, ss)
+	})
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 20, o.Value())
+
+	v.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 30, o.Value())
+
+	testutil.Equal(t, 2, len(stats))
+	testutil.Equal(t, 1, stats[0].StabilizationNum)
+	testutil.Equal(t, false, stats[0].Started.IsZero())
+	testutil.Equal(t, []int{0, 10, 1}, stats[0].HeightHistogram)
+	testutil.Equal(t, 11, stats[0].NodesRecomputed)
+	testutil.Equal(t, 10, stats[0].MaxWidth())
+
+	testutil.Equal(t, 2, stats[1].StabilizationNum)
+	testutil.Equal(t, []int{1, 10, 1}, stats[1].HeightHistogram)
+	testutil.Equal(t, 12, stats[1].NodesRecomputed)
+}
+
+func Test_Graph_OnStabilizationEndStats_chain(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 0)
+	var cursor Incr[int] = v
+	for x := 0; x < 5; x++ {
+		cursor = Map(g, cursor, func(vv int) int { return vv + 1 })
+	}
+	o := MustObserve(g, cursor)
+
+	var stats StabilizationStats
+	g.OnStabilizationEndStats(func(_ context.Context, ss StabilizationStats, _ error) {
+		stats = ss
+	})
+
+	v.Set(1)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, o.Value())
+	testutil.Equal(t, []int{1, 1, 1, 1, 1, 1}, stats.HeightHistogram)
+	testutil.Equal(t, 1, stats.MaxWidth())
+}
+
+func Test_Graph_OnStabilizationEndStats_ParallelStabilize(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 1)
+	var maps []Incr[int]
+	for x := 0; x < 10; x++ {
+		maps = append(maps, Map(g, v, func(vv int) int { return vv + 1 }))
+	}
+	_ = MustObserve(g, MapN(g, sum, maps...))
+
+	var stats StabilizationStats
+	g.OnStabilizationEndStats(func(_ context.Context, ss StabilizationStats, _ error) {
+		stats = ss
+	})
+
+	err := g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{0, 10, 1}, stats.HeightHistogram)
+	testutil.Equal(t, 10, stats.MaxWidth())
+}
+
+func Test_Graph_heightHistogram_notTracked(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	_ = MustObserve(g, Map(g, Var(g, 1), ident))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Empty(t, g.heightHistogram)
+}
@@ -0,0 +1,213 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_StabilizeStart_unevenHeights(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "foo")
+	v1 := Var(g, "bar")
+	m0 := Map2(g, v0, v1, func(a, b string) string {
+		return a + " " + b
+	})
+	r0 := Return(g, "moo")
+	m1 := Map2(g, r0, m0, func(a, b string) string {
+		return a + " != " + b
+	})
+	_ = MustObserve(g, m1)
+
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, g.IsStabilizing())
+	// the return is a constant and is not added to the recompute heap.
+	testutil.Equal(t, 2, s.Remaining())
+	testutil.Equal(t, "moo", r0.Value())
+
+	done, err := s.Step()
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, done)
+	testutil.Equal(t, "foo bar", m0.Value())
+	testutil.Equal(t, "", m1.Value())
+	testutil.Equal(t, 1, s.Remaining())
+
+	done, err = s.Step()
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, done)
+	testutil.Equal(t, "moo != foo bar", m1.Value())
+	testutil.Equal(t, 0, s.Remaining())
+
+	// stepping after we're done should be a no-op.
+	done, err = s.Step()
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, done)
+
+	err = s.Finish()
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.IsStabilizing())
+	testutil.Equal(t, 2, g.stabilizationNum)
+
+	v0.Set("not foo")
+	s, err = g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+
+	done, err = s.Step()
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, done)
+	testutil.Equal(t, "not foo", v0.Value())
+	testutil.Equal(t, "foo bar", m0.Value())
+
+	err = s.Finish()
+	testutil.NoError(t, err)
+	testutil.Equal(t, "moo != not foo bar", m1.Value())
+}
+
+func Test_Graph_StabilizeStart_alreadyStabilizing(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "foo")
+	m0 := Map(g, v0, ident)
+	o := MustObserve(g, m0)
+
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+
+	err = g.Stabilize(ctx)
+	testutil.Equal(t, ErrAlreadyStabilizing, err)
+	_, err = g.StabilizeStart(ctx)
+	testutil.Equal(t, ErrAlreadyStabilizing, err)
+
+	// sets while the stabilization is open are deferred until finish.
+	v0.Set("bar")
+	testutil.Equal(t, "foo", v0.Value())
+
+	err = s.Finish()
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", o.Value())
+	testutil.Equal(t, "bar", v0.Value())
+
+	err = s.Finish()
+	testutil.NoError(t, err)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", o.Value())
+}
+
+func Test_Graph_StabilizeStart_concurrent(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "foo")
+	_ = MustObserve(g, v0)
+
+	// only one of the calls can claim the stabilization.
+	const workers = 8
+	var wg sync.WaitGroup
+	var started int32
+	for x := 0; x < workers; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := g.StabilizeStart(ctx); err == nil {
+				atomic.AddInt32(&started, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	testutil.Equal(t, int32(1), started)
+	testutil.Equal(t, ErrAlreadyStabilizing, g.Stabilize(ctx))
+	testutil.Equal(t, ErrAlreadyStabilizing, g.ParallelStabilize(ctx))
+}
+
+func Test_Graph_StabilizeStart_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "foo")
+	f0 := MapContext(g, v0, func(_ context.Context, _ string) (string, error) {
+		return "", fmt.Errorf("this is just a test")
+	})
+	m0 := Map(g, f0, ident)
+	_ = MustObserve(g, m0)
+
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+
+	done, err := s.Step()
+	testutil.Error(t, err)
+	testutil.Equal(t, true, done)
+	testutil.Equal(t, true, g.recomputeHeap.has(m0))
+
+	err = s.Finish()
+	testutil.Error(t, err)
+	testutil.Equal(t, false, g.IsStabilizing())
+}
+
+func Test_Graph_StabilizeStart_errorRemainingBlock(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "foo")
+	f0 := MapContext(g, v0, func(_ context.Context, _ string) (string, error) {
+		return "", fmt.Errorf("this is just a test")
+	})
+	m0 := Map(g, v0, ident)
+	m1 := Map(g, v0, ident)
+	_ = g.MustObserveMany(ctx, f0, m0, m1)
+
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+
+	_, err = s.Step()
+	testutil.Error(t, err)
+	// the nodes in the block after the node that errored are returned to the heap.
+	testutil.Equal(t, 2, s.Remaining())
+	testutil.Equal(t, true, g.recomputeHeap.has(m0))
+	testutil.Equal(t, true, g.recomputeHeap.has(m1))
+
+	err = s.Finish()
+	testutil.Error(t, err)
+}
+
+func Test_Graph_StabilizeStart_setStaleConcurrently(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "foo")
+	m0 := Map(g, v, ident)
+	m1 := Map(g, m0, ident)
+	o := MustObserve(g, m1)
+
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for x := 0; x < 100; x++ {
+			g.SetStale(m0)
+		}
+	}()
+	for {
+		var stepDone bool
+		stepDone, err = s.Step()
+		testutil.NoError(t, err)
+		if stepDone {
+			break
+		}
+	}
+	<-done
+	err = s.Finish()
+	testutil.NoError(t, err)
+	testutil.Equal(t, "foo", o.Value())
+}
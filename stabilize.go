@@ -2,6 +2,8 @@ package incr
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 )
 
 // Stabilize kicks off the stabilization for nodes that have been observed by the graph's scope.
@@ -21,17 +23,72 @@ import (
 // to worry about shared resource contention and can skip acquiring locks.
 //
 // If during the stabilization pass a node's stabilize function returns an error, the recomputation pass
-// is stopped and the error is returned.
+// is stopped and the error is returned. Panics in a node's functions are returned as a [PanicError]
+// unless the graph was created with [OptGraphDisablePanicRecovery].
 func (graph *Graph) Stabilize(ctx context.Context) (err error) {
-	if err = graph.ensureNotStabilizing(ctx); err != nil {
+	if err = graph.claimStabilization(ctx); err != nil {
+		return
+	}
+	if graph.skipEmptyStabilizations && !graph.NeedsStabilization() {
+		graph.lastStabilizeChanged = false
+		atomic.StoreInt32(&graph.status, StatusNotStabilizing)
 		return
 	}
 	ctx = graph.stabilizeStart(ctx)
 	defer func() {
 		graph.stabilizeEnd(ctx, err)
 	}()
+	err = graph.stabilize(ctx)
+	return
+}
 
+// StabilizeIfNeeded stabilizes the graph with [Graph.Stabilize] if there is any work
+// to do, as determined by [Graph.NeedsStabilization], returning if the graph was stabilized.
+//
+// If there is no work to do the stabilization handlers are not called and the
+// stabilization number is not advanced.
+func (graph *Graph) StabilizeIfNeeded(ctx context.Context) (stabilized bool, err error) {
+	if err = graph.ensureNotStabilizing(ctx); err != nil {
+		return
+	}
+	if !graph.NeedsStabilization() {
+		graph.lastStabilizeChanged = false
+		return
+	}
+	stabilized = true
+	err = graph.Stabilize(ctx)
+	return
+}
+
+// NeedsStabilization returns if a call to [Graph.Stabilize] would have any work to do, that is,
+// if there are nodes in the recompute heap or values set on vars during a stabilization that
+// have yet to be applied.
+//
+// Note that [Always] nodes are added back to the recompute heap after each
+// stabilization, and as a result graphs with necessary [Always] nodes
+// always need stabilization.
+func (graph *Graph) NeedsStabilization() bool {
+	if graph.recomputeHeap.len() > 0 {
+		return true
+	}
+	graph.setDuringStabilizationMu.Lock()
+	defer graph.setDuringStabilizationMu.Unlock()
+	return len(graph.setDuringStabilization) > 0
+}
+
+func (graph *Graph) stabilize(ctx context.Context) (err error) {
+	graph.readMu.Lock()
+	defer graph.readMu.Unlock()
 	var immediateRecompute []INode
+	if graph.deterministicOrdering {
+		for graph.recomputeHeap.numItems > 0 {
+			if immediateRecompute, err = graph.recomputeMinHeightOrdered(ctx, immediateRecompute); err != nil {
+				break
+			}
+		}
+		graph.stabilizeFinishPass(ctx, err, immediateRecompute)
+		return
+	}
 	var next INode
 	for graph.recomputeHeap.numItems > 0 {
 		next, _ = graph.recomputeHeap.removeMinUnsafe()
@@ -43,20 +100,67 @@ func (graph *Graph) Stabilize(ctx context.Context) (err error) {
 			break
 		}
 	}
-	if err != nil {
-		if graph.clearRecomputeHeapOnError {
-			aborted := graph.recomputeHeap.clear()
-			for _, node := range aborted {
-				for _, ah := range node.Node().onAbortedHandlers {
-					ah(ctx, err)
-				}
-			}
+	graph.stabilizeFinishPass(ctx, err, immediateRecompute)
+	return
+}
+
+// recomputeMinHeightOrdered recomputes the nodes in the minimum height block of
+// the recompute heap in the order they were created in, appending [Always] nodes
+// to the immediate recompute list.
+//
+// If a node returns an error, the nodes in the block that have not been recomputed
+// yet are returned to the recompute heap.
+func (graph *Graph) recomputeMinHeightOrdered(ctx context.Context, immediateRecompute []INode) ([]INode, error) {
+	block := graph.recomputeHeap.removeMinHeightOrdered()
+	for index, next := range block {
+		err := graph.recompute(ctx, next, false /*parallel*/)
+		if next.Node().always {
+			immediateRecompute = append(immediateRecompute, next)
+		}
+		if err != nil {
+			graph.recomputeHeap.add(block[index+1:]...)
+			return immediateRecompute, err
 		}
 	}
+	return immediateRecompute, nil
+}
+
+// stabilizeFinishPass handles the bookkeeping at the end of a serial
+// stabilization pass, specifically aborting the remaining recompute heap
+// on error and re-adding [Always] nodes to the recompute heap.
+func (graph *Graph) stabilizeFinishPass(ctx context.Context, err error, immediateRecompute []INode) {
+	if err != nil {
+		graph.stabilizeHandleError(ctx, err)
+	}
 	if len(immediateRecompute) > 0 {
 		for _, n := range immediateRecompute {
-			graph.recomputeHeap.addIfNotPresent(n)
+			graph.recomputeHeap.addIfNotPresent(n, RecomputeReasonAlways)
+		}
+	}
+}
+
+// stabilizeHandleError handles an error returned by a stabilization pass.
+//
+// If the error is [ErrStabilizationAborted] the nodes that aborted the stabilization are
+// added back to the recompute heap so that the graph can be stabilized again, otherwise
+// the recompute heap is cleared if the graph was created with [OptGraphClearRecomputeHeapOnError].
+func (graph *Graph) stabilizeHandleError(ctx context.Context, err error) {
+	if errors.Is(err, ErrStabilizationAborted) {
+		graph.abortedByMu.Lock()
+		for _, n := range graph.abortedBy {
+			graph.recomputeHeap.addIfNotPresent(n, n.Node().lastRecomputeReason)
+		}
+		clear(graph.abortedBy)
+		graph.abortedBy = graph.abortedBy[:0]
+		graph.abortedByMu.Unlock()
+		return
+	}
+	if graph.clearRecomputeHeapOnError {
+		aborted := graph.recomputeHeap.clear()
+		for _, node := range aborted {
+			for _, ah := range node.Node().onAbortedHandlers {
+				ah(ctx, err)
+			}
 		}
 	}
-	return
 }
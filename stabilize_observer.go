@@ -0,0 +1,135 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// StabilizeObserver stabilizes only the part of the graph a given observer
+// depends on, that is, it recomputes the nodes in the recompute heap that are
+// ancestors of the node the observer observes, in height order.
+//
+// Other nodes in the recompute heap, including the children of recomputed nodes
+// that the observer does not depend on, are left in the recompute heap to be
+// recomputed by the next call to [Graph.Stabilize].
+//
+// If a [Bind] node the observer depends on links in new nodes during the
+// stabilization, those nodes are recomputed as well.
+//
+// An error is returned if the observer is not tracked by the graph.
+func (graph *Graph) StabilizeObserver(ctx context.Context, o IObserver) (err error) {
+	if err = graph.claimStabilization(ctx); err != nil {
+		return
+	}
+	if !graph.HasObserver(o) {
+		atomic.StoreInt32(&graph.status, StatusNotStabilizing)
+		return fmt.Errorf("stabilize observer; observer %v is not tracked by the graph", o)
+	}
+	ctx = graph.stabilizeStart(ctx)
+	defer func() {
+		graph.stabilizeEnd(ctx, err)
+	}()
+	err = graph.stabilizeObserver(ctx, o)
+	return
+}
+
+func (graph *Graph) stabilizeObserver(ctx context.Context, o IObserver) (err error) {
+	graph.readMu.Lock()
+	defer graph.readMu.Unlock()
+	ancestors := graph.observerAncestors(o)
+
+	var deferred, immediateRecompute []INode
+	var next INode
+	for graph.recomputeHeap.numItems > 0 {
+		next, _ = graph.recomputeHeap.removeMinUnsafe()
+		if _, ok := ancestors[next.Node().id]; !ok {
+			deferred = append(deferred, next)
+			continue
+		}
+		err = graph.recompute(ctx, next, false /*parallel*/)
+		if next.Node().always {
+			immediateRecompute = append(immediateRecompute, next)
+		}
+		if err != nil {
+			break
+		}
+		// bind nodes can link in new ancestors of the observer, including
+		// nodes we've already passed over, which have lower heights.
+		if _, isBindChange := next.(IBindChange); isBindChange {
+			ancestors = graph.observerAncestors(o)
+			deferred = slices.DeleteFunc(deferred, func(n INode) bool {
+				if _, ok := ancestors[n.Node().id]; ok {
+					graph.recomputeHeap.addIfNotPresent(n, n.Node().recomputeReason)
+					return true
+				}
+				return false
+			})
+		}
+	}
+	for _, n := range deferred {
+		graph.recomputeHeap.addIfNotPresent(n, n.Node().recomputeReason)
+	}
+	graph.stabilizeFinishPass(ctx, err, immediateRecompute)
+	return
+}
+
+// iObserverInput is implemented by observers that can
+// return the node they observe without searching the graph.
+type iObserverInput interface {
+	observedNode() INode
+}
+
+var (
+	_ iObserverInput = (*observeIncr[string])(nil)
+	_ iObserverInput = (*observeManyIncr)(nil)
+)
+
+func (o *observeIncr[A]) observedNode() INode {
+	if o.unobserved {
+		return nil
+	}
+	return o.observed
+}
+
+func (o *observeManyIncr) observedNode() INode {
+	return o.observed
+}
+
+// observerAncestors returns the set of nodes a given observer
+// depends on, including the node the observer observes.
+func (graph *Graph) observerAncestors(o IObserver) map[Identifier]struct{} {
+	var observed []INode
+	if typed, ok := o.(iObserverInput); ok {
+		if n := typed.observedNode(); n != nil {
+			observed = append(observed, n)
+		}
+	} else {
+		graph.nodesMu.Lock()
+		for _, n := range graph.nodes {
+			if slices.ContainsFunc(n.Node().observers, func(on IObserver) bool { return on.Node().id == o.Node().id }) {
+				observed = append(observed, n)
+			}
+		}
+		graph.nodesMu.Unlock()
+	}
+
+	ancestors := make(map[Identifier]struct{})
+	pending := new(queue[INode])
+	for _, n := range observed {
+		ancestors[n.Node().id] = struct{}{}
+		pending.push(n)
+	}
+	for pending.len() > 0 {
+		n, _ := pending.pop()
+		for _, p := range n.Node().parents {
+			if _, seen := ancestors[p.Node().id]; seen {
+				continue
+			}
+			ancestors[p.Node().id] = struct{}{}
+			pending.push(p)
+		}
+	}
+	return ancestors
+}
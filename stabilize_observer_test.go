@@ -0,0 +1,143 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_StabilizeObserver(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	va := Var(g, "a")
+	ma := Map(g, va, func(v string) string { return v + "!" })
+	oa := MustObserve(g, ma)
+
+	vb := Var(g, "b")
+	mb := Map(g, vb, func(v string) string { return v + "?" })
+	ob := MustObserve(g, mb)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a!", oa.Value())
+	testutil.Equal(t, "b?", ob.Value())
+
+	va.Set("aa")
+	vb.Set("bb")
+
+	err = g.StabilizeObserver(ctx, oa)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "aa!", oa.Value())
+	testutil.Equal(t, "b?", ob.Value())
+	testutil.Equal(t, true, g.recomputeHeap.has(vb))
+	testutil.Equal(t, false, g.recomputeHeap.has(va))
+	testutil.Equal(t, false, g.recomputeHeap.has(ma))
+	testutil.Equal(t, 1, g.recomputeHeap.len())
+	testutil.NoError(t, g.SanityCheck())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "aa!", oa.Value())
+	testutil.Equal(t, "bb?", ob.Value())
+	testutil.Equal(t, 2, ExpertNode(ma).NumRecomputes())
+	testutil.Equal(t, 2, ExpertNode(mb).NumRecomputes())
+}
+
+func Test_Graph_StabilizeObserver_sharedInput(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "a")
+	ma := Map(g, v, func(v string) string { return v + "!" })
+	mb := Map(g, v, func(v string) string { return v + "?" })
+	oa := MustObserve(g, ma)
+	ob := MustObserve(g, mb)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	v.Set("b")
+	err = g.StabilizeObserver(ctx, oa)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b!", oa.Value())
+	testutil.Equal(t, "a?", ob.Value())
+	testutil.Equal(t, true, g.recomputeHeap.has(mb), "children the observer doesn't depend on should be left pending")
+	testutil.Equal(t, RecomputeReasonParentChanged, mb.Node().recomputeReason)
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b?", ob.Value())
+}
+
+func Test_Graph_StabilizeObserver_bind(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	va := Var(g, "a")
+	vb := Var(g, "b")
+	ob := MustObserve(g, Map(g, vb, ident))
+
+	sw := Var(g, true)
+	bind := Bind(g, sw, func(bs Scope, swv bool) Incr[string] {
+		if swv {
+			return Map(bs, va, func(v string) string { return v + "!" })
+		}
+		return Map(bs, vb, func(v string) string { return v + "!" })
+	})
+	o := MustObserve(g, bind)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a!", o.Value())
+	testutil.Equal(t, "b", ob.Value())
+
+	// vb has a lower height than the bind, and isn't an ancestor
+	// of the observer until the bind relinks.
+	vb.Set("bb")
+	sw.Set(false)
+
+	err = g.StabilizeObserver(ctx, o)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bb!", o.Value())
+	testutil.Equal(t, "b", ob.Value())
+	testutil.NoError(t, g.SanityCheck())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bb!", o.Value())
+	testutil.Equal(t, "bb", ob.Value())
+}
+
+func Test_Graph_StabilizeObserver_observeMany(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	va := Var(g, "a")
+	vb := Var(g, "b")
+	ma := Map(g, va, ident)
+	mb := Map(g, vb, ident)
+	observers := g.MustObserveMany(ctx, ma, mb)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	va.Set("aa")
+	vb.Set("bb")
+
+	err = g.StabilizeObserver(ctx, observers[0])
+	testutil.NoError(t, err)
+	testutil.Equal(t, "aa", ma.Value())
+	testutil.Equal(t, "b", mb.Value())
+}
+
+func Test_Graph_StabilizeObserver_untracked(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	o := MustObserve(g, Var(g, "a"))
+	o.Unobserve(ctx)
+
+	err := g.StabilizeObserver(ctx, o)
+	testutil.Error(t, err)
+}
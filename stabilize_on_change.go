@@ -0,0 +1,75 @@
+package incr
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StabilizeOnChange starts a goroutine that stabilizes the graph when there
+// is work to do, that is, when nodes are marked stale (e.g. by setting vars),
+// coalescing changes such that the graph is stabilized at most once per
+// given minimum interval, returning a function that stops the goroutine.
+//
+// This is useful if vars are set far more often than the graph needs to be
+// stabilized, e.g. by a high frequency data feed, in place of stabilizing on a timer.
+//
+// Stabilization errors are not returned; register a handler with
+// [Graph.OnStabilizationEnd] to be notified of them.
+//
+// The stop function waits for any stabilization in progress to complete. The
+// goroutine is also stopped if the given context is canceled.
+//
+// At most one goroutine should be started per graph.
+func (graph *Graph) StabilizeOnChange(ctx context.Context, minInterval time.Duration) (stop func()) {
+	return graph.stabilizeOnChange(ctx, minInterval, func(_ context.Context) time.Time { return time.Now().UTC() }, time.After)
+}
+
+func (graph *Graph) stabilizeOnChange(ctx context.Context, minInterval time.Duration, clock func(context.Context) time.Time, after func(time.Duration) <-chan time.Time) (stop func()) {
+	stopped := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		last := clock(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopped:
+				return
+			case <-graph.changed:
+			}
+			if wait := last.Add(minInterval).Sub(clock(ctx)); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-stopped:
+					return
+				case <-after(wait):
+				}
+			}
+			if !graph.NeedsStabilization() {
+				continue
+			}
+			// if the graph is already stabilizing, vars set during the
+			// stabilization will signal us again when they're applied.
+			_ = graph.Stabilize(ctx)
+			last = clock(ctx)
+		}
+	}()
+	var stopOnce sync.Once
+	return func() {
+		stopOnce.Do(func() {
+			close(stopped)
+		})
+		<-done
+	}
+}
+
+// notifyChanged signals that the graph may need to be stabilized.
+func (graph *Graph) notifyChanged() {
+	select {
+	case graph.changed <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,176 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_StabilizeOnChange(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	clock := newFakeClock()
+	start := clock.Now(ctx)
+
+	var stabilizations int32
+	g.OnStabilizationEnd(func(_ context.Context, _ time.Time, _ error) {
+		atomic.AddInt32(&stabilizations, 1)
+	})
+
+	v := Var(g, 0)
+	om := MustObserve(g, Map(g, v, func(v int) int { return v * 2 }))
+
+	const minInterval = 10 * time.Millisecond
+	stop := g.stabilizeOnChange(ctx, minInterval, clock.Now, clock.After)
+	defer stop()
+
+	for x := 0; x < 100; x++ {
+		v.Set(x)
+		clock.Advance(time.Millisecond)
+	}
+	waitFor(t, func() bool {
+		clock.Advance(minInterval)
+		value, _ := om.ValueStable()
+		return value == 198
+	})
+	stop()
+
+	window := clock.Now(ctx).Sub(start)
+	maxStabilizations := int32(math.Ceil(float64(window) / float64(minInterval)))
+	testutil.Equal(t, true, atomic.LoadInt32(&stabilizations) > 0)
+	testutil.Equal(t, true, atomic.LoadInt32(&stabilizations) <= maxStabilizations, fmt.Sprintf("%d stabilizations over %v", stabilizations, window))
+}
+
+func Test_Graph_StabilizeOnChange_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	errs := make(chan error, 1)
+	g.OnStabilizationEnd(func(_ context.Context, _ time.Time, err error) {
+		if err != nil {
+			errs <- err
+		}
+	})
+	v := Var(g, "hello")
+	_ = MustObserve(g, MapContext(g, v, func(_ context.Context, v string) (string, error) {
+		if v == "error" {
+			return "", fmt.Errorf("this is only a test")
+		}
+		return v, nil
+	}))
+
+	stop := g.StabilizeOnChange(ctx, time.Millisecond)
+	defer stop()
+
+	v.Set("error")
+	select {
+	case err := <-errs:
+		testutil.Matches(t, "this is only a test", err.Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for stabilization error")
+	}
+}
+
+func Test_Graph_StabilizeOnChange_stopWaitsForStabilization(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var finished int32
+	v := Var(g, "hello")
+	_ = MustObserve(g, Map(g, v, func(v string) string {
+		if v == "block" {
+			close(started)
+			<-release
+			atomic.StoreInt32(&finished, 1)
+		}
+		return v
+	}))
+
+	stop := g.StabilizeOnChange(ctx, time.Millisecond)
+	v.Set("block")
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("stop returned before the stabilization completed")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	<-stopped
+	testutil.Equal(t, int32(1), atomic.LoadInt32(&finished))
+	testutil.Equal(t, false, g.IsStabilizing())
+}
+
+func waitFor(t *testing.T, fn func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !fn() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 01, 01, 12, 0, 0, 0, time.UTC)}
+}
+
+// fakeClock is a clock that only advances when told to,
+// firing the channels returned by After as it does.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeClockWaiter
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func (fc *fakeClock) Now(_ context.Context) time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+func (fc *fakeClock) After(d time.Duration) <-chan time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- fc.now
+		return ch
+	}
+	fc.waiters = append(fc.waiters, fakeClockWaiter{deadline: fc.now.Add(d), ch: ch})
+	return ch
+}
+
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.now = fc.now.Add(d)
+	waiters := fc.waiters[:0]
+	for _, w := range fc.waiters {
+		if w.deadline.After(fc.now) {
+			waiters = append(waiters, w)
+			continue
+		}
+		w.ch <- fc.now
+	}
+	fc.waiters = waiters
+}
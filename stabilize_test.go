@@ -3,6 +3,7 @@ package incr
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -82,7 +83,7 @@ func Test_Stabilize_error_noClear(t *testing.T) {
 
 	err := g.Stabilize(ctx)
 	testutil.NotNil(t, err)
-	testutil.Equal(t, "this is just a test", err.Error())
+	testutil.Equal(t, "this is just a test", errors.Unwrap(err).Error())
 
 	testutil.Equal(t, true, g.recomputeHeap.has(m1), "we should not clear the recompute heap on error")
 	testutil.Equal(t, false, g.recomputeHeap.has(f0))
@@ -115,7 +116,7 @@ func Test_Stabilize_error_shouldClear(t *testing.T) {
 
 	err := g.Stabilize(ctx)
 	testutil.NotNil(t, err)
-	testutil.Equal(t, "this is just a test", err.Error())
+	testutil.Equal(t, "this is just a test", errors.Unwrap(err).Error())
 
 	testutil.Equal(t, false, g.recomputeHeap.has(m1), "we should clear the recompute heap on error")
 	testutil.Equal(t, false, g.recomputeHeap.has(f0))
@@ -139,10 +140,258 @@ func Test_Stabilize_errorHandler(t *testing.T) {
 
 	err := g.Stabilize(ctx)
 	testutil.NotNil(t, err)
-	testutil.Equal(t, "this is just a test", err.Error())
+	testutil.Equal(t, "this is just a test", errors.Unwrap(err).Error())
 	testutil.Equal(t, "this is just a test", gotError.Error())
 }
 
+func Test_Stabilize_error_nodeError(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "hello")
+	m0 := Map(g, v0, ident)
+	_ = MustObserve(g, m0)
+
+	errTest := fmt.Errorf("this is just a test")
+	f0 := MapContext(g, m0, func(_ context.Context, _ string) (string, error) {
+		return "", errTest
+	})
+	f0.Node().SetLabel("f0")
+	var gotError error
+	f0.Node().OnError(func(_ context.Context, err error) {
+		gotError = err
+	})
+	_ = MustObserve(g, f0)
+
+	err := g.Stabilize(ctx)
+	testutil.NotNil(t, err)
+	testutil.Equal(t, true, errors.Is(err, errTest))
+
+	var nodeErr *NodeError
+	testutil.Equal(t, true, errors.As(err, &nodeErr))
+	testutil.Equal(t, f0.Node().ID(), nodeErr.ID)
+	testutil.Equal(t, "f0", nodeErr.Label)
+	testutil.Equal(t, "map", nodeErr.Kind)
+	testutil.Equal(t, 2, nodeErr.Height)
+	testutil.Equal(t, errTest, nodeErr.Err)
+	testutil.Matches(t, `map\[(.*)\]:f0@2: this is just a test`, err.Error())
+
+	// the error handler should get the original error.
+	testutil.Equal(t, errTest, gotError)
+}
+
+func Test_ParallelStabilize_error_nodeError(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	errTest := fmt.Errorf("this is just a test")
+	f0 := Func(g, func(_ context.Context) (string, error) {
+		return "", errTest
+	})
+	f0.Node().SetLabel("f0")
+	_ = MustObserve(g, f0)
+
+	err := g.ParallelStabilize(ctx)
+	testutil.NotNil(t, err)
+
+	var nodeErr *NodeError
+	testutil.Equal(t, true, errors.As(err, &nodeErr))
+	testutil.Equal(t, "f0", nodeErr.Label)
+	testutil.Equal(t, true, errors.Is(err, errTest))
+}
+
+func Test_Stabilize_panic(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 0)
+	m0 := Map(g, v0, func(vv int) int {
+		return 10 / vv
+	})
+	m0.Node().SetLabel("m0")
+	var gotError error
+	m0.Node().OnError(func(_ context.Context, err error) {
+		gotError = err
+	})
+	o := MustObserve(g, m0)
+
+	err := g.Stabilize(ctx)
+	testutil.NotNil(t, err)
+
+	var nodeErr *NodeError
+	testutil.Equal(t, true, errors.As(err, &nodeErr))
+	testutil.Equal(t, m0.Node().ID(), nodeErr.ID)
+	testutil.Equal(t, "m0", nodeErr.Label)
+
+	var panicErr *PanicError
+	testutil.Equal(t, true, errors.As(err, &panicErr))
+	testutil.Matches(t, `panic: runtime error: integer divide by zero`, err.Error())
+	testutil.Equal(t, true, strings.HasPrefix(err.Error(), fmt.Sprintf("map[%s]:m0@1: panic: ", m0.Node().ID().Short())))
+	testutil.Equal(t, true, strings.Contains(panicErr.Stack, "Test_Stabilize_panic"))
+	testutil.Equal(t, panicErr, gotError)
+	testutil.Equal(t, false, g.IsStabilizing())
+
+	v0.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 5, o.Value())
+}
+
+func Test_Stabilize_panic_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	errTest := fmt.Errorf("this is just a test")
+	m0 := Map(g, Var(g, "hello"), func(_ string) string {
+		panic(errTest)
+	})
+	_ = MustObserve(g, m0)
+
+	err := g.Stabilize(ctx)
+	testutil.NotNil(t, err)
+	testutil.Equal(t, true, errors.Is(err, errTest))
+}
+
+func Test_ParallelStabilize_panic(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, "")
+	m0 := Map(g, v0, func(vv string) string {
+		if vv == "" {
+			panic("empty value")
+		}
+		return vv + "!"
+	})
+	o := MustObserve(g, m0)
+
+	err := g.ParallelStabilize(ctx)
+	testutil.NotNil(t, err)
+
+	var panicErr *PanicError
+	testutil.Equal(t, true, errors.As(err, &panicErr))
+	testutil.Equal(t, "empty value", panicErr.Value)
+	testutil.Equal(t, false, g.IsStabilizing())
+
+	v0.Set("hello")
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello!", o.Value())
+}
+
+func Test_Stabilize_panic_disableRecovery(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphDisablePanicRecovery(true))
+
+	m0 := Map(g, Var(g, "hello"), func(_ string) string {
+		panic("fail fast")
+	})
+	_ = MustObserve(g, m0)
+
+	var recovered any
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		_ = g.Stabilize(ctx)
+	}()
+	testutil.Equal(t, "fail fast", recovered)
+	testutil.Equal(t, false, g.IsStabilizing())
+}
+
+func Test_Graph_StabilizeIfNeeded(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	var starts, ends int
+	g.OnStabilizationStart(func(_ context.Context) { starts++ })
+	g.OnStabilizationEnd(func(_ context.Context, _ time.Time, _ error) { ends++ })
+
+	v := Var(g, "hello")
+	o := MustObserve(g, Map(g, v, ident))
+	testutil.Equal(t, true, g.NeedsStabilization())
+
+	stabilized, err := g.StabilizeIfNeeded(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, stabilized)
+	testutil.Equal(t, "hello", o.Value())
+	testutil.Equal(t, false, g.NeedsStabilization())
+	stabilizationNum := g.StabilizationNum()
+
+	stabilized, err = g.StabilizeIfNeeded(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, stabilized)
+	testutil.Equal(t, stabilizationNum, g.StabilizationNum())
+	testutil.Equal(t, 1, starts)
+	testutil.Equal(t, 1, ends)
+
+	v.Set("world")
+	testutil.Equal(t, true, g.NeedsStabilization())
+	stabilized, err = g.StabilizeIfNeeded(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, stabilized)
+	testutil.Equal(t, "world", o.Value())
+	testutil.Equal(t, stabilizationNum+1, g.StabilizationNum())
+	testutil.Equal(t, 2, starts)
+}
+
+func Test_Graph_NeedsStabilization_setDuringStabilization(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	m := Map(g, v, func(vv string) string {
+		if vv == "hello" {
+			v.Set("world")
+		}
+		return vv
+	})
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello", o.Value())
+	testutil.Equal(t, true, g.NeedsStabilization())
+
+	stabilized, err := g.StabilizeIfNeeded(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, stabilized)
+	testutil.Equal(t, "world", o.Value())
+	testutil.Equal(t, false, g.NeedsStabilization())
+}
+
+func Test_Stabilize_skipEmptyStabilizations(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphSkipEmptyStabilizations(true))
+
+	var starts, ends int
+	g.OnStabilizationStart(func(_ context.Context) { starts++ })
+	g.OnStabilizationEnd(func(_ context.Context, _ time.Time, _ error) { ends++ })
+
+	v := Var(g, "hello")
+	o := MustObserve(g, Map(g, v, ident))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello", o.Value())
+	stabilizationNum := g.StabilizationNum()
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, stabilizationNum, g.StabilizationNum())
+	testutil.Equal(t, 1, starts)
+	testutil.Equal(t, 1, ends)
+
+	v.Set("world")
+	err = g.ParallelStabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "world", o.Value())
+	testutil.Equal(t, stabilizationNum+1, g.StabilizationNum())
+	testutil.Equal(t, 2, starts)
+}
+
 func Test_Stabilize_alreadyStabilizing(t *testing.T) {
 	ctx := testContext()
 
@@ -295,6 +544,52 @@ func Test_Stabilize_setDuringStabilization(t *testing.T) {
 	testutil.Equal(t, 1, g.recomputeHeap.numItems)
 }
 
+func Test_Stabilize_setDuringStabilization_update(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v0 := Var(g, "foo")
+
+	called := make(chan struct{})
+	wait := make(chan struct{})
+	m0 := Map(g, v0, func(v string) string {
+		if v == "foo" {
+			close(called)
+			<-wait
+		}
+		return v
+	})
+
+	o := MustObserve(g, m0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = g.Stabilize(ctx)
+	}()
+
+	<-called
+
+	// we're now stabilizing, so updates compose
+	// over the deferred set and not the visible value.
+	v0.Set("bar")
+	v0.Update(func(v string) string { return v + "-baz" })
+	v0.Update(func(v string) string { return v + "-buzz" })
+	testutil.Equal(t, "foo", v0.Value())
+
+	close(wait)
+	<-done
+
+	// we're now _done_ stabilizing
+	testutil.Equal(t, "bar-baz-buzz", v0.Value())
+	testutil.Equal(t, g.stabilizationNum, v0.Node().setAt)
+	testutil.Equal(t, 1, g.recomputeHeap.numItems)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar-baz-buzz", o.Value())
+	testutil.Equal(t, 0, g.recomputeHeap.numItems)
+}
+
 func Test_Stabilize_onUpdate(t *testing.T) {
 	ctx := testContext()
 	g := New()
@@ -828,6 +1123,84 @@ func Test_Stabilize_CutoffContext_error(t *testing.T) {
 	testutil.Equal(t, 0, output.Value())
 }
 
+func Test_Stabilize_CutoffContext_errorPropagateValue(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	input := Var(g, 3.14)
+
+	cutoff := CutoffContext(
+		g,
+		input,
+		func(_ context.Context, _, _ float64) (bool, error) {
+			return false, fmt.Errorf("this is just a test")
+		},
+	)
+	cutoff.Node().SetCutoffErrorPolicy(CutoffErrorPolicyPropagateValue)
+
+	var errors int
+	cutoff.Node().OnError(func(_ context.Context, err error) {
+		if err != nil {
+			errors++
+		}
+	})
+
+	output := Map2(
+		g,
+		cutoff,
+		Return(g, 10.0),
+		add[float64],
+	)
+
+	_ = MustObserve(g, output)
+
+	err := g.Stabilize(
+		ctx,
+	)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, errors)
+	testutil.Equal(t, 13.14, output.Value())
+
+	input.Set(3.15)
+
+	err = g.Stabilize(
+		ctx,
+	)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, errors)
+	testutil.Equal(t, 13.15, output.Value())
+}
+
+func Test_Stabilize_CutoffContext_errorPropagateValue_parallel(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	input := Var(g, 3.14)
+
+	cutoff := CutoffContext(
+		g,
+		input,
+		func(_ context.Context, _, _ float64) (bool, error) {
+			return false, fmt.Errorf("this is just a test")
+		},
+	)
+	cutoff.Node().SetCutoffErrorPolicy(CutoffErrorPolicyPropagateValue)
+
+	var errors int
+	cutoff.Node().OnError(func(_ context.Context, err error) {
+		if err != nil {
+			errors++
+		}
+	})
+	output := Map(g, cutoff, ident)
+	_ = MustObserve(g, output)
+
+	err := g.ParallelStabilize(
+		ctx,
+	)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, errors)
+	testutil.Equal(t, 3.14, output.Value())
+}
+
 func Test_Stabilize_Cutoff2(t *testing.T) {
 	ctx := testContext()
 	g := New()
@@ -939,24 +1312,311 @@ func Test_Stabilize_Cutoff2Context_error(t *testing.T) {
 	testutil.Equal(t, 0, output.Value())
 }
 
-func Test_Stabilize_Watch(t *testing.T) {
+func epsilonEnabledFn(eps float64, enabled bool, oldv, newv float64) bool {
+	if !enabled {
+		return false
+	}
+	return epsilonFn(eps, oldv, newv)
+}
+
+func Test_Stabilize_Cutoff3(t *testing.T) {
 	ctx := testContext()
 	g := New()
 
-	v0 := Var(g, 1)
-	v1 := Var(g, 1)
-	m0 := Map2(g, v0, v1, add)
-	w0 := Watch(g, m0)
+	epsilon := Var(g, 0.1)
+	enabled := Var(g, true)
+	input := Var(g, 3.14)
+	cutoff := Cutoff3(
+		g,
+		epsilon,
+		enabled,
+		input,
+		epsilonEnabledFn,
+	)
+	var outputRecomputes int
+	output := Map2(
+		g,
+		cutoff,
+		Return(g, 10.0),
+		func(v0, v1 float64) float64 {
+			outputRecomputes++
+			return v0 + v1
+		},
+	)
 
-	_ = MustObserve(g, w0)
+	_ = MustObserve(g, output)
 
-	_ = g.Stabilize(ctx)
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 13.14, output.Value())
+	testutil.Equal(t, 3.14, cutoff.Value())
+	testutil.Equal(t, 1, outputRecomputes)
 
-	testutil.Equal(t, 1, len(w0.Values()))
-	testutil.Equal(t, 2, w0.Values()[0])
-	testutil.Equal(t, 2, w0.Value())
+	input.Set(3.15)
 
-	v0.Set(2)
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.14, cutoff.Value())
+	testutil.Equal(t, 13.14, output.Value())
+
+	input.Set(3.26) // differs by 0.12, which is > 0.1
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.26, cutoff.Value())
+	testutil.Equal(t, 13.26, output.Value())
+	testutil.Equal(t, 2, outputRecomputes)
+
+	epsilon.Set(0.5)
+	input.Set(3.375) // differs by 0.115, which is < 0.5
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.26, cutoff.Value())
+	testutil.Equal(t, 13.26, output.Value())
+
+	// changing the auxiliary inputs alone re-evaluates the cutoff
+	// but does not force the value through.
+	epsilon.Set(0.4)
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.26, cutoff.Value())
+	testutil.Equal(t, 13.26, output.Value())
+	testutil.Equal(t, 2, outputRecomputes)
+
+	// disabling the cutoff lets the pending value through.
+	enabled.Set(false)
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.375, cutoff.Value())
+	testutil.Equal(t, 13.375, output.Value())
+	testutil.Equal(t, 3, outputRecomputes)
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 13.375, output.Value())
+	testutil.Equal(t, 3, outputRecomputes)
+}
+
+func Test_Stabilize_Cutoff3Context_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	epsilon := Var(g, 0.1)
+	enabled := Var(g, true)
+	input := Var(g, 3.14)
+
+	cutoff := Cutoff3Context(
+		g,
+		epsilon,
+		enabled,
+		input,
+		func(_ context.Context, _ float64, _ bool, _, _ float64) (bool, error) {
+			return false, fmt.Errorf("this is just a test")
+		},
+	)
+
+	var errors int
+	cutoff.Node().OnError(func(_ context.Context, err error) {
+		if err != nil {
+			errors++
+		}
+	})
+
+	output := Map2(
+		g,
+		cutoff,
+		Return(g, 10.0),
+		add[float64],
+	)
+
+	_ = MustObserve(g, output)
+
+	err := g.Stabilize(
+		ctx,
+	)
+	testutil.NotNil(t, err)
+	testutil.Equal(t, 1, errors)
+	testutil.Equal(t, 0, output.Value())
+	testutil.Equal(t, true, g.recomputeHeap.has(output), "the cutoff's children should stay in the recompute heap")
+
+	enabled.Set(false)
+
+	err = g.Stabilize(
+		ctx,
+	)
+	testutil.NotNil(t, err)
+	testutil.Equal(t, 2, errors)
+	testutil.Equal(t, 0, output.Value())
+}
+
+func Test_Stabilize_CutoffN(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	epsilon := Var(g, 0.1)
+	scale := Var(g, 1.0)
+	input := Var(g, 3.14)
+
+	cutoff := CutoffN(
+		g,
+		input,
+		func(aux []float64, oldv, newv float64) bool {
+			return epsilonFn(aux[0]*aux[1], oldv, newv)
+		},
+		epsilon,
+		scale,
+	)
+	var outputRecomputes int
+	output := Map2(
+		g,
+		cutoff,
+		Return(g, 10.0),
+		func(v0, v1 float64) float64 {
+			outputRecomputes++
+			return v0 + v1
+		},
+	)
+
+	_ = MustObserve(g, output)
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 13.14, output.Value())
+	testutil.Equal(t, 3.14, cutoff.Value())
+	testutil.Equal(t, 1, outputRecomputes)
+
+	input.Set(3.15)
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.14, cutoff.Value())
+	testutil.Equal(t, 13.14, output.Value())
+
+	input.Set(3.26) // differs by 0.12, which is > 0.1
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.26, cutoff.Value())
+	testutil.Equal(t, 13.26, output.Value())
+	testutil.Equal(t, 2, outputRecomputes)
+
+	scale.Set(5.0)
+	input.Set(3.375) // differs by 0.115, which is < 0.5
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.26, cutoff.Value())
+	testutil.Equal(t, 13.26, output.Value())
+
+	// changing the auxiliary inputs alone re-evaluates the cutoff
+	// but does not force the value through.
+	epsilon.Set(0.05)
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.26, cutoff.Value())
+	testutil.Equal(t, 13.26, output.Value())
+	testutil.Equal(t, 2, outputRecomputes)
+
+	// narrowing the tolerance lets the pending value through.
+	scale.Set(1.0)
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 3.375, cutoff.Value())
+	testutil.Equal(t, 13.375, output.Value())
+	testutil.Equal(t, 3, outputRecomputes)
+
+	_ = g.Stabilize(
+		ctx,
+	)
+	testutil.Equal(t, 13.375, output.Value())
+	testutil.Equal(t, 3, outputRecomputes)
+}
+
+func Test_Stabilize_CutoffNContext_error(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	epsilon := Var(g, 0.1)
+	scale := Var(g, 1.0)
+	input := Var(g, 3.14)
+
+	cutoff := CutoffNContext(
+		g,
+		input,
+		func(_ context.Context, _ []float64, _, _ float64) (bool, error) {
+			return false, fmt.Errorf("this is just a test")
+		},
+		epsilon,
+		scale,
+	)
+
+	var errors int
+	cutoff.Node().OnError(func(_ context.Context, err error) {
+		if err != nil {
+			errors++
+		}
+	})
+
+	output := Map2(
+		g,
+		cutoff,
+		Return(g, 10.0),
+		add[float64],
+	)
+
+	_ = MustObserve(g, output)
+
+	err := g.Stabilize(
+		ctx,
+	)
+	testutil.NotNil(t, err)
+	testutil.Equal(t, 1, errors)
+	testutil.Equal(t, 0, output.Value())
+
+	input.Set(3.15)
+
+	err = g.Stabilize(
+		ctx,
+	)
+	testutil.NotNil(t, err)
+	testutil.Equal(t, 2, errors)
+	testutil.Equal(t, 0, output.Value())
+}
+
+func Test_Stabilize_Watch(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 1)
+	v1 := Var(g, 1)
+	m0 := Map2(g, v0, v1, add)
+	w0 := Watch(g, m0)
+
+	_ = MustObserve(g, w0)
+
+	_ = g.Stabilize(ctx)
+
+	testutil.Equal(t, 1, len(w0.Values()))
+	testutil.Equal(t, 2, w0.Values()[0])
+	testutil.Equal(t, 2, w0.Value())
+
+	v0.Set(2)
 
 	_ = g.Stabilize(ctx)
 
@@ -1047,6 +1707,100 @@ func Test_Stabilize_Map2Context_error(t *testing.T) {
 	testutil.Equal(t, 0, m2.Value())
 }
 
+func Test_Stabilize_Map2_skipsUnchangedInputs(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v0 := Var(g, "a")
+	v1 := Var(g, "b")
+	var map2Recomputes int
+	m2 := Map2(g, v0, v1, func(a, b string) string {
+		map2Recomputes++
+		return a + b
+	})
+	var childRecomputes int
+	c := Map(g, m2, func(v string) string {
+		childRecomputes++
+		return v
+	})
+	o := MustObserve(g, c)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "ab", o.Value())
+	testutil.Equal(t, 1, map2Recomputes)
+	testutil.Equal(t, 1, childRecomputes)
+
+	// schedule the node for recomputation without changing its inputs,
+	// e.g. as would happen if its height was adjusted.
+	ExpertGraph(g).RecomputeHeapAdd(m2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, map2Recomputes)
+	testutil.Equal(t, 1, childRecomputes)
+	testutil.Equal(t, uint64(1), m2.Node().changedAt)
+
+	v0.Set("c")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "cb", o.Value())
+	testutil.Equal(t, 2, map2Recomputes)
+	testutil.Equal(t, 2, childRecomputes)
+
+	v1.Set("d")
+	ExpertGraph(g).RecomputeHeapAdd(m2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "cd", o.Value())
+	testutil.Equal(t, 3, map2Recomputes)
+	testutil.Equal(t, 3, childRecomputes)
+
+	// explicitly marking the node stale should still recompute it.
+	g.SetStale(m2)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 4, map2Recomputes)
+	testutil.Equal(t, 4, childRecomputes)
+}
+
+func Test_Stabilize_Map3_Map4_skipUnchangedInputs(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v0 := Var(g, 1)
+	v1 := Var(g, 2)
+	v2 := Var(g, 3)
+	v3 := Var(g, 4)
+	var map3Recomputes, map4Recomputes int
+	m3 := Map3(g, v0, v1, v2, func(a, b, c int) int {
+		map3Recomputes++
+		return a + b + c
+	})
+	m4 := Map4(g, v0, v1, v2, v3, func(a, b, c, d int) int {
+		map4Recomputes++
+		return a + b + c + d
+	})
+	o3 := MustObserve(g, m3)
+	o4 := MustObserve(g, m4)
+
+	for x := 0; x < 3; x++ {
+		ExpertGraph(g).RecomputeHeapAdd(m3, m4)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+	}
+	testutil.Equal(t, 6, o3.Value())
+	testutil.Equal(t, 10, o4.Value())
+	testutil.Equal(t, 1, map3Recomputes)
+	testutil.Equal(t, 1, map4Recomputes)
+
+	v3.Set(5)
+	ExpertGraph(g).RecomputeHeapAdd(m3, m4)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 6, o3.Value())
+	testutil.Equal(t, 11, o4.Value())
+	testutil.Equal(t, 1, map3Recomputes)
+	testutil.Equal(t, 2, map4Recomputes)
+}
+
 func Test_Stabilize_Map3(t *testing.T) {
 	ctx := testContext()
 	g := New()
@@ -1424,6 +2178,115 @@ func Test_Stabilize_Always_Cutoff(t *testing.T) {
 	testutil.Equal(t, "test-2", o.Value())
 }
 
+func Test_Stabilize_AlwaysThrottled_Cutoff(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	now := time.Date(2024, 01, 02, 03, 04, 05, 0, time.UTC)
+	filename := Var(g, "test")
+	filenameAlways := AlwaysThrottled(g, filename, time.Second, func(_ context.Context) time.Time {
+		return now
+	})
+	modtime := 1
+	var stats int
+	statfile := Map(g, filenameAlways, func(s string) int { stats++; return modtime })
+	statfileCutoff := Cutoff(g, statfile, func(ov, nv int) bool {
+		return ov == nv
+	})
+	readFile := Map2(g, filename, statfileCutoff, func(p string, mt int) string {
+		return fmt.Sprintf("%s-%d", p, mt)
+	})
+	o := MustObserve(g, readFile)
+
+	err := g.Stabilize(ctx)
+	testutil.Nil(t, err)
+	testutil.Equal(t, "test-1", o.Value())
+	testutil.Equal(t, 1, stats)
+
+	modtime = 2
+
+	// the file isn't stat'd again until the interval elapses.
+	err = g.Stabilize(ctx)
+	testutil.Nil(t, err)
+	testutil.Equal(t, "test-1", o.Value())
+	testutil.Equal(t, 1, stats)
+
+	now = now.Add(time.Second)
+	err = g.Stabilize(ctx)
+	testutil.Nil(t, err)
+	testutil.Equal(t, "test-2", o.Value())
+	testutil.Equal(t, 2, stats)
+
+	now = now.Add(time.Second)
+	err = g.Stabilize(ctx)
+	testutil.Nil(t, err)
+	testutil.Equal(t, "test-2", o.Value())
+	testutil.Equal(t, 3, stats)
+}
+
+func Test_Stabilize_AlwaysWhen_Cutoff(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	filename := Var(g, "test")
+	var budgetExpired bool
+	filenameAlways := AlwaysWhen(g, filename, func(_ context.Context) (bool, error) {
+		return budgetExpired, nil
+	})
+	modtime := 1
+	var statfileRecomputes int
+	statfile := Map(g, filenameAlways, func(s string) int {
+		statfileRecomputes++
+		return modtime
+	})
+	statfileCutoff := Cutoff(g, statfile, func(ov, nv int) bool {
+		return ov == nv
+	})
+	var readFileRecomputes int
+	readFile := Map2(g, filename, statfileCutoff, func(p string, mt int) string {
+		readFileRecomputes++
+		return fmt.Sprintf("%s-%d", p, mt)
+	})
+	o := MustObserve(g, readFile)
+
+	err := g.Stabilize(ctx)
+	testutil.Nil(t, err)
+	testutil.Equal(t, "test-1", o.Value())
+	testutil.Equal(t, 1, statfileRecomputes)
+	testutil.Equal(t, 1, readFileRecomputes)
+
+	modtime = 2
+
+	err = g.Stabilize(ctx)
+	testutil.Nil(t, err)
+	testutil.Equal(t, "test-1", o.Value(), "the budget hasn't expired so we shouldn't stat the file")
+	testutil.Equal(t, 1, statfileRecomputes)
+	testutil.Equal(t, 1, readFileRecomputes)
+
+	budgetExpired = true
+
+	err = g.Stabilize(ctx)
+	testutil.Nil(t, err)
+	testutil.Equal(t, "test-2", o.Value())
+	testutil.Equal(t, 2, statfileRecomputes)
+	testutil.Equal(t, 2, readFileRecomputes)
+
+	err = g.Stabilize(ctx)
+	testutil.Nil(t, err)
+	testutil.Equal(t, "test-2", o.Value())
+	testutil.Equal(t, 3, statfileRecomputes)
+	testutil.Equal(t, 2, readFileRecomputes)
+
+	budgetExpired = false
+	modtime = 3
+
+	err = g.Stabilize(ctx)
+	testutil.Nil(t, err)
+	testutil.Equal(t, "test-2", o.Value())
+	testutil.Equal(t, 3, statfileRecomputes)
+	testutil.Equal(t, 2, readFileRecomputes)
+}
+
 func Test_Stabilize_Always_Cutoff_error(t *testing.T) {
 	ctx := testContext()
 	g := New()
@@ -1551,5 +2414,5 @@ func Test_Stabilize_alwaysInRecomputeHeapOnError(t *testing.T) {
 
 	err := g.Stabilize(testContext())
 	testutil.Error(t, err)
-	testutil.Equal(t, "this is only a test", err.Error())
+	testutil.Equal(t, "this is only a test", errors.Unwrap(err).Error())
 }
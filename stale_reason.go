@@ -0,0 +1,75 @@
+package incr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StaleReason describes why a node is going to be recomputed.
+type StaleReason struct {
+	// Reason is the reason the node is going to be recomputed.
+	Reason RecomputeReason
+	// Detail is a human readable description of the reason, e.g.
+	// which of the node's inputs changed.
+	Detail string
+}
+
+// String implements fmt.Stringer.
+func (sr StaleReason) String() string {
+	if sr.Detail == "" {
+		return sr.Reason.String()
+	}
+	return sr.Reason.String() + ": " + sr.Detail
+}
+
+// IsStale returns if a given node is going to be recomputed, either because
+// it is in the recompute heap, or because it is necessary and one of its
+// inputs has changed since it was last recomputed.
+//
+// Use [Graph.StaleReason] to determine why the node is going to be recomputed.
+func (graph *Graph) IsStale(gn INode) bool {
+	return graph.StaleReason(gn).Reason != RecomputeReasonNone
+}
+
+// StaleReason returns why a given node is going to be recomputed, or a
+// reason of [RecomputeReasonNone] if the node is not going to be recomputed.
+func (graph *Graph) StaleReason(gn INode) StaleReason {
+	graph.recomputeHeap.mu.Lock()
+	n := gn.Node()
+	inRecomputeHeap := n.heightInRecomputeHeap != HeightUnset
+	reason := n.recomputeReason
+	graph.recomputeHeap.mu.Unlock()
+
+	if !inRecomputeHeap {
+		if n.recomputedAt > 0 && n.isNecessary() && n.isStaleInRespectToParent() {
+			reason = RecomputeReasonParentChanged
+		} else {
+			return StaleReason{}
+		}
+	}
+	switch reason {
+	case RecomputeReasonParentChanged:
+		var changed []string
+		for _, p := range n.parents {
+			if p.Node().changedAt > n.recomputedAt {
+				changed = append(changed, fmt.Sprint(p))
+			}
+		}
+		if len(changed) == 0 {
+			return StaleReason{Reason: reason}
+		}
+		return StaleReason{Reason: reason, Detail: "changed inputs " + strings.Join(changed, ", ")}
+	case RecomputeReasonSetStale, RecomputeReasonVarSet:
+		return StaleReason{Reason: reason, Detail: fmt.Sprintf("set at stabilization %d", n.setAt)}
+	case RecomputeReasonAlways:
+		return StaleReason{Reason: reason, Detail: "recomputed every stabilization"}
+	case RecomputeReasonLinked:
+		return StaleReason{Reason: reason, Detail: "linked to new inputs"}
+	case RecomputeReasonNecessary:
+		return StaleReason{Reason: reason, Detail: "newly observed"}
+	case RecomputeReasonInvalidated:
+		return StaleReason{Reason: reason, Detail: "an input was invalidated"}
+	default:
+		return StaleReason{Reason: reason}
+	}
+}
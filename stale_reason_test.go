@@ -0,0 +1,110 @@
+package incr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func makeStaleReasonChain(g *Graph, length int) (VarIncr[string], []Incr[string]) {
+	v0 := Var(g, ".")
+	var maps []Incr[string]
+	var previous Incr[string] = v0
+	for x := 0; x < length; x++ {
+		m := Map(g, previous, func(v0 string) string {
+			return v0 + "."
+		})
+		maps = append(maps, m)
+		previous = m
+	}
+	return v0, maps
+}
+
+func Test_Graph_StaleReason_newObserver(t *testing.T) {
+	g := New()
+	v0, maps := makeStaleReasonChain(g, 10)
+
+	testutil.Equal(t, false, g.IsStale(v0))
+	testutil.Equal(t, RecomputeReasonNone, g.StaleReason(maps[9]).Reason)
+
+	_ = MustObserve(g, maps[9])
+
+	testutil.Equal(t, false, g.IsStale(v0), "vars hold their value without being recomputed")
+	testutil.Equal(t, true, g.IsStale(maps[0]))
+	testutil.Equal(t, true, g.IsStale(maps[9]))
+	reason := g.StaleReason(maps[9])
+	testutil.Equal(t, RecomputeReasonNecessary, reason.Reason)
+	testutil.Equal(t, "necessary: newly observed", reason.String())
+
+	err := g.Stabilize(testContext())
+	testutil.NoError(t, err)
+	for _, m := range maps {
+		testutil.Equal(t, false, g.IsStale(m))
+		testutil.Equal(t, StaleReason{}, g.StaleReason(m))
+	}
+}
+
+func Test_Graph_StaleReason_varSet(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v0, maps := makeStaleReasonChain(g, 10)
+	_ = MustObserve(g, maps[9])
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.IsStale(v0))
+
+	v0.Set("..")
+	testutil.Equal(t, true, g.IsStale(v0))
+	reason := g.StaleReason(v0)
+	testutil.Equal(t, RecomputeReasonVarSet, reason.Reason)
+	testutil.Equal(t, "var_set: set at stabilization 2", reason.String())
+	testutil.Equal(t, false, g.IsStale(maps[0]), "the var hasn't changed yet")
+
+	// step through the stabilization to see the stale
+	// reason propagate to the first map in the chain.
+	s, err := g.StabilizeStart(ctx)
+	testutil.NoError(t, err)
+	_, err = s.Step()
+	testutil.NoError(t, err)
+	reason = g.StaleReason(maps[0])
+	testutil.Equal(t, RecomputeReasonParentChanged, reason.Reason)
+	testutil.Equal(t, true, strings.Contains(reason.Detail, v0.Node().ID().Short()))
+	testutil.Equal(t, false, g.IsStale(maps[1]))
+	err = s.Finish()
+	testutil.NoError(t, err)
+
+	for _, m := range maps {
+		testutil.Equal(t, false, g.IsStale(m))
+	}
+}
+
+func Test_Graph_StaleReason_setStale(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	_, maps := makeStaleReasonChain(g, 10)
+	_ = MustObserve(g, maps[9])
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+
+	g.SetStale(maps[4])
+	testutil.Equal(t, true, g.IsStale(maps[4]))
+	reason := g.StaleReason(maps[4])
+	testutil.Equal(t, RecomputeReasonSetStale, reason.Reason)
+	testutil.Equal(t, "set_stale: set at stabilization 2", reason.String())
+	testutil.Equal(t, false, g.IsStale(maps[3]))
+	testutil.Equal(t, false, g.IsStale(maps[5]))
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, false, g.IsStale(maps[4]))
+	testutil.Equal(t, RecomputeReasonSetStale, ExpertNode(maps[4]).LastRecomputeReason())
+	testutil.Equal(t, RecomputeReasonParentChanged, ExpertNode(maps[5]).LastRecomputeReason())
+}
+
+func Test_StaleReason_String(t *testing.T) {
+	testutil.Equal(t, "none", StaleReason{}.String())
+	testutil.Equal(t, "always: recomputed every stabilization", StaleReason{Reason: RecomputeReasonAlways, Detail: "recomputed every stabilization"}.String())
+}
@@ -0,0 +1,57 @@
+package incr
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync/atomic"
+)
+
+// UnnecessaryVars returns the vars that have been set while not necessary
+// and are still not necessary, that is, vars whose values are not recomputed
+// because nothing observes them, in the order they were created in.
+//
+// Vars are only tracked if the graph was created with [OptGraphStrictObservation].
+func (graph *Graph) UnnecessaryVars() (output []INode) {
+	graph.unnecessaryVarsMu.Lock()
+	defer graph.unnecessaryVarsMu.Unlock()
+	for id, n := range graph.unnecessaryVars {
+		if n.Node().isNecessary() {
+			delete(graph.unnecessaryVars, id)
+			continue
+		}
+		output = append(output, n)
+	}
+	slices.SortFunc(output, func(a, b INode) int {
+		return cmp.Compare(a.Node().order, b.Node().order)
+	})
+	return
+}
+
+// varSetUnnecessary handles a var that isn't necessary being set.
+func (graph *Graph) varSetUnnecessary(n INode) {
+	if !graph.strictObservation {
+		return
+	}
+	atomic.AddUint64(&graph.numUnnecessaryVarSets, 1)
+	graph.unnecessaryVarsMu.Lock()
+	graph.unnecessaryVars[n.Node().id] = n
+	graph.unnecessaryVarsPending = append(graph.unnecessaryVarsPending, n)
+	graph.unnecessaryVarsMu.Unlock()
+}
+
+// stabilizeStartTraceUnnecessaryVars traces the vars that were set while not
+// necessary since the last stabilization, as there is no context to trace with when
+// vars are set.
+func (graph *Graph) stabilizeStartTraceUnnecessaryVars(ctx context.Context) {
+	if !graph.strictObservation {
+		return
+	}
+	graph.unnecessaryVarsMu.Lock()
+	pending := graph.unnecessaryVarsPending
+	graph.unnecessaryVarsPending = nil
+	graph.unnecessaryVarsMu.Unlock()
+	for _, n := range pending {
+		TracePrintf(ctx, "%v was set but is not necessary, and will not be recomputed until it is observed", n)
+	}
+}
@@ -0,0 +1,98 @@
+package incr
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_OptGraphStrictObservation(t *testing.T) {
+	output := new(bytes.Buffer)
+	ctx := WithTracingOutputs(context.Background(), output, output)
+	g := New(OptGraphStrictObservation(true))
+
+	v := Var(g, "hello")
+	v.Node().SetLabel("unobserved")
+	observed := Var(g, "hello")
+	_ = MustObserve(g, observed)
+
+	v.Set("world")
+	observed.Set("world")
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+	testutil.Equal(t, uint64(1), g.Stats().NumUnnecessaryVarSets)
+	testutil.Equal(t, []INode{v}, g.UnnecessaryVars())
+	testutil.Matches(t, `var\[.*\]:unobserved@.* was set but is not necessary`, output.String())
+
+	_ = MustObserve(g, v)
+	testutil.Empty(t, g.UnnecessaryVars())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "world", v.Value())
+}
+
+func Test_OptGraphStrictObservation_disabled(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	v.Set("world")
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+	testutil.Equal(t, uint64(0), g.Stats().NumUnnecessaryVarSets)
+	testutil.Empty(t, g.UnnecessaryVars())
+}
+
+func Test_OptGraphStrictObservation_setDuringStabilization(t *testing.T) {
+	ctx := testContext()
+	g := New(OptGraphStrictObservation(true))
+
+	v := Var(g, "hello")
+	trigger := Var(g, "hello")
+	m := Map(g, trigger, func(value string) string {
+		v.Set(value)
+		return value
+	})
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+	testutil.Equal(t, "hello", v.Value())
+	testutil.Equal(t, uint64(1), g.Stats().NumUnnecessaryVarSets)
+	testutil.Equal(t, []INode{v}, g.UnnecessaryVars())
+}
+
+func Test_OptGraphStrictObservation_setDuringStabilizationDisabled(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v := Var(g, "hello")
+	trigger := Var(g, "world")
+	m := Map(g, trigger, func(value string) string {
+		v.Set(value)
+		return value
+	})
+	_ = MustObserve(g, m)
+
+	// the var isn't queued as it has no height,
+	// but still takes the value it was set to.
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+	testutil.Equal(t, "world", v.Value())
+	testutil.Equal(t, uint64(0), g.Stats().NumUnnecessaryVarSets)
+	testutil.Empty(t, g.UnnecessaryVars())
+
+	o := MustObserve(g, v)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "world", o.Value())
+}
@@ -0,0 +1,118 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// Subgraph is a set of nodes detached from a graph with [Graph.ExportSubgraph]
+// that can be attached to another graph with [Graph.ImportSubgraph].
+type Subgraph struct {
+	from     *Graph
+	root     INode
+	nodes    []INode
+	imported bool
+}
+
+// Root returns the root node of the subgraph, that is, the node
+// the subgraph was exported from.
+func (sg *Subgraph) Root() INode { return sg.root }
+
+// Nodes returns the nodes of the subgraph, starting with the root node.
+func (sg *Subgraph) Nodes() []INode { return copySlice(sg.nodes) }
+
+// ExportSubgraph detaches a given root node, along with the nodes it takes as inputs
+// transitively, from the graph such that they can be attached to another graph
+// with [Graph.ImportSubgraph], e.g. to rebalance work between graphs.
+//
+// The observers of the root node are unobserved. An error is returned if any of the other
+// nodes in the subgraph are used outside of it, that is, if they're observed or have
+// children outside of the subgraph, or if the root node has children or sentinels.
+//
+// As with [MigrateNode], the nodes must have been created within the graph's top scope
+// (i.e. not within a [Bind] function).
+//
+// It must not be called during stabilization.
+func (graph *Graph) ExportSubgraph(root INode) (*Subgraph, error) {
+	if root == nil {
+		return nil, errChildNil
+	}
+	if atomic.LoadInt32(&graph.status) != StatusNotStabilizing {
+		return nil, ErrAlreadyStabilizing
+	}
+	if GraphForNode(root) != graph {
+		return nil, fmt.Errorf("export subgraph; node %v does not belong to graph %s", root, graph.id.Short())
+	}
+	nodes := migrationNodes(graph, root)
+	inSubgraph := make(map[Identifier]struct{}, len(nodes))
+	for _, n := range nodes {
+		inSubgraph[n.Node().id] = struct{}{}
+	}
+	for _, n := range nodes {
+		nn := n.Node()
+		if nn.createdIn != Scope(graph) {
+			return nil, fmt.Errorf("export subgraph; node %v was created within a bind scope, cannot continue", n)
+		}
+		if len(nn.sentinels) > 0 {
+			return nil, fmt.Errorf("export subgraph; node %v is watched by a sentinel", n)
+		}
+		for _, c := range nn.children {
+			if _, ok := inSubgraph[c.Node().id]; !ok {
+				return nil, fmt.Errorf("export subgraph; node %v has child %v outside the subgraph", n, c)
+			}
+		}
+		if !SameNode(n, root) && len(nn.observers) > 0 {
+			return nil, fmt.Errorf("export subgraph; node %v is observed outside the subgraph", n)
+		}
+	}
+	for _, o := range root.Node().Observers() {
+		o.Unobserve(context.Background())
+	}
+	for _, n := range nodes {
+		if n.Node().isNecessary() || graph.Has(n) {
+			return nil, fmt.Errorf("export subgraph; node %v is still necessary in graph %s", n, graph.id.Short())
+		}
+	}
+	return &Subgraph{
+		from:  graph,
+		root:  root,
+		nodes: nodes,
+	}, nil
+}
+
+// ImportSubgraph attaches the nodes of a subgraph exported from another graph
+// with [Graph.ExportSubgraph] to the graph.
+//
+// If observe is true the root node of the subgraph is observed, computing its
+// height in the graph, and the observer is returned; otherwise the returned
+// observer is nil and the nodes are attached when they're observed later.
+//
+// A subgraph can only be imported once, and it must not be called
+// during the stabilization of either graph.
+func (graph *Graph) ImportSubgraph(sg *Subgraph, observe bool) (IObserver, error) {
+	if sg == nil {
+		return nil, fmt.Errorf("import subgraph; subgraph is unset")
+	}
+	if atomic.LoadInt32(&graph.status) != StatusNotStabilizing || atomic.LoadInt32(&sg.from.status) != StatusNotStabilizing {
+		return nil, ErrAlreadyStabilizing
+	}
+	if sg.imported {
+		return nil, fmt.Errorf("import subgraph; subgraph rooted at %v has already been imported", sg.root)
+	}
+	for _, n := range sg.nodes {
+		if n.Node().isNecessary() || sg.from.Has(n) {
+			return nil, fmt.Errorf("import subgraph; node %v has become necessary in graph %s", n, sg.from.id.Short())
+		}
+	}
+	migrateNodes(sg.nodes, graph)
+	sg.imported = true
+	if !observe {
+		return nil, nil
+	}
+	observers, err := graph.ObserveMany(context.Background(), sg.root)
+	if err != nil {
+		return nil, err
+	}
+	return observers[0], nil
+}
@@ -0,0 +1,142 @@
+package incr
+
+import (
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_ExportSubgraph_ImportSubgraph(t *testing.T) {
+	ctx := testContext()
+	g0 := New()
+	g1 := New()
+
+	v := Var(g0, "hello")
+	m0 := Map(g0, v, func(vv string) string { return vv + "!" })
+	m1 := Map(g0, m0, func(vv string) string { return vv + "?" })
+	o0 := MustObserve(g0, m1)
+
+	err := g0.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "hello!?", o0.Value())
+	height := m1.Node().height
+
+	sg, err := g0.ExportSubgraph(m1)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, SameNode(m1, sg.Root()))
+	testutil.Equal(t, 3, len(sg.Nodes()))
+
+	testutil.Equal(t, false, g0.Has(v))
+	testutil.Equal(t, false, g0.Has(m0))
+	testutil.Equal(t, false, g0.Has(m1))
+	testutil.Equal(t, false, g0.HasObserver(o0))
+	testutil.Equal(t, 0, g0.numNodes)
+
+	o1, err := g1.ImportSubgraph(sg, true)
+	testutil.NoError(t, err)
+	testutil.NotNil(t, o1)
+	testutil.Equal(t, g1, GraphForNode(v))
+	testutil.Equal(t, true, g1.Has(v))
+	testutil.Equal(t, true, g1.Has(m0))
+	testutil.Equal(t, true, g1.Has(m1))
+	testutil.Equal(t, height, m1.Node().height)
+
+	v.Set("world")
+	err = g1.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "world!?", m1.Value())
+	testutil.NoError(t, g1.Validate())
+
+	err = g0.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.NoError(t, g0.Validate())
+
+	_, err = g1.ImportSubgraph(sg, true)
+	testutil.Error(t, err, "subgraphs can only be imported once")
+}
+
+func Test_Graph_ImportSubgraph_withoutObserving(t *testing.T) {
+	ctx := testContext()
+	g0 := New()
+	g1 := New()
+
+	v := Var(g0, 1)
+	m := Map(g0, v, func(vv int) int { return vv * 2 })
+	_ = MustObserve(g0, m)
+
+	sg, err := g0.ExportSubgraph(m)
+	testutil.NoError(t, err)
+
+	o, err := g1.ImportSubgraph(sg, false)
+	testutil.NoError(t, err)
+	testutil.Nil(t, o)
+	testutil.Equal(t, false, g1.Has(m))
+
+	om := MustObserve(g1, m)
+	err = g1.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 2, om.Value())
+}
+
+func Test_Graph_ExportSubgraph_shared(t *testing.T) {
+	g := New()
+
+	v := Var(g, "hello")
+	m0 := Map(g, v, ident)
+	m1 := Map(g, m0, ident)
+	other := Map(g, m0, ident)
+	_ = MustObserve(g, m1)
+	_ = MustObserve(g, other)
+
+	_, err := g.ExportSubgraph(m1)
+	testutil.Error(t, err)
+	testutil.Equal(t, true, g.Has(m1))
+	testutil.Equal(t, true, g.Has(m0))
+
+	_ = MustObserve(g, v)
+	_, err = g.ExportSubgraph(other)
+	testutil.Error(t, err, "the var is observed outside of the subgraph")
+
+	_, err = g.ExportSubgraph(m0)
+	testutil.Error(t, err, "the root has children")
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_Graph_ExportSubgraph_differentGraph(t *testing.T) {
+	g0 := New()
+	g1 := New()
+
+	m := Map(g0, Var(g0, "hello"), ident)
+	_, err := g1.ExportSubgraph(m)
+	testutil.Error(t, err)
+
+	_, err = g1.ExportSubgraph(nil)
+	testutil.Error(t, err)
+
+	_, err = g1.ImportSubgraph(nil, true)
+	testutil.Error(t, err)
+}
+
+func Test_Graph_ExportSubgraph_ImportSubgraph_whileStabilizing(t *testing.T) {
+	ctx := testContext()
+	g0 := New()
+	g1 := New()
+
+	v := Var(g1, "hello")
+	m := Map(g1, v, ident)
+	sg, err := g1.ExportSubgraph(m)
+	testutil.NoError(t, err)
+
+	var exportErr, importErr error
+	mg0 := Map(g0, Var(g0, "a"), func(vv string) string {
+		_, exportErr = g0.ExportSubgraph(v)
+		_, importErr = g0.ImportSubgraph(sg, true)
+		return vv
+	})
+	_ = MustObserve(g0, mg0)
+
+	err = g0.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, ErrAlreadyStabilizing, exportErr)
+	testutil.Equal(t, ErrAlreadyStabilizing, importErr)
+}
@@ -0,0 +1,23 @@
+package incr
+
+import "fmt"
+
+// Switch returns an incremental that yields the value of the input
+// at the position given by the value of an index incremental.
+//
+// Like [MapIfLazy], only the selected input is linked into the graph, and as
+// a result changes to inputs that are not selected do not cause the switch
+// node to recompute, and those inputs are not recomputed unless they are
+// necessary for other reasons.
+//
+// If the index is out of range, the switch node returns an error when it is
+// recomputed, calling its error handlers, and yields its previous value.
+func Switch[A any](scope Scope, index Incr[int], inputs ...Incr[A]) Incr[A] {
+	return newLazySelect(scope, "switch", index, func() (Incr[A], error) {
+		i := index.Value()
+		if i < 0 || i >= len(inputs) {
+			return nil, fmt.Errorf("switch; index %d out of range for %d inputs", i, len(inputs))
+		}
+		return inputs[i], nil
+	})
+}
@@ -0,0 +1,107 @@
+package incr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Switch(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	index := Var(g, 0)
+	vars := make([]VarIncr[string], 0, 3)
+	inputs := make([]Incr[string], 0, 3)
+	recomputes := make([]int, 3)
+	for x := 0; x < 3; x++ {
+		x := x
+		v := Var(g, fmt.Sprintf("input-%d", x))
+		vars = append(vars, v)
+		inputs = append(inputs, Map(g, v, func(vv string) string {
+			recomputes[x]++
+			return vv
+		}))
+	}
+	s := Switch(g, index, inputs...)
+	testutil.Equal(t, "switch", s.Node().Kind())
+	var switchRecomputes int
+	s.Node().OnUpdate(func(_ context.Context) {
+		switchRecomputes++
+	})
+	o := MustObserve(g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "input-0", o.Value())
+	testutil.Equal(t, []int{1, 0, 0}, recomputes)
+	testutil.Equal(t, 1, switchRecomputes)
+
+	vars[1].Set("input-1-changed")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "input-0", o.Value())
+	testutil.Equal(t, []int{1, 0, 0}, recomputes)
+	testutil.Equal(t, 1, switchRecomputes)
+
+	index.Set(1)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "input-1-changed", o.Value())
+	testutil.Equal(t, []int{1, 1, 0}, recomputes)
+	testutil.Equal(t, 2, switchRecomputes)
+	testutil.Equal(t, false, g.Has(inputs[0]))
+
+	vars[1].Set("input-1-changed-again")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "input-1-changed-again", o.Value())
+	testutil.Equal(t, 3, switchRecomputes)
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_Switch_outOfRange(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	index := Var(g, 0)
+	s := Switch(g, index, Return(g, "a"), Return(g, "b"))
+	var errs []error
+	s.Node().OnError(func(_ context.Context, err error) {
+		errs = append(errs, err)
+	})
+	o := MustObserve(g, s)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "a", o.Value())
+
+	index.Set(2)
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, 1, len(errs))
+	testutil.Matches(t, "out of range", errs[0].Error())
+	testutil.Equal(t, "a", o.Value())
+
+	index.Set(-1)
+	err = g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, 2, len(errs))
+
+	index.Set(1)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "b", o.Value())
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_Switch_noInputs(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	_ = MustObserve(g, Switch[string](g, Var(g, 0)))
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+}
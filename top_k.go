@@ -0,0 +1,209 @@
+package incr
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// TopK returns an incremental whose value is the (up to) k greatest values of a given
+// list of input incrementals as ordered by a given less function, greatest first; for
+// k=1 it is an incremental maximum. Inputs can be added and removed after the node is
+// created with [MapNIncr.AddInput] and [MapNIncr.RemoveInput], e.g. to maintain a leaderboard.
+//
+// The inputs are kept in two heaps, one holding the top k values and the other the
+// rest, such that when the node is recomputed only the inputs that changed since it was
+// last recomputed (or were added or removed) are moved within or between the heaps.
+//
+// The order of inputs with equal values is unspecified.
+func TopK[A any](scope Scope, less func(A, A) bool, k int, inputs ...Incr[A]) MapNIncr[A, []A] {
+	return WithinScope(scope, &topKIncr[A]{
+		n:      NewNode("top_k"),
+		less:   less,
+		k:      k,
+		inputs: newDynamicInputs[A, *topKMember[A]](inputs),
+	})
+}
+
+var (
+	_ MapNIncr[int, []int] = (*topKIncr[int])(nil)
+	_ IParents             = (*topKIncr[int])(nil)
+	_ IStabilize           = (*topKIncr[int])(nil)
+	_ fmt.Stringer         = (*topKIncr[int])(nil)
+)
+
+type topKIncr[A any] struct {
+	n    *Node
+	less func(A, A) bool
+	k    int
+	// inputs holds the inputs, and for each
+	// the input's entry in the heaps.
+	inputs dynamicInputs[A, *topKMember[A]]
+	// top holds the top k members with the least of them at its root,
+	// and rest holds the other members with the greatest at its root.
+	top  *topKHeap[A]
+	rest *topKHeap[A]
+	// topChanged is set when the members of the top heap (or their values)
+	// change, such that the value of the node must be updated.
+	topChanged bool
+	value      []A
+}
+
+type topKMember[A any] struct {
+	value A
+	inTop bool
+	index int
+}
+
+func (tk *topKIncr[A]) Parents() []INode {
+	return tk.inputs.parents()
+}
+
+func (tk *topKIncr[A]) AddInput(i Incr[A]) error {
+	return tk.inputs.add(tk, i)
+}
+
+func (tk *topKIncr[A]) RemoveInput(id Identifier) error {
+	return tk.inputs.remove(tk, id, tk.remove)
+}
+
+func (tk *topKIncr[A]) Node() *Node { return tk.n }
+
+// Value returns the top k values, greatest first; the returned
+// slice must not be modified.
+func (tk *topKIncr[A]) Value() []A { return tk.value }
+
+func (tk *topKIncr[A]) Stabilize(_ context.Context) error {
+	if tk.top == nil || tk.inputs.stale(tk) {
+		tk.reset()
+	}
+	tk.inputs.eachChanged(func(value A, m **topKMember[A], seen bool) {
+		if !seen {
+			*m = &topKMember[A]{value: value}
+			tk.insert(*m)
+			return
+		}
+		(*m).value = value
+		tk.update(*m)
+	})
+	if tk.topChanged {
+		tk.updateValue()
+	}
+	return nil
+}
+
+func (tk *topKIncr[A]) String() string { return tk.n.String() }
+
+// reset clears the heaps such that every input is inserted again.
+func (tk *topKIncr[A]) reset() {
+	tk.top = &topKHeap[A]{less: tk.less, top: true}
+	tk.rest = &topKHeap[A]{less: tk.less}
+	tk.inputs.reset()
+	tk.topChanged = true
+}
+
+func (tk *topKIncr[A]) insert(m *topKMember[A]) {
+	m.inTop = true
+	heap.Push(tk.top, m)
+	tk.topChanged = true
+	tk.rebalance()
+}
+
+func (tk *topKIncr[A]) update(m *topKMember[A]) {
+	if m.inTop {
+		heap.Fix(tk.top, m.index)
+		tk.topChanged = true
+	} else {
+		heap.Fix(tk.rest, m.index)
+	}
+	tk.rebalance()
+}
+
+func (tk *topKIncr[A]) remove(m *topKMember[A]) {
+	if m.inTop {
+		heap.Remove(tk.top, m.index)
+		tk.topChanged = true
+	} else {
+		heap.Remove(tk.rest, m.index)
+	}
+	tk.rebalance()
+}
+
+// rebalance moves members between the heaps until the top heap holds
+// (up to) k members, all of which are at least the members in the rest heap.
+func (tk *topKIncr[A]) rebalance() {
+	for tk.top.Len() > max(tk.k, 0) {
+		tk.moveTo(tk.rest, heap.Pop(tk.top).(*topKMember[A]))
+	}
+	for tk.top.Len() < tk.k && tk.rest.Len() > 0 {
+		tk.moveTo(tk.top, heap.Pop(tk.rest).(*topKMember[A]))
+	}
+	for tk.top.Len() > 0 && tk.rest.Len() > 0 && tk.less(tk.top.members[0].value, tk.rest.members[0].value) {
+		worst := heap.Pop(tk.top).(*topKMember[A])
+		best := heap.Pop(tk.rest).(*topKMember[A])
+		tk.moveTo(tk.top, best)
+		tk.moveTo(tk.rest, worst)
+	}
+}
+
+func (tk *topKIncr[A]) moveTo(h *topKHeap[A], m *topKMember[A]) {
+	m.inTop = h.top
+	heap.Push(h, m)
+	tk.topChanged = true
+}
+
+// updateValue sets the value of the node from the members of the top heap.
+//
+// A new slice is allocated such that nodes holding on to the
+// previous value don't see it change out from under them.
+func (tk *topKIncr[A]) updateValue() {
+	tk.topChanged = false
+	value := make([]A, len(tk.top.members))
+	for index, m := range tk.top.members {
+		value[index] = m.value
+	}
+	sort.SliceStable(value, func(i, j int) bool {
+		return tk.less(value[j], value[i])
+	})
+	tk.value = value
+}
+
+// topKHeap implements [heap.Interface] for the members of a [TopK] node.
+//
+// If top is set the least member is at the root of the heap,
+// otherwise the greatest member is.
+type topKHeap[A any] struct {
+	members []*topKMember[A]
+	less    func(A, A) bool
+	top     bool
+}
+
+func (h *topKHeap[A]) Len() int { return len(h.members) }
+
+func (h *topKHeap[A]) Less(i, j int) bool {
+	if h.top {
+		return h.less(h.members[i].value, h.members[j].value)
+	}
+	return h.less(h.members[j].value, h.members[i].value)
+}
+
+func (h *topKHeap[A]) Swap(i, j int) {
+	h.members[i], h.members[j] = h.members[j], h.members[i]
+	h.members[i].index = i
+	h.members[j].index = j
+}
+
+func (h *topKHeap[A]) Push(x any) {
+	m := x.(*topKMember[A])
+	m.index = len(h.members)
+	h.members = append(h.members, m)
+}
+
+func (h *topKHeap[A]) Pop() any {
+	last := len(h.members) - 1
+	m := h.members[last]
+	h.members[last] = nil
+	h.members = h.members[:last]
+	return m
+}
@@ -0,0 +1,178 @@
+package incr
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_TopK(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	const count = 100
+	var reads int
+	vars := make([]VarIncr[int], count)
+	inputs := make([]Incr[int], count)
+	for x := 0; x < count; x++ {
+		vars[x] = Var(g, x)
+		inputs[x] = readCounter(g, vars[x], &reads)
+	}
+	tk := TopK(g, lessInt, 3, inputs...)
+	o := MustObserve(g, tk)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{99, 98, 97}, o.Value())
+
+	testutil.Equal(t, count, reads)
+
+	// a change outside the top k only reads the changed input.
+	vars[10].Set(-10)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{99, 98, 97}, o.Value())
+	testutil.Equal(t, count+1, reads)
+
+	vars[10].Set(1000)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{1000, 99, 98}, o.Value())
+
+	vars[99].Set(0)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{1000, 98, 97}, o.Value())
+
+	vars[98].Set(500)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{1000, 500, 97}, o.Value())
+}
+
+func Test_TopK_matchesSort(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	r := rand.New(rand.NewSource(1234))
+	const count = 50
+	vars := make([]VarIncr[int], count)
+	inputs := make([]Incr[int], count)
+	for x := 0; x < count; x++ {
+		vars[x] = Var(g, r.Intn(1000))
+		inputs[x] = vars[x]
+	}
+	o := MustObserve(g, TopK(g, lessInt, 5, inputs...))
+
+	for round := 0; round < 100; round++ {
+		for x := 0; x < 1+r.Intn(3); x++ {
+			vars[r.Intn(count)].Set(r.Intn(1000))
+		}
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+		testutil.Equal(t, topKOfVars(vars, 5), o.Value(), round)
+	}
+}
+
+func Test_TopK_max(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 3)
+	v1 := Var(g, 7)
+	v2 := Var(g, 5)
+	o := MustObserve(g, TopK[int](g, lessInt, 1, v0, v1, v2))
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{7}, o.Value())
+
+	v1.Set(1)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{5}, o.Value())
+}
+
+func Test_TopK_addRemoveInput(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 1)
+	v1 := Var(g, 2)
+	v2 := Var(g, 3)
+	tk := TopK[int](g, lessInt, 2, v0)
+	o := MustObserve(g, tk)
+	_ = MustObserve(g, v2)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{1}, o.Value())
+
+	err = tk.AddInput(v1)
+	testutil.NoError(t, err)
+	err = tk.AddInput(v2)
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{3, 2}, o.Value())
+
+	err = tk.RemoveInput(v2.Node().ID())
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{2, 1}, o.Value())
+
+	err = tk.RemoveInput(v0.Node().ID())
+	testutil.NoError(t, err)
+	err = tk.RemoveInput(v1.Node().ID())
+	testutil.NoError(t, err)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{}, o.Value())
+	testutil.NoError(t, g.Validate())
+
+	err = tk.RemoveInput(v0.Node().ID())
+	testutil.Equal(t, ErrInputNotFound, err)
+}
+
+func Test_TopK_RemoveInput_beforeObserve(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	v0 := Var(g, 3)
+	v1 := Var(g, 2)
+	v2 := Var(g, 1)
+	tk := TopK(g, lessInt, 2, v0, v1, v2)
+
+	err := tk.RemoveInput(v0.Node().ID())
+	testutil.NoError(t, err)
+
+	o := MustObserve(g, tk)
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{2, 1}, o.Value())
+	testutil.Equal(t, false, g.Has(v0))
+}
+
+func Test_TopK_zero(t *testing.T) {
+	ctx := testContext()
+	g := New()
+
+	o := MustObserve(g, TopK[int](g, lessInt, 0, Var(g, 1), Var(g, 2)))
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{}, o.Value())
+}
+
+func lessInt(a, b int) bool { return a < b }
+
+func topKOfVars(vars []VarIncr[int], k int) []int {
+	values := make([]int, len(vars))
+	for index, v := range vars {
+		values[index] = v.Value()
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(values)))
+	return values[:k]
+}
@@ -14,6 +14,28 @@ type Tracer interface {
 	Error(...any)
 }
 
+// StructuredTracer is a [Tracer] that also receives structured events
+// for individual nodes during stabilization.
+//
+// If the tracer added to a context with [WithTracer] implements this interface
+// the stabilization methods will call these in addition to the text output methods.
+//
+// Note that during parallel stabilization these methods may be called
+// from multiple goroutines at once.
+type StructuredTracer interface {
+	Tracer
+	// OnRecompute is called before a node's stabilize function is called.
+	OnRecompute(ctx context.Context, node NodeMetadata)
+	// OnCutoff is called when a node's cutoff function prevents it from recomputing.
+	OnCutoff(ctx context.Context, node NodeMetadata)
+	// OnError is called when a node's stabilize or cutoff function returns an error.
+	OnError(ctx context.Context, node NodeMetadata, err error)
+	// OnBind is called when a bind node changes its right-hand side.
+	//
+	// The rhs will be the zero value if the bind function returned nil.
+	OnBind(ctx context.Context, bind NodeMetadata, rhs NodeMetadata)
+}
+
 type tracerKey struct{}
 
 // WithTracing adds a default tracer to a given context.
@@ -51,6 +73,15 @@ func GetTracer(ctx context.Context) Tracer {
 	return nil
 }
 
+// GetStructuredTracer returns the tracer from a given context if it
+// implements [StructuredTracer], and nil if it does not or if one is not present.
+func GetStructuredTracer(ctx context.Context) StructuredTracer {
+	if typed, ok := GetTracer(ctx).(StructuredTracer); ok {
+		return typed
+	}
+	return nil
+}
+
 // TracePrintln prints a line to the tracer on a given context.
 func TracePrintln(ctx context.Context, args ...any) {
 	if tracer := GetTracer(ctx); tracer != nil {
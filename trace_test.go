@@ -3,22 +3,24 @@ package incr
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
 
-	. "github.com/wcharczuk/go-incr/testutil"
+	"github.com/wcharczuk/go-incr/testutil"
 )
 
 func Test_WithTracing(t *testing.T) {
 	ctx := context.Background()
 	tr := GetTracer(ctx)
-	Nil(t, tr)
+	testutil.Nil(t, tr)
 
 	ctx = WithTracing(ctx)
 	tr = GetTracer(ctx)
-	NotNil(t, tr)
-	NotNil(t, tr.(*tracer).log)
-	NotNil(t, tr.(*tracer).errLog)
+	testutil.NotNil(t, tr)
+	testutil.NotNil(t, tr.(*tracer).log)
+	testutil.NotNil(t, tr.(*tracer).errLog)
 }
 
 func Test_WithTracingOutput(t *testing.T) {
@@ -26,27 +28,145 @@ func Test_WithTracingOutput(t *testing.T) {
 	errOutput := new(bytes.Buffer)
 
 	tr := GetTracer(context.Background())
-	Nil(t, tr)
+	testutil.Nil(t, tr)
 
 	ctx := WithTracingOutputs(context.Background(), output, errOutput)
 	tr = GetTracer(ctx)
-	NotNil(t, tr)
-	NotNil(t, tr.(*tracer).log)
-	NotNil(t, tr.(*tracer).errLog)
+	testutil.NotNil(t, tr)
+	testutil.NotNil(t, tr.(*tracer).log)
+	testutil.NotNil(t, tr.(*tracer).errLog)
 
 	TracePrintln(ctx, "this is a println test")
-	Equal(t, true, strings.Contains(output.String(), "this is a println test"))
-	Equal(t, "", errOutput.String())
+	testutil.Equal(t, true, strings.Contains(output.String(), "this is a println test"))
+	testutil.Equal(t, "", errOutput.String())
 
 	TraceErrorln(ctx, "this is a errorln test")
-	Equal(t, false, strings.Contains(output.String(), "this is a errorln test"))
-	Equal(t, true, strings.Contains(errOutput.String(), "this is a errorln test"))
+	testutil.Equal(t, false, strings.Contains(output.String(), "this is a errorln test"))
+	testutil.Equal(t, true, strings.Contains(errOutput.String(), "this is a errorln test"))
 
 	TracePrintf(ctx, "this is a %s test", "printf")
-	Equal(t, true, strings.Contains(output.String(), "this is a printf test"))
-	Equal(t, false, strings.Contains(errOutput.String(), "this is a printf test"))
+	testutil.Equal(t, true, strings.Contains(output.String(), "this is a printf test"))
+	testutil.Equal(t, false, strings.Contains(errOutput.String(), "this is a printf test"))
 
 	TraceErrorf(ctx, "this is a %s test", "errorf")
-	Equal(t, false, strings.Contains(output.String(), "this is a errorf test"))
-	Equal(t, true, strings.Contains(errOutput.String(), "this is a errorf test"))
+	testutil.Equal(t, false, strings.Contains(output.String(), "this is a errorf test"))
+	testutil.Equal(t, true, strings.Contains(errOutput.String(), "this is a errorf test"))
+}
+
+type structuredTracerEvent struct {
+	Kind  string
+	Label string
+	Err   error
+}
+
+type recordingStructuredTracer struct {
+	mu     sync.Mutex
+	events []structuredTracerEvent
+}
+
+func (r *recordingStructuredTracer) Print(...any) {}
+func (r *recordingStructuredTracer) Error(...any) {}
+
+func (r *recordingStructuredTracer) record(kind, label string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, structuredTracerEvent{Kind: kind, Label: label, Err: err})
+}
+
+func (r *recordingStructuredTracer) OnRecompute(_ context.Context, node NodeMetadata) {
+	r.record("recompute", node.Label, nil)
+}
+
+func (r *recordingStructuredTracer) OnCutoff(_ context.Context, node NodeMetadata) {
+	r.record("cutoff", node.Label, nil)
+}
+
+func (r *recordingStructuredTracer) OnError(_ context.Context, node NodeMetadata, err error) {
+	r.record("error", node.Label, err)
+}
+
+func (r *recordingStructuredTracer) OnBind(_ context.Context, bind NodeMetadata, rhs NodeMetadata) {
+	r.record("bind", bind.Label+"->"+rhs.Label, nil)
+}
+
+func (r *recordingStructuredTracer) has(kind, label string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.events {
+		if e.Kind == kind && e.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+func Test_GetStructuredTracer(t *testing.T) {
+	testutil.Nil(t, GetStructuredTracer(context.Background()))
+	testutil.Nil(t, GetStructuredTracer(WithTracing(context.Background())))
+
+	rt := new(recordingStructuredTracer)
+	testutil.NotNil(t, GetStructuredTracer(WithTracer(context.Background(), rt)))
+}
+
+func Test_StructuredTracer(t *testing.T) {
+	rt := new(recordingStructuredTracer)
+	ctx := WithTracer(testContext(), rt)
+	g := New()
+
+	v := Var(g, "a")
+	v.Node().SetLabel("v")
+	c := Cutoff(g, v, func(_, _ string) bool { return false })
+	c.Node().SetLabel("c")
+
+	a := Return(g, "a-value")
+	a.Node().SetLabel("a")
+	b := Return(g, "b-value")
+	b.Node().SetLabel("b")
+	bind := Bind(g, c, func(_ Scope, which string) Incr[string] {
+		if which == "a" {
+			return a
+		}
+		return b
+	})
+	bind.Node().SetLabel("bind")
+	_ = MustObserve(g, bind)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, rt.has("recompute", "c"))
+	// return nodes are constants, and are not recomputed.
+	testutil.Equal(t, false, rt.has("recompute", "a"))
+	testutil.Equal(t, true, rt.has("recompute", "bind"))
+	testutil.Equal(t, true, rt.has("bind", "bind->a"))
+	testutil.Equal(t, false, rt.has("bind", "bind->b"))
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, rt.has("bind", "bind->b"))
+	testutil.Nil(t, g.structuredTracer)
+}
+
+func Test_StructuredTracer_cutoffAndError(t *testing.T) {
+	rt := new(recordingStructuredTracer)
+	ctx := WithTracer(testContext(), rt)
+	g := New()
+
+	v := Var(g, "a")
+	c := Cutoff(g, v, func(_, _ string) bool { return true })
+	c.Node().SetLabel("c")
+	m := MapContext(g, c, func(_ context.Context, _ string) (string, error) {
+		return "", fmt.Errorf("this is only a test")
+	})
+	m.Node().SetLabel("m")
+	_ = MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.Error(t, err)
+	testutil.Equal(t, true, rt.has("error", "m"))
+
+	v.Set("b")
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, true, rt.has("cutoff", "c"))
 }
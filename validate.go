@@ -0,0 +1,122 @@
+package incr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Validate checks the graph for structural inconsistencies, returning an
+// error describing every inconsistency found (or nil if there are none).
+//
+// Specifically it checks that:
+//   - every child has a height greater than each of its parents.
+//   - the parents and necessary children of tracked nodes are tracked by the graph.
+//   - the observers of tracked nodes are tracked by the graph, and each tracked
+//     observer is observing a tracked node.
+//   - the recompute heap is consistent with the heights of the nodes in it.
+//
+// Validate is intended as a debugging aid and test assertion, e.g. after
+// changing graph structure with [Link] and [Unlink], and should not be
+// called while the graph is stabilizing.
+func (graph *Graph) Validate() error {
+	graph.nodesMu.Lock()
+	nodes := make([]INode, 0, len(graph.nodes))
+	for _, n := range graph.nodes {
+		nodes = append(nodes, n)
+	}
+	graph.nodesMu.Unlock()
+	slices.SortFunc(nodes, nodeSorter)
+
+	graph.observersMu.Lock()
+	observers := make([]INode, 0, len(graph.observers))
+	for _, o := range graph.observers {
+		observers = append(observers, o)
+	}
+	graph.observersMu.Unlock()
+	slices.SortFunc(observers, nodeSorter)
+
+	var errs []error
+	observing := make(map[Identifier]struct{}, len(observers))
+	for _, n := range nodes {
+		nn := n.Node()
+		for _, p := range nn.parents {
+			if !graph.HasID(p.Node().id) {
+				errs = append(errs, fmt.Errorf("validate; node %v has parent %v that is not tracked by the graph", n, p))
+			}
+			if p.Node().height >= nn.height {
+				errs = append(errs, fmt.Errorf("validate; node %v has height %d but parent %v has height %d", n, nn.height, p, p.Node().height))
+			}
+		}
+		for _, c := range nn.children {
+			if c.Node().isNecessary() && !graph.HasID(c.Node().id) {
+				errs = append(errs, fmt.Errorf("validate; node %v has necessary child %v that is not tracked by the graph", n, c))
+			}
+			if c.Node().height <= nn.height {
+				errs = append(errs, fmt.Errorf("validate; node %v has height %d but child %v has height %d", n, nn.height, c, c.Node().height))
+			}
+		}
+		for _, o := range nn.observers {
+			observing[o.Node().id] = struct{}{}
+			if !graph.HasObserver(o) {
+				errs = append(errs, fmt.Errorf("validate; node %v has observer %v that is not tracked by the graph", n, o))
+			}
+		}
+	}
+	for _, o := range observers {
+		if _, ok := observing[o.Node().id]; !ok {
+			errs = append(errs, fmt.Errorf("validate; observer %v is not observing a node tracked by the graph", o))
+		}
+	}
+
+	graph.recomputeHeap.mu.Lock()
+	if err := graph.recomputeHeap.sanityCheck(); err != nil {
+		errs = append(errs, err)
+	}
+	graph.recomputeHeap.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// SanityCheck checks the graph's internal bookkeeping for consistency,
+// returning an error describing every inconsistency found (or nil if there are none).
+//
+// Specifically it checks that:
+//   - the recompute heap is consistent with the heights of the nodes in it.
+//   - parent and child links are symmetric, that is each parent of a tracked
+//     node lists the node as a child, and each child lists the node as a parent.
+//
+// Like [Graph.Validate] it should not be called while the graph is stabilizing.
+func (graph *Graph) SanityCheck() error {
+	graph.nodesMu.Lock()
+	nodes := make([]INode, 0, len(graph.nodes))
+	for _, n := range graph.nodes {
+		nodes = append(nodes, n)
+	}
+	graph.nodesMu.Unlock()
+	slices.SortFunc(nodes, nodeSorter)
+
+	var errs []error
+	graph.recomputeHeap.mu.Lock()
+	if err := graph.recomputeHeap.sanityCheck(); err != nil {
+		errs = append(errs, err)
+	}
+	graph.recomputeHeap.mu.Unlock()
+
+	hasNode := func(nodes []INode, id Identifier) bool {
+		return slices.ContainsFunc(nodes, func(n INode) bool { return n.Node().id == id })
+	}
+	for _, n := range nodes {
+		nn := n.Node()
+		for _, p := range nn.parents {
+			if !hasNode(p.Node().children, nn.id) {
+				errs = append(errs, fmt.Errorf("sanity check; node %v has parent %v that does not list it as a child", n, p))
+			}
+		}
+		for _, c := range nn.children {
+			if !hasNode(c.Node().parents, nn.id) {
+				errs = append(errs, fmt.Errorf("sanity check; node %v has child %v that does not list it as a parent", n, c))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
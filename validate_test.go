@@ -0,0 +1,128 @@
+package incr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wcharczuk/go-incr/testutil"
+)
+
+func Test_Graph_Validate(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v0 := Var(g, "foo")
+	v1 := Var(g, "bar")
+	sw := Var(g, true)
+	b := Bind(g, sw, func(bs Scope, swv bool) Incr[string] {
+		if swv {
+			return Map(bs, v0, ident)
+		}
+		return Map2(bs, v0, v1, concat)
+	})
+	m := Map(g, b, ident)
+	_ = Sentinel(g, func() bool { return false }, m)
+	_ = MustObserve(g, m)
+	_ = MustObserve(g, v1)
+
+	testutil.NoError(t, g.Validate())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.NoError(t, g.Validate())
+
+	sw.Set(false)
+	testutil.NoError(t, g.Validate())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.NoError(t, g.Validate())
+}
+
+func Test_Graph_Validate_heights(t *testing.T) {
+	g := New()
+	v0 := Var(g, "foo")
+	m0 := Map(g, v0, ident)
+	m1 := Map(g, m0, ident)
+	_ = MustObserve(g, m1)
+	testutil.NoError(t, g.Validate())
+
+	m0.Node().height = 3
+
+	err := g.Validate()
+	testutil.Error(t, err)
+	// we should report both the parent and the child side of each inconsistency.
+	testutil.Equal(t, 2, strings.Count(err.Error(), "validate; node"))
+	testutil.Equal(t, true, strings.Contains(err.Error(), "has height 2 but parent"))
+	testutil.Equal(t, true, strings.Contains(err.Error(), "has height 3 but child"))
+	testutil.Equal(t, true, strings.Contains(err.Error(), "recompute heap; sanity check"))
+}
+
+func Test_Graph_Validate_untracked(t *testing.T) {
+	g := New()
+	v0 := Var(g, "foo")
+	m0 := Map(g, v0, ident)
+	m1 := Map(g, m0, ident)
+	o := MustObserve(g, m1)
+	testutil.NoError(t, g.Validate())
+
+	g.nodesMu.Lock()
+	delete(g.nodes, m0.Node().id)
+	g.nodesMu.Unlock()
+	g.removeObserver(o)
+
+	err := g.Validate()
+	testutil.Error(t, err)
+	testutil.Equal(t, true, strings.Contains(err.Error(), "has parent "+m0.(*mapIncr[string, string]).String()+" that is not tracked"))
+	testutil.Equal(t, true, strings.Contains(err.Error(), "has necessary child "+m0.(*mapIncr[string, string]).String()+" that is not tracked"))
+	testutil.Equal(t, true, strings.Contains(err.Error(), "that is not tracked by the graph"))
+	testutil.Equal(t, 3, strings.Count(err.Error(), "\n")+1)
+}
+
+func Test_Graph_Validate_orphanedObserver(t *testing.T) {
+	g := New()
+	v0 := Var(g, "foo")
+	o := MustObserve(g, v0)
+	testutil.NoError(t, g.Validate())
+
+	v0.Node().removeObserver(o.Node().id)
+
+	err := g.Validate()
+	testutil.Error(t, err)
+	testutil.Equal(t, true, strings.Contains(err.Error(), "is not observing a node tracked by the graph"))
+}
+
+func Test_Graph_SanityCheck(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v0 := Var(g, "foo")
+	m0 := Map(g, v0, ident)
+	m1 := Map(g, m0, ident)
+	_ = MustObserve(g, m1)
+	testutil.NoError(t, g.SanityCheck())
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.NoError(t, g.SanityCheck())
+
+	m1.Node().addParents(v0)
+	m0.Node().addChildren(v0)
+
+	err = g.SanityCheck()
+	testutil.Error(t, err)
+	testutil.Equal(t, true, strings.Contains(err.Error(), "does not list it as a child"))
+	testutil.Equal(t, true, strings.Contains(err.Error(), "does not list it as a parent"))
+}
+
+func Test_Graph_SanityCheck_recomputeHeap(t *testing.T) {
+	g := New()
+	v0 := Var(g, "foo")
+	m0 := Map(g, v0, ident)
+	_ = MustObserve(g, m0)
+	testutil.Equal(t, true, g.recomputeHeap.has(m0))
+
+	m0.Node().height = 3
+
+	err := g.SanityCheck()
+	testutil.Error(t, err)
+	testutil.Equal(t, true, strings.Contains(err.Error(), "recompute heap; sanity check"))
+}
@@ -2,7 +2,9 @@ package incr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"sync/atomic"
 )
 
@@ -29,6 +31,34 @@ type VarIncr[T any] interface {
 	//
 	// Calling [Set] will invalidate any nodes that reference this variable.
 	Set(T)
+
+	// SetIfChangedFunc sets the var value only if the given value differs
+	// from the current value as determined by a given equality function,
+	// returning if the value was set.
+	//
+	// If the values are equal, the var is not marked stale, and nodes
+	// that reference this variable will not be recomputed.
+	SetIfChangedFunc(T, func(T, T) bool) bool
+
+	// Update sets the var value to the result of a given function applied
+	// to the latest value of the var, e.g. to increment a counter.
+	//
+	// If the var was set during the stabilization in progress, the function
+	// is applied to the value it was set to rather than to [Value], such that
+	// updates made during a stabilization compose in the order they're made.
+	//
+	// Calls to Set, SetIfChangedFunc and Update are serialized, and
+	// the function must not call Set or Update on the var itself.
+	Update(func(T) T)
+}
+
+// SetIfChanged sets the value of a var only if the given value differs
+// from the current value, returning if the value was set.
+//
+// It is a lighter-weight alternative to a downstream [Cutoff] node for
+// vars that are frequently set to the value they already hold.
+func SetIfChanged[T comparable](v VarIncr[T], value T) bool {
+	return v.SetIfChangedFunc(value, func(a, b T) bool { return a == b })
 }
 
 var (
@@ -36,13 +66,17 @@ var (
 	_ IShouldBeInvalidated = (*varIncr[string])(nil)
 	_ IStale               = (*varIncr[string])(nil)
 	_ IStabilize           = (*varIncr[string])(nil)
+	_ recordingSetter      = (*varIncr[string])(nil)
 	_ fmt.Stringer         = (*varIncr[string])(nil)
 )
 
 type varIncr[T any] struct {
-	n                           *Node
-	setAt                       uint64
-	value                       T
+	n     *Node
+	setAt uint64
+	value T
+	// mu serializes sets of the var, and guards the
+	// value the var was set to during stabilization.
+	mu                          sync.Mutex
 	setDuringStabilizationValue T
 	setDuringStabilization      bool
 }
@@ -56,27 +90,112 @@ func (vn *varIncr[T]) ShouldBeInvalidated() bool {
 }
 
 func (vn *varIncr[T]) Set(v T) {
-	graph := GraphForNode(vn)
-	if atomic.LoadInt32(&graph.status) == StatusStabilizing {
+	vn.mu.Lock()
+	duringStabilization := vn.setUnsafe(v)
+	vn.mu.Unlock()
+	vn.markSet(v, duringStabilization)
+}
+
+func (vn *varIncr[T]) Update(fn func(T) T) {
+	vn.mu.Lock()
+	v := fn(vn.latestValueUnsafe())
+	duringStabilization := vn.setUnsafe(v)
+	vn.mu.Unlock()
+	vn.markSet(v, duringStabilization)
+}
+
+func (vn *varIncr[T]) SetIfChangedFunc(v T, eq func(T, T) bool) bool {
+	vn.mu.Lock()
+	if eq(vn.latestValueUnsafe(), v) {
+		vn.mu.Unlock()
+		return false
+	}
+	duringStabilization := vn.setUnsafe(v)
+	vn.mu.Unlock()
+	vn.markSet(v, duringStabilization)
+	return true
+}
+
+// latestValueUnsafe returns the value the var was last set to, which is
+// the value it was set to during the stabilization in progress if any,
+// including while the update handlers run at the end of the stabilization.
+//
+// It must be called while holding the var's lock.
+func (vn *varIncr[T]) latestValueUnsafe() T {
+	if vn.setDuringStabilization {
+		return vn.setDuringStabilizationValue
+	}
+	return vn.value
+}
+
+// setUnsafe sets the value of the var, or if a stabilization is in progress
+// the value the var takes once it ends, returning if a stabilization is in progress.
+//
+// Once the var has a pending value it's replaced rather than the current value
+// until the stabilization ends, e.g. if the var is set by an update handler.
+//
+// It must be called while holding the var's lock.
+func (vn *varIncr[T]) setUnsafe(v T) (duringStabilization bool) {
+	if vn.setDuringStabilization || atomic.LoadInt32(&GraphForNode(vn).status) == StatusStabilizing {
 		vn.setDuringStabilizationValue = v
 		vn.setDuringStabilization = true
+		return true
+	}
+	vn.value = v
+	return false
+}
 
+// markSet tells the graph the var was set by [varIncr.setUnsafe].
+//
+// It must be called without holding the var's lock, as the graph
+// recomputes the vars set during stabilization while holding its own lock.
+func (vn *varIncr[T]) markSet(v T, duringStabilization bool) {
+	graph := GraphForNode(vn)
+	graph.record(RecordingEventSet, vn, func(e *RecordingEvent) {
+		e.Value = v
+	})
+	if duringStabilization {
 		graph.setDuringStabilizationMu.Lock()
 		graph.setDuringStabilization[vn.Node().id] = vn
 		graph.setDuringStabilizationMu.Unlock()
+		graph.notifyChanged()
 		return
 	}
-	vn.value = v
 	if vn.n.isNecessary() {
-		graph.SetStale(vn)
+		graph.setStale(vn, RecomputeReasonVarSet)
+	} else {
+		graph.varSetUnnecessary(vn)
 	}
 }
 
+// setRecorded implements recordingSetter.
+//
+// Values that aren't of the var's type, e.g. because they were decoded
+// from JSON, are converted by encoding them to JSON and decoding them as the type.
+func (vn *varIncr[T]) setRecorded(value any) error {
+	if typed, ok := value.(T); ok {
+		vn.Set(typed)
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var typed T
+	if err = json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	vn.Set(typed)
+	return nil
+}
+
 func (vn *varIncr[T]) Node() *Node { return vn.n }
 
 func (vn *varIncr[T]) Value() T { return vn.value }
 
 func (vn *varIncr[T]) Stabilize(ctx context.Context) error {
+	vn.mu.Lock()
+	defer vn.mu.Unlock()
 	if vn.setDuringStabilization {
 		var zero T
 		vn.value = vn.setDuringStabilizationValue
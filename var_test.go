@@ -2,6 +2,7 @@ package incr
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/wcharczuk/go-incr/testutil"
@@ -92,3 +93,131 @@ func Test_Var_ShouldBeInvalidated(t *testing.T) {
 	v := Var(g, "foo")
 	testutil.Equal(t, false, v.(*varIncr[string]).ShouldBeInvalidated())
 }
+
+func Test_Var_SetIfChanged(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, "foo")
+	m := Map(g, v, ident)
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+	setAt := v.Node().setAt
+
+	testutil.Equal(t, false, SetIfChanged(v, "foo"))
+	testutil.Equal(t, false, SetIfChanged(v, "foo"))
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+	testutil.Equal(t, setAt, v.Node().setAt)
+
+	testutil.Equal(t, true, SetIfChanged(v, "bar"))
+	testutil.Equal(t, 1, g.recomputeHeap.len())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "bar", o.Value())
+}
+
+func Test_Var_SetIfChangedFunc(t *testing.T) {
+	g := New()
+	v := Var(g, []string{"foo"})
+	_ = MustObserve(g, v)
+	g.recomputeHeap.clear()
+
+	testutil.Equal(t, false, v.SetIfChangedFunc([]string{"foo"}, CutoffSliceEqual[string]))
+	testutil.Equal(t, 0, g.recomputeHeap.len())
+
+	testutil.Equal(t, true, v.SetIfChangedFunc([]string{"foo", "bar"}, CutoffSliceEqual[string]))
+	testutil.Equal(t, 1, g.recomputeHeap.len())
+	testutil.Equal(t, []string{"foo", "bar"}, v.Value())
+}
+
+func Test_Var_Update(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 1)
+	o := MustObserve(g, v)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 1, o.Value())
+
+	v.Update(func(v int) int { return v + 1 })
+	v.Update(func(v int) int { return v * 10 })
+	testutil.Equal(t, 20, v.Value())
+	testutil.Equal(t, 1, g.recomputeHeap.len())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 20, o.Value())
+}
+
+func Test_Var_Update_concurrentDuringStabilization(t *testing.T) {
+	g := New()
+	v := Var(g, 0)
+	_ = MustObserve(g, v)
+	g.status = StatusStabilizing
+
+	// updates made from different goroutines shouldn't be lost.
+	const workers, updates = 8, 100
+	var wg sync.WaitGroup
+	for x := 0; x < workers; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := 0; y < updates; y++ {
+				v.Update(func(v int) int { return v + 1 })
+				_ = v.SetIfChangedFunc(0, func(_, _ int) bool { return true })
+			}
+		}()
+	}
+	wg.Wait()
+	testutil.Equal(t, true, v.(*varIncr[int]).setDuringStabilization)
+	testutil.Equal(t, workers*updates, v.(*varIncr[int]).setDuringStabilizationValue)
+}
+
+func Test_Var_SetIfChanged_duringStabilization(t *testing.T) {
+	g := New()
+	v := Var(g, "foo")
+	_ = MustObserve(g, v)
+	g.status = StatusStabilizing
+
+	testutil.Equal(t, false, SetIfChanged(v, "foo"))
+	testutil.Equal(t, false, v.(*varIncr[string]).setDuringStabilization)
+
+	testutil.Equal(t, true, SetIfChanged(v, "bar"))
+	testutil.Equal(t, true, v.(*varIncr[string]).setDuringStabilization)
+
+	// compare against the pending value, not the current value.
+	testutil.Equal(t, false, SetIfChanged(v, "bar"))
+	testutil.Equal(t, true, SetIfChanged(v, "foo"))
+	testutil.Equal(t, "foo", v.(*varIncr[string]).setDuringStabilizationValue)
+}
+
+func Test_Var_Update_inUpdateHandler(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 0)
+	m := Map(g, v, func(value int) int {
+		if value == 0 {
+			v.Set(10)
+		}
+		return value
+	})
+	m.Node().OnUpdate(func(_ context.Context) {
+		// the update handlers run after the stabilization has
+		// finished recomputing, but before the var takes the value
+		// it was set to, and should start from that value.
+		v.Update(func(value int) int { return value + 1 })
+	})
+	o := MustObserve(g, m)
+
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 0, o.Value())
+
+	err = g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 11, o.Value())
+}
@@ -7,24 +7,58 @@ import (
 
 // Watch returns a new watch incremental that tracks
 // values for a given incremental each time it stabilizes.
-func Watch[A any](scope Scope, i Incr[A]) WatchIncr[A] {
+//
+// By default the watch node retains every value it sees; use
+// [WatchWithCapacity] to retain only the most recent values.
+func Watch[A any](scope Scope, i Incr[A], opts ...WatchOption) WatchIncr[A] {
+	var options WatchOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	return WithinScope(scope, &watchIncr[A]{
-		n:    NewNode("watch"),
-		incr: i,
+		n:        NewNode("watch"),
+		incr:     i,
+		capacity: options.Capacity,
 	})
 }
 
+// WatchOption mutates [WatchOptions].
+type WatchOption func(*WatchOptions)
+
+// WatchWithCapacity sets the maximum number of values a [Watch] node
+// will retain, after which the oldest values are discarded as new
+// values are seen.
+//
+// A capacity of zero or less means values are retained indefinitely.
+func WatchWithCapacity(capacity int) func(*WatchOptions) {
+	return func(wo *WatchOptions) {
+		wo.Capacity = capacity
+	}
+}
+
+// WatchOptions are options for [Watch] nodes.
+type WatchOptions struct {
+	Capacity int
+}
+
 // WatchIncr is a type that implements the watch interface.
 type WatchIncr[A any] interface {
 	Incr[A]
 
 	// Reset empties the tracked values.
+	//
+	// The current value of the node is left as is.
 	Reset()
 
 	// Values returns the input incremental values the [Watch] node
-	// has seen through stabilization passes. This array of values will
+	// has seen through stabilization passes, oldest first. Unless the node
+	// was created with [WatchWithCapacity], this array of values will
 	// continue to grow until you call [Reset] on the node.
 	Values() []A
+
+	// Last returns up to the most recent n values the [Watch] node
+	// has seen, oldest first.
+	Last(n int) []A
 }
 
 var (
@@ -39,6 +73,11 @@ type watchIncr[A any] struct {
 	incr   Incr[A]
 	value  A
 	values []A
+
+	// capacity, if positive, makes values a ring buffer
+	// whose oldest element is at index head.
+	capacity int
+	head     int
 }
 
 func (w *watchIncr[A]) Parents() []INode {
@@ -51,16 +90,39 @@ func (w *watchIncr[A]) Value() A {
 
 func (w *watchIncr[A]) Stabilize(ctx context.Context) error {
 	w.value = w.incr.Value()
+	if w.capacity > 0 && len(w.values) == w.capacity {
+		w.values[w.head] = w.value
+		w.head = (w.head + 1) % w.capacity
+		return nil
+	}
 	w.values = append(w.values, w.value)
 	return nil
 }
 
 func (w *watchIncr[A]) Reset() {
 	w.values = nil
+	w.head = 0
 }
 
 func (w *watchIncr[A]) Values() []A {
-	return w.values
+	if w.head == 0 {
+		return w.values
+	}
+	return w.Last(len(w.values))
+}
+
+func (w *watchIncr[A]) Last(n int) []A {
+	if n > len(w.values) {
+		n = len(w.values)
+	}
+	if n <= 0 {
+		return nil
+	}
+	output := make([]A, 0, n)
+	for x := len(w.values) - n; x < len(w.values); x++ {
+		output = append(output, w.values[(w.head+x)%len(w.values)])
+	}
+	return output
 }
 
 func (w *watchIncr[A]) Node() *Node {
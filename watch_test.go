@@ -15,3 +15,61 @@ func Test_Watch(t *testing.T) {
 
 	testutil.Matches(t, "watch\\[.*\\]:w0", w0.(fmt.Stringer).String())
 }
+
+func Test_Watch_unbounded(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 0)
+	w := Watch(g, v)
+	_ = MustObserve(g, w)
+
+	for x := 1; x <= 5; x++ {
+		v.Set(x)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+	}
+	testutil.Equal(t, []int{1, 2, 3, 4, 5}, w.Values())
+	testutil.Equal(t, []int{4, 5}, w.Last(2))
+	testutil.Equal(t, []int{1, 2, 3, 4, 5}, w.Last(10))
+	testutil.Equal(t, 0, len(w.Last(0)))
+}
+
+func Test_Watch_capacity(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 0)
+	w := Watch(g, v, WatchWithCapacity(3))
+	_ = MustObserve(g, w)
+
+	for x := 1; x <= 10; x++ {
+		v.Set(x)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+	}
+	testutil.Equal(t, 10, w.Value())
+	testutil.Equal(t, []int{8, 9, 10}, w.Values())
+	testutil.Equal(t, []int{9, 10}, w.Last(2))
+	testutil.Equal(t, []int{8, 9, 10}, w.Last(5))
+}
+
+func Test_Watch_Reset(t *testing.T) {
+	ctx := testContext()
+	g := New()
+	v := Var(g, 0)
+	w := Watch(g, v, WatchWithCapacity(3))
+	_ = MustObserve(g, w)
+
+	for x := 1; x <= 4; x++ {
+		v.Set(x)
+		err := g.Stabilize(ctx)
+		testutil.NoError(t, err)
+	}
+	w.Reset()
+	testutil.Empty(t, w.Values())
+	testutil.Equal(t, 4, w.Value())
+
+	v.Set(5)
+	err := g.Stabilize(ctx)
+	testutil.NoError(t, err)
+	testutil.Equal(t, []int{5}, w.Values())
+}